@@ -77,6 +77,10 @@ func setDefaultValue(v *viper.Viper) {
 	v.SetDefault("TAP_PROPERTIES_DIR", "resources/tap")
 	v.SetDefault("ENABLE_REMOTE_PLUGINS", "true")
 	v.SetDefault("REMOTE_PLUGINS_STARTUP_PATH", "python/plugins/start.sh")
+	v.SetDefault("SMTP_PORT", "25")
+	v.SetDefault("API_CORS_ALLOW_ORIGINS", "*")
+	v.SetDefault("OIDC_USERNAME_CLAIM", "email")
+	v.SetDefault("OIDC_ROLE_CLAIM", "roles")
 }
 
 // replaceNewEnvItemInOldContent replace old config to new config in env file content
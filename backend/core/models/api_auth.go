@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"time"
+)
+
+const (
+	// API_ROLE_VIEWER could read pipelines, blueprints, projects and the data collected
+	API_ROLE_VIEWER = "viewer"
+	// API_ROLE_OPERATOR could also trigger, cancel, rerun and resume pipelines and feed webhooks
+	API_ROLE_OPERATOR = "operator"
+	// API_ROLE_ADMIN could do everything, including managing connections, blueprints, migrations and pushing data
+	API_ROLE_ADMIN = "admin"
+)
+
+// ApiRoleLevels ranks the roles, a role is granted everything granted to the roles of lower levels
+var ApiRoleLevels = map[string]int{
+	API_ROLE_VIEWER:   1,
+	API_ROLE_OPERATOR: 2,
+	API_ROLE_ADMIN:    3,
+}
+
+// ApiToken authenticates the callers of the REST API, only the sha256 hash of the token is stored
+type ApiToken struct {
+	common.Model
+	Name        string     `json:"name" gorm:"type:varchar(255)" validate:"required"`
+	Role        string     `json:"role" gorm:"type:varchar(20)" validate:"oneof=viewer operator admin"`
+	TokenPrefix string     `json:"tokenPrefix" gorm:"type:varchar(20)"`
+	TokenHash   string     `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+	Creator     string     `json:"creator" gorm:"type:varchar(255)"`
+	ExpiredAt   *time.Time `json:"expiredAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+}
+
+func (ApiToken) TableName() string {
+	return "_devlake_api_tokens"
+}
+
+// ApiAuditLog records who called which endpoint of the REST API
+type ApiAuditLog struct {
+	ID         uint64    `json:"id" gorm:"primaryKey"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"index"`
+	Principal  string    `json:"principal" gorm:"type:varchar(255);index"`
+	Role       string    `json:"role" gorm:"type:varchar(20)"`
+	AuthMethod string    `json:"authMethod" gorm:"type:varchar(20)"`
+	Method     string    `json:"method" gorm:"type:varchar(10)"`
+	Route      string    `json:"route" gorm:"type:varchar(255)"`
+	Path       string    `json:"path"`
+	StatusCode int       `json:"statusCode"`
+	ClientIp   string    `json:"clientIp" gorm:"type:varchar(50)"`
+	LatencyMs  int64     `json:"latencyMs"`
+}
+
+func (ApiAuditLog) TableName() string {
+	return "_devlake_api_audit_logs"
+}
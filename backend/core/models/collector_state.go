@@ -28,6 +28,14 @@ type CollectorLatestState struct {
 	RawDataTable       string    `gorm:"primaryKey;column:raw_data_table;type:varchar(255)" json:"raw_data_table"`
 	CreatedDateAfter   *time.Time
 	LatestSuccessStart *time.Time
+	// TimeRanges are ranges of created date which have been collected, so a wider window could be backfilled
+	TimeRanges []TimeRange `gorm:"type:text;serializer:json"`
+}
+
+// TimeRange is the range [After, Before), nil means unbounded
+type TimeRange struct {
+	After  *time.Time `json:"after"`
+	Before *time.Time `json:"before"`
 }
 
 func (CollectorLatestState) TableName() string {
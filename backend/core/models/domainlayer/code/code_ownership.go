@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	PATH_TYPE_FILE      = "FILE"
+	PATH_TYPE_DIRECTORY = "DIRECTORY"
+)
+
+// CodeOwnership is the number of current lines of a file or a directory last changed by an author according to git blame
+type CodeOwnership struct {
+	RepoId      string `gorm:"primaryKey;type:varchar(255)"`
+	Path        string `gorm:"primaryKey;type:varchar(255)"`
+	AuthorEmail string `gorm:"primaryKey;type:varchar(255)"`
+	PathType    string `gorm:"type:varchar(20)"`
+	AuthorName  string `gorm:"type:varchar(255)"`
+	Lines       int
+	Ratio       float64
+	common.NoPKModel
+}
+
+func (CodeOwnership) TableName() string {
+	return "code_ownerships"
+}
+
+// CodeOwnershipMetric summarizes the ownership and the churn of a file or a directory, the root directory is `.`
+type CodeOwnershipMetric struct {
+	RepoId            string `gorm:"primaryKey;type:varchar(255)"`
+	Path              string `gorm:"primaryKey;type:varchar(255)"`
+	PathType          string `gorm:"type:varchar(20)"`
+	Lines             int
+	FileCount         int
+	OwnerCount        int
+	BusFactor         int    `gorm:"comment:the least number of authors owning more than half of the lines"`
+	PrimaryOwnerEmail string `gorm:"type:varchar(255)"`
+	PrimaryOwnerRatio float64
+	ChurnLines        int     `gorm:"comment:lines rewritten within the churn days of being authored"`
+	HotspotScore      float64 `gorm:"comment:normalized churn times normalized size, files only"`
+	IsHotspot         bool
+	common.NoPKModel
+}
+
+func (CodeOwnershipMetric) TableName() string {
+	return "code_ownership_metrics"
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"time"
+)
+
+const (
+	DORA_DEPLOYMENT_FREQUENCY = "DEPLOYMENT_FREQUENCY"
+	DORA_CHANGE_LEAD_TIME     = "CHANGE_LEAD_TIME"
+	DORA_CHANGE_FAILURE_RATE  = "CHANGE_FAILURE_RATE"
+	DORA_TIME_TO_RESTORE      = "TIME_TO_RESTORE"
+)
+
+const (
+	DORA_PERIOD_DAY   = "DAY"
+	DORA_PERIOD_WEEK  = "WEEK"
+	DORA_PERIOD_MONTH = "MONTH"
+)
+
+const (
+	DORA_LEVEL_ELITE  = "ELITE"
+	DORA_LEVEL_HIGH   = "HIGH"
+	DORA_LEVEL_MEDIUM = "MEDIUM"
+	DORA_LEVEL_LOW    = "LOW"
+)
+
+// ProjectDoraMetric is a daily/weekly/monthly rollup of one of the four DORA metrics of a project
+type ProjectDoraMetric struct {
+	ProjectName string    `gorm:"primaryKey;type:varchar(100)"`
+	Metric      string    `gorm:"primaryKey;type:varchar(50)"`
+	Period      string    `gorm:"primaryKey;type:varchar(20)"`
+	PeriodStart time.Time `gorm:"primaryKey"`
+	// Value is the number of deployment days for DEPLOYMENT_FREQUENCY, the median minutes for
+	// CHANGE_LEAD_TIME and TIME_TO_RESTORE, and the incident/deployment ratio for CHANGE_FAILURE_RATE
+	Value *float64
+	// SampleCount is the number of deployments, pull requests or incidents the value was computed from
+	SampleCount int
+	Level       string `gorm:"type:varchar(20)"`
+	common.NoPKModel
+}
+
+func (ProjectDoraMetric) TableName() string {
+	return "project_dora_metrics"
+}
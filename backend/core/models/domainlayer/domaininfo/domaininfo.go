@@ -31,6 +31,8 @@ type Tabler interface {
 func GetDomainTablesInfo() []Tabler {
 	return []Tabler{
 		// code
+		&code.CodeOwnership{},
+		&code.CodeOwnershipMetric{},
 		&code.Commit{},
 		&code.CommitFile{},
 		&code.CommitFileComponent{},
@@ -45,6 +47,9 @@ func GetDomainTablesInfo() []Tabler {
 		&code.RefCommit{},
 		&code.FinishedCommitsDiff{},
 		&code.RefsPrCherrypick{},
+		&code.Release{},
+		&code.ReleaseCommit{},
+		&code.ReleasePullRequest{},
 		&code.Repo{},
 		&code.RepoCommit{},
 		&code.RepoLanguage{},
@@ -53,9 +58,11 @@ func GetDomainTablesInfo() []Tabler {
 		&crossdomain.BoardRepo{},
 		&crossdomain.IssueCommit{},
 		&crossdomain.IssueRepoCommit{},
+		&crossdomain.ProjectDoraMetric{},
 		&crossdomain.ProjectMapping{},
 		&crossdomain.PullRequestIssue{},
 		&crossdomain.RefsIssuesDiffs{},
+		&crossdomain.ReleaseIssue{},
 		&crossdomain.Team{},
 		&crossdomain.TeamUser{},
 		&crossdomain.User{},
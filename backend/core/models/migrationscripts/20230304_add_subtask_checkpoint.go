@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"gorm.io/datatypes"
+)
+
+var _ plugin.MigrationScript = (*addSubtaskCheckpoint)(nil)
+
+type addSubtaskCheckpoint struct{}
+
+type task20230304 struct {
+	CompletedSubtasks datatypes.JSON
+}
+
+func (task20230304) TableName() string {
+	return "_devlake_tasks"
+}
+
+type subtask20230304 struct {
+	Status string `gorm:"type:varchar(100)"`
+}
+
+func (subtask20230304) TableName() string {
+	return "_devlake_subtasks"
+}
+
+func (script *addSubtaskCheckpoint) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.AutoMigrate(&task20230304{})
+	if err != nil {
+		return err
+	}
+	return db.AutoMigrate(&subtask20230304{})
+}
+
+func (*addSubtaskCheckpoint) Version() uint64 {
+	return 20230304000001
+}
+
+func (*addSubtaskCheckpoint) Name() string {
+	return "add checkpoint of subtasks to resume a task"
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addNotificationChannel)(nil)
+
+type addNotificationChannel struct{}
+
+type notification20230306 struct {
+	ChannelId uint64 `gorm:"index"`
+}
+
+func (notification20230306) TableName() string {
+	return "_devlake_notifications"
+}
+
+func (script *addNotificationChannel) Up(basicRes context.BasicRes) errors.Error {
+	err := basicRes.GetDal().AutoMigrate(&notification20230306{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(basicRes, &archived.NotificationChannel{})
+}
+
+func (*addNotificationChannel) Version() uint64 {
+	return 20230306000001
+}
+
+func (*addNotificationChannel) Name() string {
+	return "add _devlake_notification_channels"
+}
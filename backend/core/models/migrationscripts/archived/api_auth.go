@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type ApiToken struct {
+	Model
+	Name        string `gorm:"type:varchar(255)"`
+	Role        string `gorm:"type:varchar(20)"`
+	TokenPrefix string `gorm:"type:varchar(20)"`
+	TokenHash   string `gorm:"type:varchar(64);uniqueIndex"`
+	Creator     string `gorm:"type:varchar(255)"`
+	ExpiredAt   *time.Time
+	LastUsedAt  *time.Time
+}
+
+func (ApiToken) TableName() string {
+	return "_devlake_api_tokens"
+}
+
+type ApiAuditLog struct {
+	ID         uint64    `gorm:"primaryKey"`
+	CreatedAt  time.Time `gorm:"index"`
+	Principal  string    `gorm:"type:varchar(255);index"`
+	Role       string    `gorm:"type:varchar(20)"`
+	AuthMethod string    `gorm:"type:varchar(20)"`
+	Method     string    `gorm:"type:varchar(10)"`
+	Route      string    `gorm:"type:varchar(255)"`
+	Path       string
+	StatusCode int
+	ClientIp   string `gorm:"type:varchar(50)"`
+	LatencyMs  int64
+}
+
+func (ApiAuditLog) TableName() string {
+	return "_devlake_api_audit_logs"
+}
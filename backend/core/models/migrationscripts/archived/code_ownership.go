@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+type CodeOwnership struct {
+	RepoId      string `gorm:"primaryKey;type:varchar(255)"`
+	Path        string `gorm:"primaryKey;type:varchar(255)"`
+	AuthorEmail string `gorm:"primaryKey;type:varchar(255)"`
+	PathType    string `gorm:"type:varchar(20)"`
+	AuthorName  string `gorm:"type:varchar(255)"`
+	Lines       int
+	Ratio       float64
+	NoPKModel
+}
+
+func (CodeOwnership) TableName() string {
+	return "code_ownerships"
+}
+
+type CodeOwnershipMetric struct {
+	RepoId            string `gorm:"primaryKey;type:varchar(255)"`
+	Path              string `gorm:"primaryKey;type:varchar(255)"`
+	PathType          string `gorm:"type:varchar(20)"`
+	Lines             int
+	FileCount         int
+	OwnerCount        int
+	BusFactor         int
+	PrimaryOwnerEmail string `gorm:"type:varchar(255)"`
+	PrimaryOwnerRatio float64
+	ChurnLines        int
+	HotspotScore      float64
+	IsHotspot         bool
+	NoPKModel
+}
+
+func (CodeOwnershipMetric) TableName() string {
+	return "code_ownership_metrics"
+}
@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+type NotificationChannel struct {
+	Model
+	Name         string `gorm:"type:varchar(255)"`
+	Type         string `gorm:"type:varchar(20)"`
+	ProjectName  string `gorm:"type:varchar(255);index"`
+	BlueprintId  uint64 `gorm:"index"`
+	Endpoint     string
+	Secret       string
+	Recipients   string
+	Template     string `gorm:"type:text"`
+	FailuresOnly bool
+	Enable       bool
+}
+
+func (NotificationChannel) TableName() string {
+	return "_devlake_notification_channels"
+}
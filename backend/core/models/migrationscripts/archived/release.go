@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import "time"
+
+type Release struct {
+	DomainEntity
+	RepoId            string `gorm:"index;type:varchar(255)"`
+	Name              string `gorm:"type:varchar(255)"`
+	TagName           string `gorm:"type:varchar(255)"`
+	CommitSha         string `gorm:"type:varchar(40)"`
+	Description       string
+	Url               string `gorm:"type:varchar(255)"`
+	AuthorId          string `gorm:"type:varchar(255)"`
+	IsDraft           bool
+	IsPrerelease      bool
+	CreatedDate       time.Time
+	PublishedDate     *time.Time
+	PreviousReleaseId string `gorm:"type:varchar(255)"`
+}
+
+func (Release) TableName() string {
+	return "releases"
+}
+
+type ReleaseCommit struct {
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha string `gorm:"primaryKey;type:varchar(40)"`
+	NoPKModel
+}
+
+func (ReleaseCommit) TableName() string {
+	return "release_commits"
+}
+
+type ReleasePullRequest struct {
+	ReleaseId     string `gorm:"primaryKey;type:varchar(255)"`
+	PullRequestId string `gorm:"primaryKey;type:varchar(255)"`
+	NoPKModel
+}
+
+func (ReleasePullRequest) TableName() string {
+	return "release_pull_requests"
+}
+
+type ReleaseIssue struct {
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	NoPKModel
+}
+
+func (ReleaseIssue) TableName() string {
+	return "release_issues"
+}
@@ -70,5 +70,13 @@ func All() []plugin.MigrationScript {
 		new(renameProjectMetrics),
 		new(addOriginalTypeToIssue221230),
 		new(addSecurityTesting),
+		new(addProjectDoraMetric),
+		new(addTimeRangesToCollectorState),
+		new(addSubtaskCheckpoint),
+		new(addRawDataRetentionPolicy),
+		new(addNotificationChannel),
+		new(addApiAuth),
+		new(addReleases),
+		new(addCodeOwnership),
 	}
 }
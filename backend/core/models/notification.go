@@ -31,6 +31,7 @@ const (
 type Notification struct {
 	common.Model
 	Type         NotificationType
+	ChannelId    uint64
 	Endpoint     string
 	Nonce        string
 	ResponseCode int
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	// NOTIFICATION_CHANNEL_WEBHOOK posts the signed PipelineNotification json to the endpoint
+	NOTIFICATION_CHANNEL_WEBHOOK = "WEBHOOK"
+	// NOTIFICATION_CHANNEL_SLACK posts the rendered message to a Slack incoming webhook
+	NOTIFICATION_CHANNEL_SLACK = "SLACK"
+	// NOTIFICATION_CHANNEL_TEAMS posts the rendered message to a Microsoft Teams incoming webhook
+	NOTIFICATION_CHANNEL_TEAMS = "TEAMS"
+	// NOTIFICATION_CHANNEL_EMAIL sends the rendered message to the recipients by the SMTP server configured
+	NOTIFICATION_CHANNEL_EMAIL = "EMAIL"
+)
+
+// NotificationChannel is where pipeline notifications would be sent to.
+// A channel receives notifications of pipelines of the blueprint if BlueprintId was set, or of all blueprints
+// of the project if ProjectName was set, or of all pipelines if neither was set.
+type NotificationChannel struct {
+	common.Model
+	Name        string `json:"name" gorm:"type:varchar(255)" validate:"required"`
+	Type        string `json:"type" gorm:"type:varchar(20)" validate:"oneof=WEBHOOK SLACK TEAMS EMAIL"`
+	ProjectName string `json:"projectName" gorm:"type:varchar(255);index"`
+	BlueprintId uint64 `json:"blueprintId" gorm:"index"`
+	// Endpoint is the url of the webhook, required by all types but EMAIL
+	Endpoint string `json:"endpoint" gorm:"serializer:encdec"`
+	// Secret signs the payload of WEBHOOK channels
+	Secret string `json:"secret" gorm:"serializer:encdec"`
+	// Recipients is a comma separated list of email addresses, required by EMAIL
+	Recipients string `json:"recipients"`
+	// Template is a go text/template rendering the message with services.PipelineNotificationMessage,
+	// a default template would be used if empty, it is ignored by WEBHOOK
+	Template     string `json:"template" gorm:"type:text"`
+	FailuresOnly bool   `json:"failuresOnly"`
+	Enable       bool   `json:"enable"`
+}
+
+func (NotificationChannel) TableName() string {
+	return "_devlake_notification_channels"
+}
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"time"
+)
+
+// RawDataRetentionPolicy decides how long the data in `_raw_*` tables would be kept.
+// A policy applies to a single raw table if RawTable was set, or to all raw tables of the Plugin if Plugin was set,
+// or to all raw tables if neither was set. The most specific enabled policy wins.
+type RawDataRetentionPolicy struct {
+	common.Model
+	Plugin   string `json:"plugin" gorm:"type:varchar(100)"`
+	RawTable string `json:"rawTable" gorm:"type:varchar(255)"`
+	// RetentionDays deletes the data collected more than the given days ago, 0 means no limit
+	RetentionDays int `json:"retentionDays" validate:"min=0"`
+	// KeepLatestOnly deletes the data superseded by later response of the same request (url and input)
+	// of the same raw_data_params
+	KeepLatestOnly bool       `json:"keepLatestOnly"`
+	Enable         bool       `json:"enable"`
+	LastPurgedAt   *time.Time `json:"lastPurgedAt"`
+	LastPurgedRows int64      `json:"lastPurgedRows"`
+}
+
+func (RawDataRetentionPolicy) TableName() string {
+	return "_devlake_raw_data_retention_policies"
+}
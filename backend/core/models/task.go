@@ -58,13 +58,15 @@ type Task struct {
 	Progress       float32             `json:"progress"`
 	ProgressDetail *TaskProgressDetail `json:"progressDetail" gorm:"-"`
 
-	FailedSubTask string     `json:"failedSubTask"`
-	PipelineId    uint64     `json:"pipelineId" gorm:"index"`
-	PipelineRow   int        `json:"pipelineRow"`
-	PipelineCol   int        `json:"pipelineCol"`
-	BeganAt       *time.Time `json:"beganAt"`
-	FinishedAt    *time.Time `json:"finishedAt" gorm:"index"`
-	SpentSeconds  int        `json:"spentSeconds"`
+	FailedSubTask string `json:"failedSubTask"`
+	// CompletedSubtasks were completed by the task resumed by this one, they would be skipped
+	CompletedSubtasks datatypes.JSON `json:"completedSubtasks"`
+	PipelineId        uint64         `json:"pipelineId" gorm:"index"`
+	PipelineRow       int            `json:"pipelineRow"`
+	PipelineCol       int            `json:"pipelineCol"`
+	BeganAt           *time.Time     `json:"beganAt"`
+	FinishedAt        *time.Time     `json:"finishedAt" gorm:"index"`
+	SpentSeconds      int            `json:"spentSeconds"`
 }
 
 type NewTask struct {
@@ -74,6 +76,8 @@ type NewTask struct {
 	PipelineRow int    `json:"-"`
 	PipelineCol int    `json:"-"`
 	IsRerun     bool   `json:"-"`
+	// CompletedSubtasks would be skipped when the task is resumed
+	CompletedSubtasks []string `json:"-"`
 }
 
 type Subtask struct {
@@ -84,6 +88,7 @@ type Subtask struct {
 	BeganAt      *time.Time `json:"beganAt"`
 	FinishedAt   *time.Time `json:"finishedAt" gorm:"index"`
 	SpentSeconds int64      `json:"spentSeconds"`
+	Status       string     `json:"status" gorm:"type:varchar(100)"`
 }
 
 func (Task) TableName() string {
@@ -100,6 +105,15 @@ func (task *Task) GetSubTasks() ([]string, errors.Error) {
 	return subtasks, err
 }
 
+func (task *Task) GetCompletedSubtasks() ([]string, errors.Error) {
+	var subtasks []string
+	if len(task.CompletedSubtasks) == 0 {
+		return subtasks, nil
+	}
+	err := errors.Convert(json.Unmarshal(task.CompletedSubtasks, &subtasks))
+	return subtasks, err
+}
+
 func (task *Task) GetOptions() (map[string]interface{}, errors.Error) {
 	var options map[string]interface{}
 	err := errors.Convert(json.Unmarshal([]byte(task.Options), &options))
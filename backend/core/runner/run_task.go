@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/telemetry"
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
@@ -160,18 +161,9 @@ func RunPluginTask(
 	)
 }
 
-// RunPluginSubTasks FIXME ...
-func RunPluginSubTasks(
-	ctx gocontext.Context,
-	basicRes context.BasicRes,
-	task *models.Task,
-	pluginTask plugin.PluginTask,
-	progress chan plugin.RunningProgress,
-) errors.Error {
-	logger := basicRes.GetLogger()
-	logger.Info("start plugin")
-	// find out all possible subtasks this plugin can offer
-	subtaskMetas := pluginTask.SubTaskMetas()
+// GetSubtasksFlag returns which subtasks would be run, subtasks enabled by default are run if none was specified,
+// and `Required` subtasks are always run
+func GetSubtasksFlag(subtaskMetas []plugin.SubTaskMeta, specifiedTasks []string) (map[string]bool, errors.Error) {
 	subtasksFlag := make(map[string]bool)
 	for _, subtaskMeta := range subtaskMetas {
 		subtasksFlag[subtaskMeta.Name] = subtaskMeta.EnabledByDefault
@@ -184,38 +176,69 @@ func RunPluginSubTasks(
 	}
 	*/
 
+	if len(specifiedTasks) > 0 {
+		// first, disable all subtasks
+		for task := range subtasksFlag {
+			subtasksFlag[task] = false
+		}
+		// second, check specified subtasks is valid and enable them if so
+		for _, task := range specifiedTasks {
+			if _, ok := subtasksFlag[task]; ok {
+				subtasksFlag[task] = true
+			} else {
+				return nil, errors.Default.New(fmt.Sprintf("subtask %s does not exist", task))
+			}
+		}
+	}
+
+	// make sure `Required` subtasks are always enabled
+	for _, subtaskMeta := range subtaskMetas {
+		if subtaskMeta.Required {
+			subtasksFlag[subtaskMeta.Name] = true
+		}
+	}
+	return subtasksFlag, nil
+}
+
+// RunPluginSubTasks FIXME ...
+func RunPluginSubTasks(
+	ctx gocontext.Context,
+	basicRes context.BasicRes,
+	task *models.Task,
+	pluginTask plugin.PluginTask,
+	progress chan plugin.RunningProgress,
+) errors.Error {
+	logger := basicRes.GetLogger()
+	logger.Info("start plugin")
+	// find out all possible subtasks this plugin can offer
+	subtaskMetas := pluginTask.SubTaskMetas()
 	// user specifies what subtasks to run
 	subtaskNames, err := task.GetSubTasks()
 	if err != nil {
 		return err
 	}
+	var specifiedTasks []string
 	if len(subtaskNames) != 0 {
 		// decode user specified subtasks
-		var specifiedTasks []string
 		err := api.Decode(subtaskNames, &specifiedTasks, nil)
 		if err != nil {
 			return errors.Default.Wrap(err, "subtasks could not be decoded")
 		}
-		if len(specifiedTasks) > 0 {
-			// first, disable all subtasks
-			for task := range subtasksFlag {
-				subtasksFlag[task] = false
-			}
-			// second, check specified subtasks is valid and enable them if so
-			for _, task := range specifiedTasks {
-				if _, ok := subtasksFlag[task]; ok {
-					subtasksFlag[task] = true
-				} else {
-					return errors.Default.New(fmt.Sprintf("subtask %s does not exist", task))
-				}
-			}
-		}
+	}
+	subtasksFlag, err := GetSubtasksFlag(subtaskMetas, specifiedTasks)
+	if err != nil {
+		return err
 	}
 
-	// make sure `Required` subtasks are always enabled
-	for _, subtaskMeta := range subtaskMetas {
-		if subtaskMeta.Required {
-			subtasksFlag[subtaskMeta.Name] = true
+	// subtasks completed by the task this one resumed are skipped, but still counted as finished steps
+	completedSubtasks, err := task.GetCompletedSubtasks()
+	if err != nil {
+		return err
+	}
+	skippedSubtasks := make(map[string]bool)
+	for _, name := range completedSubtasks {
+		if subtasksFlag[name] {
+			skippedSubtasks[name] = true
 		}
 	}
 
@@ -242,7 +265,7 @@ func RunPluginSubTasks(
 	taskCtx.SetData(taskData)
 
 	// execute subtasks in order
-	taskCtx.SetProgress(0, steps)
+	taskCtx.SetProgress(len(skippedSubtasks), steps)
 	subtaskNumber := 0
 	for _, subtaskMeta := range subtaskMetas {
 		subtaskCtx, err := taskCtx.SubTaskContext(subtaskMeta.Name)
@@ -254,10 +277,14 @@ func RunPluginSubTasks(
 			// subtask was disabled
 			continue
 		}
+		subtaskNumber++
+		if skippedSubtasks[subtaskMeta.Name] {
+			logger.Info("skipping subtask %s which was completed before", subtaskMeta.Name)
+			continue
+		}
 
 		// run subtask
 		logger.Info("executing subtask %s", subtaskMeta.Name)
-		subtaskNumber++
 		if progress != nil {
 			progress <- plugin.RunningProgress{
 				Type:          plugin.SetCurrentSubTask,
@@ -317,14 +344,26 @@ func runSubtask(
 		TaskID:  parentID,
 		Number:  subtaskNumber,
 		BeganAt: &beginAt,
+		Status:  models.TASK_FAILED,
 	}
 	defer func() {
 		finishedAt := time.Now()
 		subtask.FinishedAt = &finishedAt
 		subtask.SpentSeconds = finishedAt.Unix() - beginAt.Unix()
 		recordSubtask(basicRes, subtask)
+		telemetry.ObserveSubtask(
+			ctx.TaskContext().GetName(),
+			subtask.Name,
+			subtask.Status != models.TASK_COMPLETED,
+			finishedAt.Sub(beginAt),
+		)
 	}()
-	return entryPoint(ctx)
+	err := entryPoint(ctx)
+	if err == nil {
+		// the checkpoint to resume the task from
+		subtask.Status = models.TASK_COMPLETED
+	}
+	return err
 }
 
 func recordSubtask(basicRes context.BasicRes, subtask *models.Subtask) {
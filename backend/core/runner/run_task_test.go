@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// resumeTestPlugin runs the subtasks by names, the subtask failing returns an error
+type resumeTestPlugin struct {
+	ran     []string
+	failing string
+}
+
+func (p *resumeTestPlugin) subtaskMeta(name string, enabledByDefault bool) plugin.SubTaskMeta {
+	return plugin.SubTaskMeta{
+		Name: name,
+		EntryPoint: func(taskCtx plugin.SubTaskContext) errors.Error {
+			p.ran = append(p.ran, name)
+			if name == p.failing {
+				return errors.Default.New("failed")
+			}
+			return nil
+		},
+		EnabledByDefault: enabledByDefault,
+	}
+}
+
+func runResumedTask(p *resumeTestPlugin, completedSubtasks string) ([]*models.Subtask, []plugin.RunningProgress, errors.Error) {
+	var recorded []*models.Subtask
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Create", mock.AnythingOfType("*models.Subtask"), mock.Anything).Run(func(args mock.Arguments) {
+			recorded = append(recorded, args.Get(0).(*models.Subtask))
+		}).Return(nil)
+	})
+	basicRes.On("NestedLogger", mock.Anything).Return(basicRes)
+
+	pluginTask := new(mockplugin.PluginTask)
+	pluginTask.On("SubTaskMetas").Return([]plugin.SubTaskMeta{
+		p.subtaskMeta("collectIssues", true),
+		p.subtaskMeta("collectComments", false),
+		p.subtaskMeta("extractIssues", true),
+		p.subtaskMeta("convertIssues", true),
+	})
+	pluginTask.On("PrepareTaskData", mock.Anything, mock.Anything).Return(nil, nil)
+
+	task := &models.Task{
+		Plugin:            "jira",
+		Subtasks:          []byte(`[]`),
+		Options:           `{}`,
+		CompletedSubtasks: []byte(completedSubtasks),
+	}
+	task.ID = 2
+	progress := make(chan plugin.RunningProgress, 100)
+	err := RunPluginSubTasks(gocontext.Background(), basicRes, task, pluginTask, progress)
+	close(progress)
+	var progresses []plugin.RunningProgress
+	for p := range progress {
+		progresses = append(progresses, p)
+	}
+	return recorded, progresses, err
+}
+
+func TestRunPluginSubTasksResumed(t *testing.T) {
+	// the task failed at extractIssues before, collectComments is not enabled so it is not skipped nor counted
+	p := &resumeTestPlugin{}
+	recorded, progresses, err := runResumedTask(p, `["collectIssues","collectComments"]`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"extractIssues", "convertIssues"}, p.ran)
+
+	// the skipped subtasks are counted as finished steps, and the subtask numbers stay the same as the first run
+	assert.Equal(t, plugin.RunningProgress{Type: plugin.TaskSetProgress, Current: 1, Total: 3}, progresses[0])
+	assert.Equal(t, plugin.RunningProgress{Type: plugin.SetCurrentSubTask, SubTaskName: "extractIssues", SubTaskNumber: 2}, progresses[1])
+	assert.Equal(t, plugin.RunningProgress{Type: plugin.TaskIncProgress, Current: 2, Total: 3}, progresses[2])
+	assert.Equal(t, plugin.RunningProgress{Type: plugin.SetCurrentSubTask, SubTaskName: "convertIssues", SubTaskNumber: 3}, progresses[3])
+	assert.Equal(t, plugin.RunningProgress{Type: plugin.TaskIncProgress, Current: 3, Total: 3}, progresses[4])
+
+	// the checkpoints of the subtasks run
+	assert.Len(t, recorded, 2)
+	assert.Equal(t, "extractIssues", recorded[0].Name)
+	assert.Equal(t, 2, recorded[0].Number)
+	assert.Equal(t, models.TASK_COMPLETED, recorded[0].Status)
+	assert.Equal(t, "convertIssues", recorded[1].Name)
+	assert.Equal(t, uint64(2), recorded[1].TaskID)
+}
+
+func TestRunPluginSubTasksResumedFailedAgain(t *testing.T) {
+	p := &resumeTestPlugin{failing: "extractIssues"}
+	recorded, _, err := runResumedTask(p, `["collectIssues"]`)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"extractIssues"}, p.ran)
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, models.TASK_FAILED, recorded[0].Status)
+}
+
+func TestRunPluginSubTasksNotResumed(t *testing.T) {
+	p := &resumeTestPlugin{}
+	recorded, _, err := runResumedTask(p, ``)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"collectIssues", "extractIssues", "convertIssues"}, p.ran)
+	assert.Len(t, recorded, 3)
+}
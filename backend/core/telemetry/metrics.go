@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// SubtaskDuration observes how long each subtask took to run
+	SubtaskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "devlake_subtask_duration_seconds",
+		Help:    "Duration of plugin subtasks in seconds",
+		Buckets: []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600, 7200},
+	}, []string{"plugin", "subtask", "status"})
+
+	// ApiRequests counts the api requests issued by ApiAsyncClient
+	ApiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "devlake_api_requests_total",
+		Help: "Number of api requests issued by plugins, labeled by response status",
+	}, []string{"plugin", "connection", "status"})
+
+	// ApiRetries counts the api requests being retried by ApiAsyncClient
+	ApiRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "devlake_api_request_retries_total",
+		Help: "Number of api requests retried by plugins",
+	}, []string{"plugin", "connection"})
+
+	// ApiLatency observes the latency of api requests issued by ApiAsyncClient
+	ApiLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "devlake_api_request_duration_seconds",
+		Help:    "Latency of api requests issued by plugins in seconds",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"plugin", "connection"})
+
+	// SchedulerQueueDepth tracks the number of tasks waiting or running in a WorkerScheduler
+	SchedulerQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "devlake_api_scheduler_queue_depth",
+		Help: "Number of api requests queued or in-flight in the worker scheduler",
+	}, []string{"plugin", "connection"})
+
+	// SchedulerTickInterval tracks the current tick interval of a WorkerScheduler
+	SchedulerTickInterval = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "devlake_api_scheduler_tick_interval_seconds",
+		Help: "Current tick interval of the worker scheduler in seconds",
+	}, []string{"plugin", "connection"})
+
+	// BatchSaveRows counts the rows written by BatchSave per table
+	BatchSaveRows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "devlake_batch_save_rows_total",
+		Help: "Number of rows written by batch save, labeled by table",
+	}, []string{"table"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		SubtaskDuration,
+		ApiRequests,
+		ApiRetries,
+		ApiLatency,
+		SchedulerQueueDepth,
+		SchedulerTickInterval,
+		BatchSaveRows,
+	)
+}
+
+// ObserveSubtask records the duration of a finished subtask
+func ObserveSubtask(plugin, subtask string, failed bool, duration time.Duration) {
+	status := "success"
+	if failed {
+		status = "failed"
+	}
+	SubtaskDuration.WithLabelValues(plugin, subtask, status).Observe(duration.Seconds())
+}
+
+// ObserveApiRequest records a finished api request, statusCode should be 0 if no response was received
+func ObserveApiRequest(plugin, connection string, statusCode int, duration time.Duration) {
+	status := "error"
+	if statusCode > 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	ApiRequests.WithLabelValues(plugin, connection, status).Inc()
+	ApiLatency.WithLabelValues(plugin, connection).Observe(duration.Seconds())
+}
+
+// ObserveApiRetry records a retried api request
+func ObserveApiRetry(plugin, connection string) {
+	ApiRetries.WithLabelValues(plugin, connection).Inc()
+}
+
+// ObserveBatchSave records the rows written into the specified table
+func ObserveBatchSave(table string, rows int) {
+	if rows <= 0 {
+		return
+	}
+	BatchSaveRows.WithLabelValues(table).Add(float64(rows))
+}
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveApiRequest(t *testing.T) {
+	ObserveApiRequest("github", "1", 200, time.Second)
+	ObserveApiRequest("github", "1", 200, time.Second)
+	ObserveApiRequest("github", "1", 0, time.Second)
+	ObserveApiRetry("github", "1")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(ApiRequests.WithLabelValues("github", "1", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ApiRequests.WithLabelValues("github", "1", "error")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ApiRetries.WithLabelValues("github", "1")))
+}
+
+func TestObserveBatchSave(t *testing.T) {
+	ObserveBatchSave("issues", 500)
+	ObserveBatchSave("issues", 0)
+	ObserveBatchSave("issues", 20)
+
+	assert.Equal(t, float64(520), testutil.ToFloat64(BatchSaveRows.WithLabelValues("issues")))
+}
@@ -181,6 +181,15 @@ func (t *DataFlowTester) FlushTabler(dst schema.Tabler) {
 	}
 }
 
+// UseCassette makes api clients created afterward replay responses from the specified cassette directory, so
+// collectors can be verified offline. Set API_CASSETTE_MODE=record in .env to record the cassettes from real servers.
+func (t *DataFlowTester) UseCassette(cassetteDir string) {
+	if t.Cfg.GetString(`API_CASSETTE_MODE`) != api.CassetteModeRecord {
+		t.Cfg.Set(`API_CASSETTE_MODE`, api.CassetteModeReplay)
+	}
+	t.Cfg.Set(`API_CASSETTE_DIR`, cassetteDir)
+}
+
 // Subtask executes specified subtasks
 func (t *DataFlowTester) Subtask(subtaskMeta plugin.SubTaskMeta, taskData interface{}) {
 	subtaskCtx := t.SubtaskContext(taskData)
@@ -190,6 +199,13 @@ func (t *DataFlowTester) Subtask(subtaskMeta plugin.SubTaskMeta, taskData interf
 	}
 }
 
+// TaskContext creates a task context, i.e. to create the api client for collectors
+func (t *DataFlowTester) TaskContext(taskData interface{}) plugin.TaskContext {
+	taskCtx := contextimpl.NewDefaultTaskContext(context.Background(), runner.CreateBasicRes(t.Cfg, t.Log, t.Db), t.Name, nil, nil)
+	taskCtx.SetData(taskData)
+	return taskCtx
+}
+
 // SubtaskContext creates a subtask context
 func (t *DataFlowTester) SubtaskContext(taskData interface{}) plugin.SubTaskContext {
 	return contextimpl.NewStandaloneSubTaskContext(context.Background(), runner.CreateBasicRes(t.Cfg, t.Log, t.Db), t.Name, taskData)
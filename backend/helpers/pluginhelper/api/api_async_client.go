@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/telemetry"
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/common"
 )
@@ -45,6 +46,9 @@ type ApiAsyncClient struct {
 	maxRetry     int
 	numOfWorkers int
 	logger       log.Logger
+	// labels of the metrics
+	pluginName   string
+	connectionId string
 }
 
 const defaultTimeout = 120 * time.Second
@@ -122,14 +126,21 @@ func CreateAsyncApiClient(
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "failed to create scheduler")
 	}
+	pluginName := taskCtx.GetName()
+	scheduler.setMetrics(
+		telemetry.SchedulerQueueDepth.WithLabelValues(pluginName, apiClient.connectionId),
+		telemetry.SchedulerTickInterval.WithLabelValues(pluginName, apiClient.connectionId),
+	)
 
 	// finally, wrap around api client with async sematic
 	return &ApiAsyncClient{
-		apiClient,
-		scheduler,
-		retry,
-		numOfWorkers,
-		logger,
+		ApiClient:       apiClient,
+		WorkerScheduler: scheduler,
+		maxRetry:        retry,
+		numOfWorkers:    numOfWorkers,
+		logger:          logger,
+		pluginName:      pluginName,
+		connectionId:    apiClient.connectionId,
 	}, nil
 }
 
@@ -162,7 +173,13 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		var respBody []byte
 
 		apiClient.logger.Debug("endpoint: %s  method: %s  header: %s  body: %s query: %s", path, method, header, body, query)
+		beginAt := time.Now()
 		res, err = apiClient.Do(method, path, query, body, header)
+		statusCode := 0
+		if res != nil {
+			statusCode = res.StatusCode
+		}
+		telemetry.ObserveApiRequest(apiClient.pluginName, apiClient.connectionId, statusCode, time.Since(beginAt))
 		// make sure response body is read successfully, or we might have to retry
 		if err == nil {
 			// make sure response.Body stream will be closed to avoid running out of file handle
@@ -196,6 +213,7 @@ func (apiClient *ApiAsyncClient) DoAsync(
 			if retry < apiClient.maxRetry && err != context.Canceled {
 				apiClient.logger.Warn(err, "retry #%d calling %s", retry, path)
 				retry++
+				telemetry.ObserveApiRetry(apiClient.pluginName, apiClient.connectionId)
 				apiClient.NextTick(func() errors.Error {
 					apiClient.SubmitBlocking(request)
 					return nil
@@ -48,6 +48,7 @@ var ErrIgnoreAndContinue = errors.Default.New("ignore and continue")
 // ApiClient is designed for simple api requests
 type ApiClient struct {
 	client     *http.Client
+	transport  *http.Transport
 	endpoint   string
 	headers    map[string]string
 	data       map[string]interface{}
@@ -57,6 +58,8 @@ type ApiClient struct {
 	afterResponse common.ApiClientAfterResponse
 	ctx           gocontext.Context
 	logger        log.Logger
+	// connectionId labels the metrics of requests, it is empty unless created from a connection
+	connectionId string
 }
 
 // NewApiClientFromConnection creates ApiClient based on given connection.
@@ -65,13 +68,16 @@ func NewApiClientFromConnection(
 	br context.BasicRes,
 	connection aha.ApiConnection,
 ) (*ApiClient, errors.Error) {
-	if reflect.ValueOf(connection).Kind() != reflect.Ptr {
-		return nil, errors.Default.New("connection is not a pointer")
-	}
 	apiClient, err := NewApiClient(ctx, connection.GetEndpoint(), nil, 0, connection.GetProxy(), br)
 	if err != nil {
 		return nil, err
 	}
+	// connection could be either a struct or a pointer to it
+	if value := reflect.Indirect(reflect.ValueOf(connection)); value.Kind() == reflect.Struct {
+		if id := value.FieldByName("ID"); id.IsValid() && id.CanInterface() {
+			apiClient.connectionId = fmt.Sprintf("%v", id.Interface())
+		}
+	}
 
 	// if connection needs to prepare the ApiClient, i.e. fetch token for future requests
 	if prepareApiClient, ok := connection.(aha.PrepareApiClient); ok {
@@ -107,7 +113,8 @@ func NewApiClient(
 		timeout,
 	)
 	// create the Transport
-	apiClient.client.Transport = &http.Transport{}
+	apiClient.transport = &http.Transport{}
+	apiClient.client.Transport = apiClient.transport
 
 	// set insecureSkipVerify
 	insecureSkipVerify, err := utils.StrToBoolOr(br.GetConfig("IN_SECURE_SKIP_VERIFY"), false)
@@ -115,7 +122,19 @@ func NewApiClient(
 		return nil, errors.Default.Wrap(err, "failed to parse IN_SECURE_SKIP_VERIFY")
 	}
 	if insecureSkipVerify {
-		apiClient.client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		apiClient.transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	// record or replay the responses if API_CASSETTE_MODE is set
+	apiClient.client.Transport, err = WrapTransportWithCassette(br, apiClient.transport)
+	if err != nil {
+		return nil, err
+	}
+
+	if GetCassetteMode(br) == CassetteModeReplay {
+		// responses are served from the cassettes, there is no need to check connectivity
+		apiClient.SetContext(ctx)
+		return apiClient, nil
 	}
 
 	if proxy != "" {
@@ -252,7 +271,7 @@ func (apiClient *ApiClient) SetProxy(proxyUrl string) errors.Error {
 		return errors.Convert(err)
 	}
 	if pu.Scheme == "http" || pu.Scheme == "socks5" {
-		apiClient.client.Transport.(*http.Transport).Proxy = http.ProxyURL(pu)
+		apiClient.transport.Proxy = http.ProxyURL(pu)
 	}
 	return nil
 }
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	"github.com/stretchr/testify/assert"
+)
+
+type testApiConnection struct {
+	ID       uint64
+	Endpoint string
+}
+
+func (c testApiConnection) GetEndpoint() string {
+	return c.Endpoint
+}
+
+func (c testApiConnection) GetProxy() string {
+	return ""
+}
+
+func (c testApiConnection) GetRateLimitPerHour() int {
+	return 0
+}
+
+func TestNewApiClientFromConnection(t *testing.T) {
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetConfig", "IN_SECURE_SKIP_VERIFY").Return("")
+	mockRes.On("GetConfig", "API_CASSETTE_MODE").Return(CassetteModeReplay)
+	mockRes.On("GetConfig", "API_CASSETTE_DIR").Return(t.TempDir())
+	mockRes.On("GetConfig", "API_CASSETTE_REDACT_KEYS").Return("")
+
+	connection := testApiConnection{ID: 7, Endpoint: "https://example.com/api/"}
+	// both struct value and pointer are accepted
+	apiClient, err := NewApiClientFromConnection(context.Background(), mockRes, connection)
+	assert.Nil(t, err)
+	assert.Equal(t, "7", apiClient.connectionId)
+	apiClient, err = NewApiClientFromConnection(context.Background(), mockRes, &connection)
+	assert.Nil(t, err)
+	assert.Equal(t, "7", apiClient.connectionId)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"sort"
 	"time"
 )
 
@@ -54,6 +55,7 @@ func NewApiCollectorWithState(args RawDataSubTaskArgs, createdDateAfter *time.Ti
 			return nil, errors.Default.Wrap(err, "failed to load JiraLatestCollectorMeta")
 		}
 	}
+	latestState.TimeRanges = GetCollectedTimeRanges(&latestState)
 	return &ApiCollectorStateManager{
 		RawDataSubTaskArgs: args,
 		LatestState:        latestState,
@@ -62,14 +64,43 @@ func NewApiCollectorWithState(args RawDataSubTaskArgs, createdDateAfter *time.Ti
 	}, nil
 }
 
+// GetCollectedTimeRanges returns the time ranges collected according to the state,
+// states saved before time ranges were introduced cover everything created after CreatedDateAfter
+func GetCollectedTimeRanges(latestState *models.CollectorLatestState) []models.TimeRange {
+	if latestState.LatestSuccessStart != nil && len(latestState.TimeRanges) == 0 {
+		return []models.TimeRange{{After: latestState.CreatedDateAfter}}
+	}
+	return latestState.TimeRanges
+}
+
 // IsIncremental return if the old data can support collect incrementally.
 // only when latest collection is success &&
-// (m.LatestState.CreatedDateAfter == nil means all data have been collected ||
-// CreatedDateAfter at this time exists and no before than in the LatestState)
-// if CreatedDateAfter at this time not exists, collect incrementally only when "m.LatestState.CreatedDateAfter == nil"
+// the window of this time (created after CreatedDateAfter) was covered by the collected time ranges,
+// e.g. m.LatestState.CreatedDateAfter == nil means all data have been collected
 func (m ApiCollectorStateManager) IsIncremental() bool {
-	return m.LatestState.LatestSuccessStart != nil &&
-		(m.LatestState.CreatedDateAfter == nil || m.CreatedDateAfter != nil && !m.CreatedDateAfter.Before(*m.LatestState.CreatedDateAfter))
+	return m.LatestState.LatestSuccessStart != nil && len(m.GetMissingRanges()) == 0
+}
+
+// IsBackfill return if the window of this time was widened to an earlier date after a successful collection,
+// the collector may fetch only the data created in GetMissingRanges and keep the existing data by setting
+// ApiCollectorArgs.Incremental to true, otherwise it should collect everything as usual
+func (m ApiCollectorStateManager) IsBackfill() bool {
+	if m.LatestState.LatestSuccessStart == nil {
+		return false
+	}
+	missingRanges := m.GetMissingRanges()
+	for _, missingRange := range missingRanges {
+		// data created recently is never missing once collected, or it is not a backfill
+		if missingRange.Before == nil {
+			return false
+		}
+	}
+	return len(missingRanges) > 0
+}
+
+// GetMissingRanges return ranges of created date in the window of this time but not collected yet
+func (m ApiCollectorStateManager) GetMissingRanges() []models.TimeRange {
+	return subtractTimeRanges(models.TimeRange{After: m.CreatedDateAfter}, m.LatestState.TimeRanges)
 }
 
 // InitCollector init the embedded collector
@@ -93,11 +124,7 @@ func (m ApiCollectorStateManager) Execute() errors.Error {
 		return err
 	}
 
-	db := m.Ctx.GetDal()
-	m.LatestState.LatestSuccessStart = &m.ExecuteStart
-	m.LatestState.CreatedDateAfter = m.CreatedDateAfter
-	err = db.CreateOrUpdate(&m.LatestState)
-	return err
+	return m.saveState(m.ApiCollector.args.Incremental)
 }
 
 // ExecuteGraphQL the embedded collector and record execute state
@@ -107,9 +134,111 @@ func (m ApiCollectorStateManager) ExecuteGraphQL() errors.Error {
 		return err
 	}
 
-	db := m.Ctx.GetDal()
-	m.LatestState.LatestSuccessStart = &m.ExecuteStart
+	return m.saveState(m.GraphqlCollector.args.Incremental)
+}
+
+// saveState records the collected time ranges, the start time of a backfill is not recorded because only
+// the missing ranges were collected, data updated since last collection is still left for next incremental one
+func (m ApiCollectorStateManager) saveState(keptRawData bool) errors.Error {
+	if keptRawData && m.IsBackfill() {
+		m.LatestState.TimeRanges = mergeTimeRanges(append(m.LatestState.TimeRanges, m.GetMissingRanges()...))
+	} else {
+		if !keptRawData {
+			m.LatestState.TimeRanges = nil
+		}
+		m.LatestState.TimeRanges = mergeTimeRanges(append(m.LatestState.TimeRanges, models.TimeRange{After: m.CreatedDateAfter}))
+		m.LatestState.LatestSuccessStart = &m.ExecuteStart
+	}
 	m.LatestState.CreatedDateAfter = m.CreatedDateAfter
-	err = db.CreateOrUpdate(&m.LatestState)
-	return err
+	for _, timeRange := range m.LatestState.TimeRanges {
+		if timeRange.Before == nil {
+			m.LatestState.CreatedDateAfter = timeRange.After
+		}
+	}
+	db := m.Ctx.GetDal()
+	return db.CreateOrUpdate(&m.LatestState)
+}
+
+var (
+	beginningOfTime = time.Time{}
+	endOfTime       = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// getBounds replaces the unbounded ends of the range with the beginning and the end of time
+func getBounds(timeRange models.TimeRange) (after time.Time, before time.Time) {
+	after, before = beginningOfTime, endOfTime
+	if timeRange.After != nil {
+		after = *timeRange.After
+	}
+	if timeRange.Before != nil {
+		before = *timeRange.Before
+	}
+	return
+}
+
+// newTimeRange is the reverse of getBounds
+func newTimeRange(after time.Time, before time.Time) models.TimeRange {
+	timeRange := models.TimeRange{}
+	if after.After(beginningOfTime) {
+		timeRange.After = &after
+	}
+	if before.Before(endOfTime) {
+		timeRange.Before = &before
+	}
+	return timeRange
+}
+
+// mergeTimeRanges sorts the ranges and merges those overlapping or adjacent
+func mergeTimeRanges(ranges []models.TimeRange) []models.TimeRange {
+	sorted := make([]models.TimeRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		afterI, _ := getBounds(sorted[i])
+		afterJ, _ := getBounds(sorted[j])
+		return afterI.Before(afterJ)
+	})
+	merged := []models.TimeRange{}
+	var lastAfter, lastBefore time.Time
+	for i, timeRange := range sorted {
+		after, before := getBounds(timeRange)
+		if i > 0 && !after.After(lastBefore) {
+			if before.After(lastBefore) {
+				lastBefore = before
+			}
+			merged[len(merged)-1] = newTimeRange(lastAfter, lastBefore)
+			continue
+		}
+		lastAfter, lastBefore = after, before
+		merged = append(merged, newTimeRange(lastAfter, lastBefore))
+	}
+	return merged
+}
+
+// subtractTimeRanges returns the parts of window not covered by the ranges
+func subtractTimeRanges(window models.TimeRange, ranges []models.TimeRange) []models.TimeRange {
+	missing := []models.TimeRange{}
+	cursor, windowBefore := getBounds(window)
+	for _, timeRange := range mergeTimeRanges(ranges) {
+		after, before := getBounds(timeRange)
+		if !before.After(cursor) {
+			continue
+		}
+		if after.After(cursor) {
+			end := after
+			if windowBefore.Before(end) {
+				end = windowBefore
+			}
+			if cursor.Before(end) {
+				missing = append(missing, newTimeRange(cursor, end))
+			}
+		}
+		cursor = before
+		if !cursor.Before(windowBefore) {
+			return missing
+		}
+	}
+	if cursor.Before(windowBefore) {
+		missing = append(missing, newTimeRange(cursor, windowBefore))
+	}
+	return missing
 }
@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func dateOfMonth(month time.Month) *time.Time {
+	t := time.Date(2023, month, 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestMergeTimeRanges(t *testing.T) {
+	assert.Empty(t, mergeTimeRanges(nil))
+	assert.Equal(t, []models.TimeRange{{After: dateOfMonth(1)}}, mergeTimeRanges([]models.TimeRange{
+		{After: dateOfMonth(3)},
+		{After: dateOfMonth(1), Before: dateOfMonth(2)},
+		{After: dateOfMonth(2), Before: dateOfMonth(4)},
+	}))
+	assert.Equal(t, []models.TimeRange{{Before: dateOfMonth(3)}, {After: dateOfMonth(5), Before: dateOfMonth(6)}}, mergeTimeRanges([]models.TimeRange{
+		{After: dateOfMonth(5), Before: dateOfMonth(6)},
+		{After: dateOfMonth(1), Before: dateOfMonth(3)},
+		{Before: dateOfMonth(2)},
+	}))
+}
+
+func TestSubtractTimeRanges(t *testing.T) {
+	// nothing collected
+	assert.Equal(t, []models.TimeRange{{After: dateOfMonth(1)}}, subtractTimeRanges(models.TimeRange{After: dateOfMonth(1)}, nil))
+	// window narrowed
+	assert.Empty(t, subtractTimeRanges(models.TimeRange{After: dateOfMonth(3)}, []models.TimeRange{{After: dateOfMonth(1)}}))
+	assert.Empty(t, subtractTimeRanges(models.TimeRange{After: dateOfMonth(3)}, []models.TimeRange{{}}))
+	// window widened
+	assert.Equal(t, []models.TimeRange{{After: dateOfMonth(1), Before: dateOfMonth(3)}}, subtractTimeRanges(models.TimeRange{After: dateOfMonth(1)}, []models.TimeRange{{After: dateOfMonth(3)}}))
+	assert.Equal(t, []models.TimeRange{{Before: dateOfMonth(3)}}, subtractTimeRanges(models.TimeRange{}, []models.TimeRange{{After: dateOfMonth(3)}}))
+	// gaps
+	assert.Equal(t,
+		[]models.TimeRange{{After: dateOfMonth(1), Before: dateOfMonth(2)}, {After: dateOfMonth(3), Before: dateOfMonth(5)}, {After: dateOfMonth(6)}},
+		subtractTimeRanges(models.TimeRange{After: dateOfMonth(1)}, []models.TimeRange{{After: dateOfMonth(2), Before: dateOfMonth(3)}, {After: dateOfMonth(5), Before: dateOfMonth(6)}}),
+	)
+	// bounded window
+	assert.Equal(t,
+		[]models.TimeRange{{After: dateOfMonth(1), Before: dateOfMonth(2)}},
+		subtractTimeRanges(models.TimeRange{After: dateOfMonth(1), Before: dateOfMonth(4)}, []models.TimeRange{{After: dateOfMonth(2)}}),
+	)
+}
+
+func TestApiCollectorStateManager(t *testing.T) {
+	lastSuccess := dateOfMonth(6)
+
+	// never collected
+	m := ApiCollectorStateManager{CreatedDateAfter: dateOfMonth(3)}
+	assert.False(t, m.IsIncremental())
+	assert.False(t, m.IsBackfill())
+
+	// same or narrower window
+	m.LatestState = models.CollectorLatestState{LatestSuccessStart: lastSuccess, TimeRanges: []models.TimeRange{{After: dateOfMonth(3)}}}
+	assert.True(t, m.IsIncremental())
+	assert.False(t, m.IsBackfill())
+	m.CreatedDateAfter = dateOfMonth(4)
+	assert.True(t, m.IsIncremental())
+
+	// wider window
+	m.CreatedDateAfter = dateOfMonth(1)
+	assert.False(t, m.IsIncremental())
+	assert.True(t, m.IsBackfill())
+	assert.Equal(t, []models.TimeRange{{After: dateOfMonth(1), Before: dateOfMonth(3)}}, m.GetMissingRanges())
+	m.CreatedDateAfter = nil
+	assert.True(t, m.IsBackfill())
+	assert.Equal(t, []models.TimeRange{{Before: dateOfMonth(3)}}, m.GetMissingRanges())
+
+	// everything collected
+	m.LatestState.TimeRanges = []models.TimeRange{{}}
+	assert.True(t, m.IsIncremental())
+	assert.False(t, m.IsBackfill())
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/telemetry"
 	"reflect"
 	"strings"
 )
@@ -41,6 +42,8 @@ type BatchSave struct {
 	valueIndex map[string]int
 	primaryKey []reflect.StructField
 	tableName  string
+	// metricTable is the table label of the rows-written metric
+	metricTable string
 }
 
 // NewBatchSave creates a new BatchSave instance
@@ -59,17 +62,27 @@ func NewBatchSave(basicRes context.BasicRes, slotType reflect.Type, size int, ta
 		tn = tableName[0]
 	}
 
+	metricTable := tn
+	if metricTable == "" {
+		if tabler, ok := reflect.New(slotType.Elem()).Interface().(dal.Tabler); ok {
+			metricTable = tabler.TableName()
+		} else {
+			metricTable = slotType.String()
+		}
+	}
+
 	logger := basicRes.GetLogger().Nested(slotType.String())
 	return &BatchSave{
-		basicRes:   basicRes,
-		log:        logger,
-		db:         db,
-		slotType:   slotType,
-		slots:      reflect.MakeSlice(reflect.SliceOf(slotType), size, size),
-		size:       size,
-		valueIndex: make(map[string]int),
-		primaryKey: primaryKey,
-		tableName:  tn,
+		basicRes:    basicRes,
+		log:         logger,
+		db:          db,
+		slotType:    slotType,
+		slots:       reflect.MakeSlice(reflect.SliceOf(slotType), size, size),
+		size:        size,
+		valueIndex:  make(map[string]int),
+		primaryKey:  primaryKey,
+		tableName:   tn,
+		metricTable: metricTable,
 	}, nil
 }
 
@@ -114,6 +127,7 @@ func (c *BatchSave) Flush() errors.Error {
 	if err != nil {
 		return err
 	}
+	telemetry.ObserveBatchSave(c.metricTable, c.current)
 	c.log.Debug("batch save flush total %d records to database", c.current)
 	c.current = 0
 	c.valueIndex = make(map[string]int)
@@ -0,0 +1,349 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+const (
+	// CassetteModeRecord sends requests to the server and records the responses into the cassette directory
+	CassetteModeRecord = "record"
+	// CassetteModeReplay serves responses from the cassette directory without touching the network
+	CassetteModeReplay = "replay"
+)
+
+// headers of the response that would not be recorded
+var cassetteIgnoredHeaders = []string{"Set-Cookie", "Content-Length", "Content-Encoding"}
+
+// cassetteRedactedKeys are the query parameters and json body fields carrying credentials, i.e. the token returned by
+// the login api of zentao or the tenant_access_token of feishu, more could be added by API_CASSETTE_REDACT_KEYS
+var cassetteRedactedKeys = []string{
+	"access_token", "private_token", "token", "password", "secret",
+	"client_secret", "app_secret", "api_key", "apikey", "refresh_token",
+	"tenant_access_token", "app_access_token", "user_access_token", "id_token",
+}
+
+const cassetteRedacted = "REDACTED"
+
+// CassetteInteraction is a recorded request and the response to it
+type CassetteInteraction struct {
+	Method      string      `json:"method"`
+	Url         string      `json:"url"`
+	RequestBody string      `json:"requestBody,omitempty"`
+	StatusCode  int         `json:"statusCode"`
+	Header      http.Header `json:"header"`
+	Body        string      `json:"body"`
+}
+
+// CassetteTransport records http interactions into a directory, or replays them from it, so collectors can be tested offline.
+// Identical requests are stored in the same file in order, and replayed in the same order, the last response is served
+// repeatedly once exhausted. Request headers are never recorded, and the values of redacted keys in the query and
+// the json or form body are replaced before the request is keyed and recorded, so are the ones in the response body,
+// to keep credentials out of the cassettes. The caller still receives the response as it is while recording.
+type CassetteTransport struct {
+	mode       string
+	dir        string
+	transport  http.RoundTripper
+	redactKeys map[string]bool
+	mu         sync.Mutex
+	tracks     map[string][]*CassetteInteraction
+	cursors    map[string]int
+}
+
+// NewCassetteTransport creates a CassetteTransport working in the specified mode on top of the given transport
+func NewCassetteTransport(mode string, dir string, transport http.RoundTripper) (*CassetteTransport, errors.Error) {
+	if mode != CassetteModeRecord && mode != CassetteModeReplay {
+		return nil, errors.BadInput.New(fmt.Sprintf("unknown cassette mode %s, it should be %s or %s", mode, CassetteModeRecord, CassetteModeReplay))
+	}
+	if dir == "" {
+		return nil, errors.BadInput.New("cassette dir is required")
+	}
+	if mode == CassetteModeRecord {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to create cassette dir %s", dir))
+		}
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	cassette := &CassetteTransport{
+		mode:       mode,
+		dir:        dir,
+		transport:  transport,
+		redactKeys: make(map[string]bool),
+		tracks:     make(map[string][]*CassetteInteraction),
+		cursors:    make(map[string]int),
+	}
+	cassette.AddRedactKeys(cassetteRedactedKeys...)
+	return cassette, nil
+}
+
+// AddRedactKeys adds the keys, case-insensitive, of which values would be redacted from query and body of requests,
+// and from body of responses
+func (c *CassetteTransport) AddRedactKeys(keys ...string) {
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			c.redactKeys[strings.ToLower(key)] = true
+		}
+	}
+}
+
+// GetCassetteMode returns the cassette mode configured by API_CASSETTE_MODE, empty if disabled
+func GetCassetteMode(br context.BasicRes) string {
+	return strings.ToLower(br.GetConfig("API_CASSETTE_MODE"))
+}
+
+// WrapTransportWithCassette wraps the transport with a CassetteTransport when API_CASSETTE_MODE and API_CASSETTE_DIR
+// are configured, the transport would be returned as it is otherwise
+func WrapTransportWithCassette(br context.BasicRes, transport http.RoundTripper) (http.RoundTripper, errors.Error) {
+	mode := GetCassetteMode(br)
+	if mode == "" {
+		return transport, nil
+	}
+	cassette, err := NewCassetteTransport(mode, br.GetConfig("API_CASSETTE_DIR"), transport)
+	if err != nil {
+		return nil, err
+	}
+	cassette.AddRedactKeys(strings.Split(br.GetConfig("API_CASSETTE_REDACT_KEYS"), ",")...)
+	return cassette, nil
+}
+
+// Mode returns the mode of the CassetteTransport
+func (c *CassetteTransport) Mode() string {
+	return c.mode
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewBuffer(reqBody))
+	}
+	// both recording and replaying key the request by the redacted url and body so they match each other
+	redactedUrl := c.redactUrl(req.URL)
+	redactedBody := c.redactBody(req.Header.Get("Content-Type"), reqBody)
+	key := cassetteKey(req.Method, redactedUrl, redactedBody)
+	if c.mode == CassetteModeReplay {
+		return c.replay(key, req)
+	}
+	return c.record(key, req, redactedUrl, redactedBody)
+}
+
+// redactUrl returns the url with the values of redacted query parameters replaced
+func (c *CassetteTransport) redactUrl(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for key := range query {
+		if c.redactKeys[strings.ToLower(key)] {
+			query.Set(key, cassetteRedacted)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	clone := *u
+	clone.RawQuery = query.Encode()
+	return clone.String()
+}
+
+// redactBody returns the json or form body with the values of redacted keys replaced, other bodies are kept as they are
+func (c *CassetteTransport) redactBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		redacted := false
+		for key := range form {
+			if c.redactKeys[strings.ToLower(key)] {
+				form.Set(key, cassetteRedacted)
+				redacted = true
+			}
+		}
+		if !redacted {
+			return body
+		}
+		return []byte(form.Encode())
+	}
+	var decoded interface{}
+	if json.Unmarshal(body, &decoded) != nil {
+		return body
+	}
+	if !c.redactJson(decoded) {
+		return body
+	}
+	redacted, err := json.Marshal(decoded)
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// redactJson replaces values of the redacted keys in place and returns true if any of them is found
+func (c *CassetteTransport) redactJson(value interface{}) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if c.redactKeys[strings.ToLower(key)] {
+				v[key] = cassetteRedacted
+				redacted = true
+			} else if c.redactJson(item) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if c.redactJson(item) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+func (c *CassetteTransport) replay(key string, req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	track, ok := c.tracks[key]
+	if !ok {
+		var err errors.Error
+		track, err = c.load(key)
+		if err != nil {
+			return nil, err
+		}
+		c.tracks[key] = track
+	}
+	if len(track) == 0 {
+		return nil, errors.NotFound.New(fmt.Sprintf("no recorded response for %s %s in cassette %s", req.Method, req.URL, c.dir))
+	}
+	cursor := c.cursors[key]
+	if cursor < len(track)-1 {
+		c.cursors[key] = cursor + 1
+	}
+	interaction := track[cursor]
+	header := interaction.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.Body)),
+		ContentLength: int64(len(interaction.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (c *CassetteTransport) record(key string, req *http.Request, redactedUrl string, redactedBody []byte) (*http.Response, error) {
+	res, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewBuffer(resBody))
+	header := res.Header.Clone()
+	for _, name := range cassetteIgnoredHeaders {
+		header.Del(name)
+	}
+	interaction := &CassetteInteraction{
+		Method:      req.Method,
+		Url:         redactedUrl,
+		RequestBody: string(redactedBody),
+		StatusCode:  res.StatusCode,
+		Header:      header,
+		Body:        string(c.redactBody(res.Header.Get("Content-Type"), resBody)),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tracks[key] = append(c.tracks[key], interaction)
+	if err := c.save(key, c.tracks[key]); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *CassetteTransport) load(key string) ([]*CassetteInteraction, errors.Error) {
+	content, err := os.ReadFile(filepath.Join(c.dir, key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to read cassette %s", key))
+	}
+	var track []*CassetteInteraction
+	if err := json.Unmarshal(content, &track); err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to decode cassette %s", key))
+	}
+	return track, nil
+}
+
+func (c *CassetteTransport) save(key string, track []*CassetteInteraction) errors.Error {
+	content, err := json.MarshalIndent(track, "", "  ")
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to encode cassette %s", key))
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, key), content, 0644); err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to write cassette %s", key))
+	}
+	return nil
+}
+
+// cassetteKey returns the file name of the cassette holding responses of the request
+func cassetteKey(method string, url string, body []byte) string {
+	h := sha1.New()
+	h.Write([]byte(method))
+	h.Write([]byte(" "))
+	h.Write([]byte(url))
+	h.Write([]byte("\n"))
+	h.Write(body)
+	return fmt.Sprintf("%s_%x.json", strings.ToLower(method), h.Sum(nil)[:8])
+}
@@ -0,0 +1,221 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassetteTransportRecordAndReplay(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("X-Total-Pages", "2")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"page":%s,"hit":%d}`, r.URL.Query().Get("page"), hits)))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	recorder, err := NewCassetteTransport(CassetteModeRecord, dir, nil)
+	assert.Nil(t, err)
+	client := &http.Client{Transport: recorder}
+	for _, page := range []string{"1", "2", "2"} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/issues?page="+page, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		res, err := client.Do(req)
+		assert.Nil(t, err)
+		_ = res.Body.Close()
+	}
+	assert.Equal(t, 3, hits)
+	files, _ := os.ReadDir(dir)
+	assert.Len(t, files, 2)
+	for _, file := range files {
+		content, _ := os.ReadFile(dir + "/" + file.Name())
+		assert.NotContains(t, string(content), "secret")
+	}
+
+	server.Close()
+	replayer, err := NewCassetteTransport(CassetteModeReplay, dir, nil)
+	assert.Nil(t, err)
+	client = &http.Client{Transport: replayer}
+	// identical requests are replayed in the recorded order and the last one is served repeatedly
+	for _, expected := range []struct{ page, body string }{
+		{"1", `{"page":1,"hit":1}`},
+		{"2", `{"page":2,"hit":2}`},
+		{"2", `{"page":2,"hit":3}`},
+		{"2", `{"page":2,"hit":3}`},
+	} {
+		res, err := client.Get(server.URL + "/issues?page=" + expected.page)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get("X-Total-Pages"))
+		assert.Empty(t, res.Header.Get("Set-Cookie"))
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, expected.body, string(body))
+	}
+
+	// requests never recorded would fail
+	_, err1 := client.Get(server.URL + "/issues?page=3")
+	assert.NotNil(t, err1)
+}
+
+func TestCassetteTransportRedact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	dir := t.TempDir()
+	recorder, err := NewCassetteTransport(CassetteModeRecord, dir, nil)
+	assert.Nil(t, err)
+	recorder.AddRedactKeys("X-Signature")
+	client := &http.Client{Transport: recorder}
+	res, err1 := client.Get(server.URL + "/repos?access_token=secret1&page=1&x-signature=secret2")
+	assert.Nil(t, err1)
+	_ = res.Body.Close()
+	res, err1 = client.Post(server.URL+"/auth", "application/json", strings.NewReader(`{"app_id":"a","app_secret":"secret3","nested":[{"password":"secret4"}]}`))
+	assert.Nil(t, err1)
+	_ = res.Body.Close()
+	res, err1 = client.Post(server.URL+"/oauth", "application/x-www-form-urlencoded", strings.NewReader(`client_id=a&client_secret=secret5`))
+	assert.Nil(t, err1)
+	_ = res.Body.Close()
+	server.Close()
+
+	files, _ := os.ReadDir(dir)
+	assert.Len(t, files, 3)
+	for _, file := range files {
+		content, _ := os.ReadFile(dir + "/" + file.Name())
+		assert.NotRegexp(t, `secret\d`, string(content))
+		assert.Contains(t, string(content), "REDACTED")
+	}
+
+	// requests with other credentials are replayed by the same cassettes
+	replayer, err := NewCassetteTransport(CassetteModeReplay, dir, nil)
+	assert.Nil(t, err)
+	replayer.AddRedactKeys("x-signature")
+	client = &http.Client{Transport: replayer}
+	res, err1 = client.Get(server.URL + "/repos?access_token=other&page=1&x-signature=other")
+	assert.Nil(t, err1)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, err1 = client.Post(server.URL+"/auth", "application/json", strings.NewReader(`{"app_id":"a","app_secret":"other","nested":[{"password":"other"}]}`))
+	assert.Nil(t, err1)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, err1 = client.Post(server.URL+"/oauth", "application/x-www-form-urlencoded", strings.NewReader(`client_id=a&client_secret=other`))
+	assert.Nil(t, err1)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestCassetteTransportRedactLoginResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if r.URL.Path == "/api.php/v1/tokens" {
+			_, _ = w.Write([]byte(`{"token":"zentao-session"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":0,"msg":"ok","tenant_access_token":"t-feishu","expire":7200}`))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	recorder, err := NewCassetteTransport(CassetteModeRecord, dir, nil)
+	assert.Nil(t, err)
+	client := &http.Client{Transport: recorder}
+
+	// the caller still gets the real token while recording
+	res, err1 := client.Post(server.URL+"/api.php/v1/tokens", "application/json", strings.NewReader(`{"account":"admin","password":"secret1"}`))
+	assert.Nil(t, err1)
+	body, _ := io.ReadAll(res.Body)
+	assert.Equal(t, `{"token":"zentao-session"}`, string(body))
+	res, err1 = client.Post(server.URL+"/open-apis/auth/v3/tenant_access_token/internal", "application/json", strings.NewReader(`{"app_id":"a","app_secret":"secret2"}`))
+	assert.Nil(t, err1)
+	_ = res.Body.Close()
+
+	files, _ := os.ReadDir(dir)
+	assert.Len(t, files, 2)
+	for _, file := range files {
+		content, _ := os.ReadFile(dir + "/" + file.Name())
+		assert.NotContains(t, string(content), "zentao-session")
+		assert.NotContains(t, string(content), "t-feishu")
+		assert.NotRegexp(t, `secret\d`, string(content))
+	}
+
+	replayer, err := NewCassetteTransport(CassetteModeReplay, dir, nil)
+	assert.Nil(t, err)
+	client = &http.Client{Transport: replayer}
+	res, err1 = client.Post(server.URL+"/open-apis/auth/v3/tenant_access_token/internal", "application/json", strings.NewReader(`{"app_id":"a","app_secret":"other"}`))
+	assert.Nil(t, err1)
+	body, _ = io.ReadAll(res.Body)
+	assert.JSONEq(t, `{"code":0,"msg":"ok","tenant_access_token":"REDACTED","expire":7200}`, string(body))
+}
+
+func TestNewCassetteTransportInvalidMode(t *testing.T) {
+	_, err := NewCassetteTransport("rewind", t.TempDir(), nil)
+	assert.NotNil(t, err)
+	_, err = NewCassetteTransport(CassetteModeReplay, "", nil)
+	assert.NotNil(t, err)
+}
+
+func TestApiClientReplayRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.WriteHeader(http.StatusOK)
+	}))
+	dir := t.TempDir()
+	recorder, err := NewCassetteTransport(CassetteModeRecord, dir, nil)
+	assert.Nil(t, err)
+	// ApiClient sends requests to the endpoint joined with a slash
+	req, _ := http.NewRequest(http.MethodOptions, server.URL+"/rate_limit", nil)
+	req.Header.Set("Content-Type", "application/json")
+	res, err1 := recorder.RoundTrip(req)
+	assert.Nil(t, err1)
+	_ = res.Body.Close()
+	server.Close()
+
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetConfig", "IN_SECURE_SKIP_VERIFY").Return("")
+	mockRes.On("GetConfig", "API_CASSETTE_MODE").Return(CassetteModeReplay)
+	mockRes.On("GetConfig", "API_CASSETTE_DIR").Return(dir)
+	mockRes.On("GetConfig", "API_CASSETTE_REDACT_KEYS").Return("")
+
+	// the server is gone, connectivity check must be skipped in replay mode
+	apiClient, err2 := NewApiClient(context.Background(), server.URL, nil, 0, "", mockRes)
+	assert.Nil(t, err2)
+	calculator := &ApiRateLimitCalculator{
+		ApiPath:  "rate_limit",
+		MaxRetry: 1,
+		DynamicRateLimit: func(res *http.Response) (int, time.Duration, errors.Error) {
+			limit, err := strconv.Atoi(res.Header.Get("X-RateLimit-Limit"))
+			return limit, 1 * time.Hour, errors.Convert(err)
+		},
+	}
+	requests, duration, err2 := calculator.Calculate(apiClient)
+	assert.Nil(t, err2)
+	assert.Equal(t, 4750, requests)
+	assert.Equal(t, time.Hour, duration)
+}
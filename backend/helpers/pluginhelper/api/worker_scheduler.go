@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-devlake/core/log"
 
 	"github.com/panjf2000/ants/v2"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // CalcTickInterval calculates tick interval for number of works to be
@@ -52,6 +53,9 @@ type WorkerScheduler struct {
 	counter      int32
 	logger       log.Logger
 	tickInterval time.Duration
+	// optional gauges to expose the scheduler state as metrics
+	queueDepth        prometheus.Gauge
+	tickIntervalGauge prometheus.Gauge
 }
 
 //var callframeEnabled = os.Getenv("ASYNC_CF") == "true"
@@ -91,8 +95,10 @@ func (s *WorkerScheduler) SubmitBlocking(task func() errors.Error) {
 		return
 	}
 	s.waitGroup.Add(1)
-	s.checkError(s.pool.Submit(func() {
+	s.incQueueDepth()
+	err := s.pool.Submit(func() {
 		defer s.waitGroup.Done()
+		defer s.decQueueDepth()
 
 		id := atomic.AddInt32(&s.counter, 1)
 		s.logger.Debug("schedulerJob >>> %d started", id)
@@ -112,7 +118,33 @@ func (s *WorkerScheduler) SubmitBlocking(task func() errors.Error) {
 				panic(err)
 			}
 		}
-	}))
+	})
+	if err != nil {
+		s.waitGroup.Done()
+		s.decQueueDepth()
+		s.checkError(err)
+	}
+}
+
+// setMetrics attaches gauges to expose queue depth and tick interval of the WorkerScheduler
+func (s *WorkerScheduler) setMetrics(queueDepth, tickInterval prometheus.Gauge) {
+	s.queueDepth = queueDepth
+	s.tickIntervalGauge = tickInterval
+	if s.tickIntervalGauge != nil {
+		s.tickIntervalGauge.Set(s.tickInterval.Seconds())
+	}
+}
+
+func (s *WorkerScheduler) incQueueDepth() {
+	if s.queueDepth != nil {
+		s.queueDepth.Inc()
+	}
+}
+
+func (s *WorkerScheduler) decQueueDepth() {
+	if s.queueDepth != nil {
+		s.queueDepth.Dec()
+	}
 }
 
 /*
@@ -173,6 +205,9 @@ func (s *WorkerScheduler) WaitAsync() errors.Error {
 func (s *WorkerScheduler) Reset(interval time.Duration) {
 	s.tickInterval = interval
 	s.ticker.Reset(interval)
+	if s.tickIntervalGauge != nil {
+		s.tickIntervalGauge.Set(interval.Seconds())
+	}
 }
 
 // GetTickInterval returns current tick interval of the WorkScheduler
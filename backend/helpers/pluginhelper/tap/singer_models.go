@@ -35,7 +35,9 @@ type (
 	SingerTapConfig struct {
 		TapExecutable        string
 		StreamPropertiesFile string
-		IsLegacy             bool
+		// StreamProperties the deserialized catalog/properties, StreamPropertiesFile is ignored when it is set
+		StreamProperties *SingerTapProperties
+		IsLegacy         bool
 	}
 
 	// SingerTapProperties wraps SingerTapStreams
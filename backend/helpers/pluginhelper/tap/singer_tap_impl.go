@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/mitchellh/hashstructure"
 	"os"
+	"os/exec"
 	"path/filepath"
 )
 
@@ -129,14 +130,17 @@ func (t *SingerTap) GetName() string {
 
 // Run implements Tap.Run
 func (t *SingerTap) Run(ctx context.Context) (<-chan *Response, errors.Error) {
-	cmd := utils.CreateCmd(
-		t.cmd,
+	// run the tap directly instead of through a shell so no argument gets interpreted
+	args := []string{
 		"--config",
 		t.configFile.path,
 		ifElse(t.IsLegacy, "--properties", "--catalog"),
 		t.propertiesFile.path,
-		ifElse(t.stateFile.path != "", "--state "+t.stateFile.path, ""),
-	)
+	}
+	if t.stateFile.path != "" {
+		args = append(args, "--state", t.stateFile.path)
+	}
+	cmd := exec.Command(t.cmd, args...)
 	rawStream, err := utils.StreamProcess(cmd, &utils.StreamProcessOptions{
 		OnStdout: func(b []byte) (any, errors.Error) {
 			var output Output[json.RawMessage]
@@ -167,6 +171,12 @@ func (t *SingerTap) Run(ctx context.Context) (<-chan *Response, errors.Error) {
 }
 
 func readProperties(tempDir string, cfg *SingerTapConfig) (*fileData[SingerTapProperties], errors.Error) {
+	if cfg.StreamProperties != nil {
+		return &fileData[SingerTapProperties]{
+			path:    filepath.Join(tempDir, "properties.json"),
+			content: cfg.StreamProperties,
+		}, nil
+	}
 	globalDir := config.GetConfig().GetString(singerPropertiesDir)
 	_, err := os.Stat(globalDir)
 	if err != nil {
@@ -19,6 +19,7 @@ package e2e
 
 import (
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/ae/impl"
 	"github.com/apache/incubator-devlake/plugins/ae/models"
 	"github.com/apache/incubator-devlake/plugins/ae/tasks"
@@ -55,3 +56,50 @@ func TestAEProjectDataFlow(t *testing.T) {
 		),
 	)
 }
+
+func TestAEProjectCollectorDataFlow(t *testing.T) {
+	var ae impl.AE
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "ae", ae)
+	// responses are replayed from the cassette, set API_CASSETTE_MODE=record to record it again
+	dataflowTester.UseCassette("./cassettes/project")
+
+	taskData := &tasks.AeTaskData{
+		Options: &tasks.AeOptions{
+			ConnectionId: 1,
+			ProjectId:    13,
+		},
+	}
+	apiClient, err := tasks.CreateApiClient(dataflowTester.TaskContext(taskData), &models.AeConnection{
+		AeConn: models.AeConn{
+			RestConnection: helper.RestConnection{
+				Endpoint: "http://34.214.122.134:30012/",
+			},
+			AeAppKey: models.AeAppKey{
+				AppId:     "app",
+				SecretKey: "secret",
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	taskData.ApiClient = apiClient
+
+	// verify collection, the collected records are extracted to compare with the snapshot
+	dataflowTester.FlushRawTable("_raw_ae_project")
+	dataflowTester.Subtask(tasks.CollectProjectMeta, taskData)
+	dataflowTester.FlushTabler(&models.AEProject{})
+	dataflowTester.Subtask(tasks.ExtractProjectMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AEProject{},
+		"./snapshot_tables/_tool_ae_projects.csv",
+		[]string{
+			"connection_id",
+			"id",
+			"git_url",
+			"priority",
+			"ae_create_time",
+			"ae_update_time",
+		},
+	)
+}
@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+func MakeDataSourcePipelinePlanV200(subtaskMetas []plugin.SubTaskMeta, connectionId uint64, bpScopes []*plugin.BlueprintScopeV200, syncPolicy *plugin.BlueprintSyncPolicy) (plugin.PipelinePlan, []plugin.Scope, errors.Error) {
+	connection := &models.AzureConnection{}
+	err := connectionHelper.FirstById(connection, connectionId)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := makeDataSourcePipelinePlanV200(subtaskMetas, bpScopes, connection, syncPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+	scopes, err := makeScopesV200(bpScopes, connectionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, scopes, nil
+}
+
+func makeDataSourcePipelinePlanV200(
+	subtaskMetas []plugin.SubTaskMeta,
+	bpScopes []*plugin.BlueprintScopeV200,
+	connection *models.AzureConnection,
+	syncPolicy *plugin.BlueprintSyncPolicy,
+) (plugin.PipelinePlan, errors.Error) {
+	plan := make(plugin.PipelinePlan, 0, len(bpScopes))
+	for _, bpScope := range bpScopes {
+		var stage plugin.PipelineStage
+		repo, err := getRepoByConnectionIdAndScopeId(connection.ID, bpScope.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		// construct task options for Azure, the project id works wherever the project name does
+		options := make(map[string]interface{})
+		options["connectionId"] = connection.ID
+		options["project"] = repo.ProjectId
+		options["repositoryId"] = repo.AzureId
+		options["transformationRuleId"] = repo.TransformationRuleId
+		if syncPolicy != nil && syncPolicy.CreatedDateAfter != nil {
+			options["createdDateAfter"] = syncPolicy.CreatedDateAfter.Format(time.RFC3339)
+		}
+
+		subtasks, err := helper.MakePipelinePlanSubtasks(subtaskMetas, bpScope.Entities)
+		if err != nil {
+			return nil, err
+		}
+		stage = append(stage, &plugin.PipelineTask{
+			Plugin:   "azure",
+			Subtasks: subtasks,
+			Options:  options,
+		})
+
+		// collect git data by gitextractor if CODE was requested
+		if utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_CODE) && repo.RemoteURL != "" {
+			cloneUrl, err := errors.Convert01(url.Parse(repo.RemoteURL))
+			if err != nil {
+				return nil, err
+			}
+			// azure takes the personal access token as the password of any user
+			cloneUrl.User = url.UserPassword("git", connection.Password)
+			stage = append(stage, &plugin.PipelineTask{
+				Plugin: "gitextractor",
+				Options: map[string]interface{}{
+					"url":    cloneUrl.String(),
+					"repoId": didgen.NewDomainIdGenerator(&models.AzureRepo{}).Generate(connection.ID, repo.AzureId),
+					"proxy":  connection.Proxy,
+				},
+			})
+		}
+		plan = append(plan, stage)
+	}
+
+	return plan, nil
+}
+
+func makeScopesV200(bpScopes []*plugin.BlueprintScopeV200, connectionId uint64) ([]plugin.Scope, errors.Error) {
+	scopes := make([]plugin.Scope, 0)
+	// the board is the project, which is shared by its repos
+	boardIds := make(map[string]bool)
+	for _, bpScope := range bpScopes {
+		repo, err := getRepoByConnectionIdAndScopeId(connectionId, bpScope.Id)
+		if err != nil {
+			return nil, err
+		}
+		id := didgen.NewDomainIdGenerator(&models.AzureRepo{}).Generate(connectionId, repo.AzureId)
+
+		if utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_CODE_REVIEW) ||
+			utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_CODE) {
+			scopeRepo := code.NewRepo(id, repo.Name)
+			scopeRepo.Url = repo.WebUrl
+			scopes = append(scopes, scopeRepo)
+		}
+		if utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_CICD) {
+			scopeCICD := devops.NewCicdScope(id, repo.Name)
+			scopeCICD.Url = repo.WebUrl
+			scopes = append(scopes, scopeCICD)
+		}
+		if utils.StringsContains(bpScope.Entities, plugin.DOMAIN_TYPE_TICKET) {
+			boardId := didgen.NewDomainIdGenerator(&models.AzureProject{}).Generate(connectionId, repo.ProjectId)
+			if !boardIds[boardId] {
+				boardIds[boardId] = true
+				scopes = append(scopes, ticket.NewBoard(boardId, getProjectName(connectionId, repo.ProjectId)))
+			}
+		}
+	}
+	return scopes, nil
+}
+
+// getProjectName returns the name of the project if it has been collected, or its id otherwise
+func getProjectName(connectionId uint64, projectId string) string {
+	project := &models.AzureProject{}
+	db := basicRes.GetDal()
+	err := db.First(project, dal.Where("connection_id = ? AND azure_id = ?", connectionId, projectId))
+	if err != nil {
+		return projectId
+	}
+	return project.Name
+}
+
+// getRepoByConnectionIdAndScopeId get the repo by the connectionId and the scopeId
+func getRepoByConnectionIdAndScopeId(connectionId uint64, scopeId string) (*models.AzureRepo, errors.Error) {
+	repo := &models.AzureRepo{}
+	db := basicRes.GetDal()
+	err := db.First(repo, dal.Where("connection_id = ? AND azure_id = ?", connectionId, scopeId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("can not find repo by connection [%d] scope [%s]", connectionId, scopeId))
+		}
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find repo by connection [%d] scope [%s]", connectionId, scopeId))
+	}
+	return repo, nil
+}
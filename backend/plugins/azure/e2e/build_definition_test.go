@@ -26,8 +26,8 @@ import (
 )
 
 func TestAzureBuildDefinitionDataFlow(t *testing.T) {
-	var github impl.Azure
-	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", github)
+	var azure impl.Azure
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azure", azure)
 
 	taskData := &tasks.AzureTaskData{
 		Options: &tasks.AzureOptions{
@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/azure/impl"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/azure/tasks"
+)
+
+func TestAzureIterationDataFlow(t *testing.T) {
+	var azure impl.Azure
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azure", azure)
+
+	taskData := &tasks.AzureTaskData{
+		Options: &tasks.AzureOptions{
+			ConnectionId: 1,
+			Project:      "test",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_projects.csv", "_raw_azure_api_projects")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_iterations.csv", "_raw_azure_api_iterations")
+
+	// verify project extraction
+	dataflowTester.FlushTabler(&models.AzureProject{})
+	dataflowTester.Subtask(tasks.ExtractApiProjectMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzureProject{},
+		"./snapshot_tables/_tool_azure_projects.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"azure_id",
+			"name",
+			"description",
+			"url",
+		),
+	)
+
+	// verify iteration extraction
+	dataflowTester.FlushTabler(&models.AzureIteration{})
+	dataflowTester.Subtask(tasks.ExtractApiIterationsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzureIteration{},
+		"./snapshot_tables/_tool_azure_iterations.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"azure_id",
+			"identifier",
+			"project_id",
+			"name",
+			"path",
+			"url",
+			"start_date",
+			"finish_date",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Board{})
+	dataflowTester.Subtask(tasks.ConvertProjectMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Board{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/boards.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.Sprint{})
+	dataflowTester.FlushTabler(&ticket.BoardSprint{})
+	dataflowTester.Subtask(tasks.ConvertIterationsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Sprint{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/sprints.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardSprint{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_sprints.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
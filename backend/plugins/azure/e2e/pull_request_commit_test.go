@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/azure/impl"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/azure/tasks"
+)
+
+func TestAzurePullRequestCommitDataFlow(t *testing.T) {
+	var azure impl.Azure
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azure", azure)
+
+	taskData := &tasks.AzureTaskData{
+		Options: &tasks.AzureOptions{
+			ConnectionId: 1,
+			Project:      "test",
+			RepositoryId: "5dc348ab-98a9-4c49-95da-b70b24a62932",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_pull_request_commits.csv", "_raw_azure_api_pull_request_commits")
+	// the pull requests tell the repository of the commits
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_azure_pull_requests.csv", &models.AzurePullRequest{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.AzurePullRequestCommit{})
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestCommitsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzurePullRequestCommit{},
+		"./snapshot_tables/_tool_azure_pull_request_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"pull_request_id",
+			"commit_sha",
+			"author_name",
+			"author_email",
+			"authored_date",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.PullRequestCommit{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestCommitsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_commits.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
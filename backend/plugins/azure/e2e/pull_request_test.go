@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/azure/impl"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/azure/tasks"
+)
+
+func TestAzurePullRequestDataFlow(t *testing.T) {
+	var azure impl.Azure
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azure", azure)
+
+	taskData := &tasks.AzureTaskData{
+		Options: &tasks.AzureOptions{
+			ConnectionId: 1,
+			Project:      "test",
+			RepositoryId: "5dc348ab-98a9-4c49-95da-b70b24a62932",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_pull_requests.csv", "_raw_azure_api_pull_requests")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_pull_request_threads.csv", "_raw_azure_api_pull_request_threads")
+
+	// verify pull request extraction
+	dataflowTester.FlushTabler(&models.AzurePullRequest{})
+	dataflowTester.FlushTabler(&models.AzurePullRequestReviewer{})
+	dataflowTester.FlushTabler(&models.AzureAccount{})
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzurePullRequest{},
+		"./snapshot_tables/_tool_azure_pull_requests.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"azure_id",
+			"repository_id",
+			"fork_repository_id",
+			"status",
+			"merge_status",
+			"is_draft",
+			"title",
+			"description",
+			"url",
+			"created_by_id",
+			"created_by_name",
+			"creation_date",
+			"closed_date",
+			"source_ref_name",
+			"target_ref_name",
+			"last_merge_source_commit_sha",
+			"last_merge_target_commit_sha",
+			"last_merge_commit_sha",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.AzurePullRequestReviewer{},
+		"./snapshot_tables/_tool_azure_pull_request_reviewers.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"pull_request_id",
+			"reviewer_id",
+			"display_name",
+			"unique_name",
+			"vote",
+			"is_required",
+		),
+	)
+
+	// verify pull request thread extraction
+	dataflowTester.FlushTabler(&models.AzurePullRequestComment{})
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestThreadsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzurePullRequestComment{},
+		"./snapshot_tables/_tool_azure_pull_request_comments.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"pull_request_id",
+			"thread_id",
+			"comment_id",
+			"parent_comment_id",
+			"author_id",
+			"author_name",
+			"content",
+			"comment_type",
+			"thread_status",
+			"thread_type",
+			"vote",
+			"file_path",
+			"line",
+			"published_date",
+			"last_updated_date",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.AzureAccount{},
+		"./snapshot_tables/_tool_azure_accounts.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"azure_id",
+			"display_name",
+			"unique_name",
+			"image_url",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestCommentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_comments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&crossdomain.Account{})
+	dataflowTester.Subtask(tasks.ConvertAccountsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.Account{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/accounts.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
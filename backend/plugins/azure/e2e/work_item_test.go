@@ -0,0 +1,142 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/azure/impl"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/apache/incubator-devlake/plugins/azure/tasks"
+)
+
+func TestAzureWorkItemDataFlow(t *testing.T) {
+	var azure impl.Azure
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azure", azure)
+
+	taskData := &tasks.AzureTaskData{
+		Options: &tasks.AzureOptions{
+			ConnectionId: 1,
+			Project:      "test",
+			AzureTransformationRule: &models.AzureTransformationRule{
+				TypeMappings: map[string]interface{}{
+					"Impediment": ticket.INCIDENT,
+				},
+				StatusMappings: map[string]interface{}{
+					"Resolved":     ticket.IN_PROGRESS,
+					"Ready for QA": ticket.IN_PROGRESS,
+				},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_work_items.csv", "_raw_azure_api_work_items")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azure_api_work_item_updates.csv", "_raw_azure_api_work_item_updates")
+	// the work items are converted within the project and linked to its iterations
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_azure_projects.csv", &models.AzureProject{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_azure_iterations.csv", &models.AzureIteration{})
+
+	// verify work item extraction
+	dataflowTester.FlushTabler(&models.AzureWorkItem{})
+	dataflowTester.FlushTabler(&models.AzureAccount{})
+	dataflowTester.Subtask(tasks.ExtractApiWorkItemsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzureWorkItem{},
+		"./snapshot_tables/_tool_azure_work_items.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"azure_id",
+			"project_id",
+			"rev",
+			"type",
+			"std_type",
+			"state",
+			"std_status",
+			"title",
+			"description",
+			"url",
+			"parent_id",
+			"iteration_id",
+			"iteration_path",
+			"area_path",
+			"priority",
+			"severity",
+			"tags",
+			"story_point",
+			"original_estimate_hours",
+			"completed_work_hours",
+			"remaining_work_hours",
+			"creator_id",
+			"creator_name",
+			"assignee_id",
+			"assignee_name",
+			"created_date",
+			"changed_date",
+			"resolved_date",
+			"closed_date",
+		),
+	)
+
+	// verify work item update extraction
+	dataflowTester.FlushTabler(&models.AzureWorkItemChange{})
+	dataflowTester.Subtask(tasks.ExtractApiWorkItemUpdatesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.AzureWorkItemChange{},
+		"./snapshot_tables/_tool_azure_work_item_changes.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"work_item_id",
+			"update_id",
+			"field",
+			"old_value",
+			"new_value",
+			"author_id",
+			"author_name",
+			"changed_date",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.SprintIssue{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Issue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.SprintIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/sprint_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.Subtask(tasks.ConvertWorkItemChangelogsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueChangelogs{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_changelogs.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -19,6 +19,8 @@ package impl
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -38,6 +40,8 @@ var _ plugin.PluginApi = (*Azure)(nil)
 var _ plugin.PluginModel = (*Azure)(nil)
 var _ plugin.CloseablePluginTask = (*Azure)(nil)
 var _ plugin.PluginMigration = (*Azure)(nil)
+var _ plugin.PluginSource = (*Azure)(nil)
+var _ plugin.DataSourcePluginBlueprintV200 = (*Azure)(nil)
 
 // PluginEntry exports for Framework to search and load
 var PluginEntry Azure //nolint
@@ -53,12 +57,37 @@ func (p Azure) Init(basicRes context.BasicRes) errors.Error {
 	return nil
 }
 
+func (p Azure) Connection() interface{} {
+	return &models.AzureConnection{}
+}
+
+func (p Azure) Scope() interface{} {
+	return &models.AzureRepo{}
+}
+
+func (p Azure) TransformationRule() interface{} {
+	return &models.AzureTransformationRule{}
+}
+
 func (p Azure) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
+		&models.AzureAccount{},
 		&models.AzureBuild{},
 		&models.AzureBuildDefinition{},
+		&models.AzureCommit{},
 		&models.AzureConnection{},
+		&models.AzureIteration{},
+		&models.AzureProject{},
+		&models.AzurePullRequest{},
+		&models.AzurePullRequestComment{},
+		&models.AzurePullRequestCommit{},
+		&models.AzurePullRequestReviewer{},
 		&models.AzureRepo{},
+		&models.AzureRepoCommit{},
+		&models.AzureTimelineRecord{},
+		&models.AzureTransformationRule{},
+		&models.AzureWorkItem{},
+		&models.AzureWorkItemChange{},
 	}
 }
 
@@ -68,6 +97,38 @@ func (p Azure) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractApiRepoMeta,
 		tasks.CollectApiBuildDefinitionMeta,
 		tasks.ExtractApiBuildDefinitionMeta,
+		tasks.CollectApiPullRequestsMeta,
+		tasks.ExtractApiPullRequestsMeta,
+		tasks.CollectApiPullRequestCommitsMeta,
+		tasks.ExtractApiPullRequestCommitsMeta,
+		tasks.CollectApiPullRequestThreadsMeta,
+		tasks.ExtractApiPullRequestThreadsMeta,
+		tasks.CollectApiCommitsMeta,
+		tasks.ExtractApiCommitsMeta,
+		tasks.CollectApiBuildsMeta,
+		tasks.ExtractApiBuildsMeta,
+		tasks.CollectApiTimelineRecordsMeta,
+		tasks.ExtractApiTimelineRecordsMeta,
+		tasks.CollectApiProjectMeta,
+		tasks.ExtractApiProjectMeta,
+		tasks.CollectApiIterationsMeta,
+		tasks.ExtractApiIterationsMeta,
+		tasks.CollectApiWorkItemsMeta,
+		tasks.ExtractApiWorkItemsMeta,
+		tasks.CollectApiWorkItemUpdatesMeta,
+		tasks.ExtractApiWorkItemUpdatesMeta,
+		tasks.ConvertRepoMeta,
+		tasks.ConvertAccountsMeta,
+		tasks.ConvertPullRequestsMeta,
+		tasks.ConvertPullRequestCommitsMeta,
+		tasks.ConvertPullRequestCommentsMeta,
+		tasks.ConvertCommitsMeta,
+		tasks.ConvertBuildsMeta,
+		tasks.ConvertTimelineRecordsMeta,
+		tasks.ConvertProjectMeta,
+		tasks.ConvertIterationsMeta,
+		tasks.ConvertWorkItemsMeta,
+		tasks.ConvertWorkItemChangelogsMeta,
 	}
 }
 
@@ -93,15 +154,67 @@ func (p Azure) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]in
 		return nil, err
 	}
 
+	var createdDateAfter time.Time
+	if op.CreatedDateAfter != "" {
+		createdDateAfter, err = errors.Convert01(time.Parse(time.RFC3339, op.CreatedDateAfter))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `createdDateAfter`")
+		}
+	}
+
+	db := taskCtx.GetDal()
+	var repo *models.AzureRepo
+	if op.RepositoryId != "" {
+		repo = &models.AzureRepo{}
+		err = db.First(repo, dal.Where("connection_id = ? AND azure_id = ?", op.ConnectionId, op.RepositoryId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find repo: %s", op.RepositoryId))
+		}
+		if err != nil {
+			// the repo is collected by the task if it was not put as a scope
+			if op.Project == "" {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("project is required for repo %s which is not in scope", op.RepositoryId))
+			}
+			repo = nil
+		} else {
+			if op.Project == "" {
+				op.Project = repo.ProjectId
+			}
+			if op.TransformationRuleId == 0 {
+				op.TransformationRuleId = repo.TransformationRuleId
+			}
+		}
+	}
+
+	if op.AzureTransformationRule == nil && op.TransformationRuleId != 0 {
+		var transformationRule models.AzureTransformationRule
+		err = db.First(&transformationRule, dal.Where("id = ?", op.TransformationRuleId))
+		if err != nil {
+			if db.IsErrorNotFound(err) {
+				return nil, errors.Default.Wrap(err, fmt.Sprintf("can not find transformationRules by transformationRuleId [%d]", op.TransformationRuleId))
+			}
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find transformationRules by transformationRuleId [%d]", op.TransformationRuleId))
+		}
+		op.AzureTransformationRule = &transformationRule
+	}
+	if op.AzureTransformationRule == nil {
+		op.AzureTransformationRule = new(models.AzureTransformationRule)
+	}
+
 	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
 	if err != nil {
 		return nil, err
 	}
-	return &tasks.AzureTaskData{
+	taskData := &tasks.AzureTaskData{
 		Options:    op,
 		ApiClient:  apiClient,
 		Connection: connection,
-	}, nil
+		Repo:       repo,
+	}
+	if !createdDateAfter.IsZero() {
+		taskData.CreatedDateAfter = &createdDateAfter
+	}
+	return taskData, nil
 }
 
 // PkgPath information lost when compiled as plugin(.so)
@@ -109,6 +222,10 @@ func (p Azure) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/azure"
 }
 
+func (p Azure) MakeDataSourcePipelinePlanV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200, syncPolicy plugin.BlueprintSyncPolicy) (plugin.PipelinePlan, []plugin.Scope, errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes, &syncPolicy)
+}
+
 func (p Azure) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 	return map[string]map[string]plugin.ApiResourceHandler{
 		"test": {
@@ -123,6 +240,22 @@ func (p Azure) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"PATCH":  api.PatchConnection,
 			"DELETE": api.DeleteConnection,
 		},
+		"connections/:connectionId/scopes/:repoId": {
+			"GET":   api.GetScope,
+			"PATCH": api.UpdateScope,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"transformation_rules": {
+			"POST": api.CreateTransformationRule,
+			"GET":  api.GetTransformationRuleList,
+		},
+		"transformation_rules/:id": {
+			"PATCH": api.UpdateTransformationRule,
+			"GET":   api.GetTransformationRule,
+		},
 	}
 }
 
@@ -18,23 +18,30 @@ limitations under the License.
 package models
 
 import (
-	"github.com/apache/incubator-devlake/core/models/common"
 	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
 )
 
+// AzureBuild is a run of a pipeline, SourceVersion is the commit sha being built
 type AzureBuild struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	AzureId        int    `gorm:"primaryKey"`
+	ProjectId      string `gorm:"type:varchar(255)"`
+	RepositoryId   string `gorm:"index;type:varchar(255)"`
+	DefinitionId   int
+	DefinitionName string `gorm:"type:varchar(255)"`
+	BuildNumber    string `gorm:"type:varchar(255)"`
+	Status         string `gorm:"type:varchar(100)"`
+	Result         string `gorm:"type:varchar(100)"`
+	Reason         string `gorm:"type:varchar(100)"`
+	SourceBranch   string `gorm:"type:varchar(255)"`
+	SourceVersion  string `gorm:"type:varchar(40)"`
+	Url            string `gorm:"type:varchar(255)"`
+	QueueTime      *time.Time
+	StartTime      *time.Time
+	FinishTime     *time.Time
 	common.NoPKModel
-	// collected fields
-	ConnectionId      uint64    `gorm:"primaryKey"`
-	JobName           string    `gorm:"primaryKey;type:varchar(255)"`
-	Duration          float64   // build time
-	DisplayName       string    `gorm:"type:varchar(255)"` // "#7"
-	EstimatedDuration float64   // EstimatedDuration
-	Number            int64     `gorm:"primaryKey"`
-	Result            string    // Result
-	Timestamp         int64     // start time
-	StartTime         time.Time // convered by timestamp
-	CommitSha         string    `gorm:"type:varchar(255)"`
 }
 
 func (AzureBuild) TableName() string {
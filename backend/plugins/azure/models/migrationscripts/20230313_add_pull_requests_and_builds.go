@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/azure/models/migrationscripts/archived"
+)
+
+type azureRepo20230313 struct {
+	TransformationRuleId uint64
+}
+
+func (azureRepo20230313) TableName() string {
+	return "_tool_azure_repos"
+}
+
+type addPullRequestsAndBuilds struct{}
+
+func (*addPullRequestsAndBuilds) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.AutoMigrate(&azureRepo20230313{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.AzureTransformationRule{},
+		&archived.AzureAccount{},
+		&archived.AzurePullRequest{},
+		&archived.AzurePullRequestReviewer{},
+		&archived.AzurePullRequestComment{},
+		&archived.AzurePullRequestCommit{},
+		&archived.AzureCommit{},
+		&archived.AzureRepoCommit{},
+		&archived.AzureBuild{},
+		&archived.AzureTimelineRecord{},
+	)
+}
+
+func (*addPullRequestsAndBuilds) Version() uint64 {
+	return 20230313000001
+}
+
+func (*addPullRequestsAndBuilds) Name() string {
+	return "add azure pull request, commit, build and timeline tables"
+}
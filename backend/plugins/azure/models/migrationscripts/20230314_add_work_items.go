@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/azure/models/migrationscripts/archived"
+	"gorm.io/datatypes"
+)
+
+type azureTransformationRule20230314 struct {
+	TypeMappings   datatypes.JSONMap
+	StatusMappings datatypes.JSONMap
+}
+
+func (azureTransformationRule20230314) TableName() string {
+	return "_tool_azure_transformation_rules"
+}
+
+type addWorkItems struct{}
+
+func (*addWorkItems) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := db.AutoMigrate(&azureTransformationRule20230314{})
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.AzureProject{},
+		&archived.AzureWorkItem{},
+		&archived.AzureWorkItemChange{},
+		&archived.AzureIteration{},
+	)
+}
+
+func (*addWorkItems) Version() uint64 {
+	return 20230314000001
+}
+
+func (*addWorkItems) Name() string {
+	return "add azure project, work item and iteration tables"
+}
@@ -25,5 +25,7 @@ import (
 func All() []plugin.MigrationScript {
 	return []plugin.MigrationScript{
 		new(addInitTables20220825),
+		new(addPullRequestsAndBuilds),
+		new(addWorkItems),
 	}
 }
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// AzurePullRequestComment is a comment of a pull request thread, FilePath and Line are set for comments on the diff.
+// Votes are recorded by the system in threads of type VoteUpdate, Vote holds the value of them
+type AzurePullRequestComment struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	PullRequestId   int    `gorm:"primaryKey"`
+	ThreadId        int    `gorm:"primaryKey"`
+	CommentId       int    `gorm:"primaryKey"`
+	ParentCommentId int
+	AuthorId        string `gorm:"type:varchar(255)"`
+	AuthorName      string `gorm:"type:varchar(255)"`
+	Content         string
+	CommentType     string `gorm:"type:varchar(100)"`
+	ThreadStatus    string `gorm:"type:varchar(100)"`
+	ThreadType      string `gorm:"type:varchar(100)"`
+	Vote            int
+	FilePath        string `gorm:"type:varchar(255)"`
+	Line            int
+	PublishedDate   time.Time
+	LastUpdatedDate *time.Time
+	common.NoPKModel
+}
+
+func (AzurePullRequestComment) TableName() string {
+	return "_tool_azure_pull_request_comments"
+}
@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// AzurePullRequestReviewer holds the vote of a reviewer, 10 approved, 5 approved with suggestions,
+// 0 no vote, -5 waiting for author and -10 rejected
+type AzurePullRequestReviewer struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	PullRequestId int    `gorm:"primaryKey"`
+	ReviewerId    string `gorm:"primaryKey;type:varchar(255)"`
+	DisplayName   string `gorm:"type:varchar(255)"`
+	UniqueName    string `gorm:"type:varchar(255)"`
+	Vote          int
+	IsRequired    bool
+	common.NoPKModel
+}
+
+func (AzurePullRequestReviewer) TableName() string {
+	return "_tool_azure_pull_request_reviewers"
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	TimelineRecordTypeStage = "Stage"
+	TimelineRecordTypeJob   = "Job"
+)
+
+// AzureTimelineRecord is a stage or a job of a build, tasks and checkpoints of the timeline are not kept.
+// RecordId is derived from the stage or job name, so it is only unique within a build
+type AzureTimelineRecord struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	BuildId      int    `gorm:"primaryKey"`
+	RecordId     string `gorm:"primaryKey;type:varchar(255)"`
+	ParentId     string `gorm:"type:varchar(255)"`
+	Type         string `gorm:"type:varchar(100)"`
+	Name         string `gorm:"type:varchar(255)"`
+	State        string `gorm:"type:varchar(100)"`
+	Result       string `gorm:"type:varchar(100)"`
+	StartTime    *time.Time
+	FinishTime   *time.Time
+	common.NoPKModel
+}
+
+func (AzureTimelineRecord) TableName() string {
+	return "_tool_azure_timeline_records"
+}
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// AzureWorkItemChange is the change of a field in an update of a work item, only the fields
+// converted into changelogs are kept, the values of identity fields are the ids of the accounts
+type AzureWorkItemChange struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	WorkItemId   int    `gorm:"primaryKey"`
+	UpdateId     int    `gorm:"primaryKey"`
+	Field        string `gorm:"primaryKey;type:varchar(255)"`
+	OldValue     string
+	NewValue     string
+	AuthorId     string `gorm:"type:varchar(255)"`
+	AuthorName   string `gorm:"type:varchar(255)"`
+	ChangedDate  time.Time
+	common.NoPKModel
+}
+
+func (AzureWorkItemChange) TableName() string {
+	return "_tool_azure_work_item_changes"
+}
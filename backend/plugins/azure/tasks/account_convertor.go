@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertAccountsMeta = plugin.SubTaskMeta{
+	Name:             "convertAccounts",
+	EntryPoint:       ConvertAccounts,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_accounts into domain layer table accounts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertAccounts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEMS_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(dal.From(&models.AzureAccount{}), dal.Where("connection_id = ?", data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzureAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzureAccount{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			azureAccount := inputRow.(*models.AzureAccount)
+			account := &crossdomain.Account{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: accountIdGen.Generate(azureAccount.ConnectionId, azureAccount.AzureId),
+				},
+				FullName:  azureAccount.DisplayName,
+				UserName:  azureAccount.UniqueName,
+				AvatarUrl: azureAccount.ImageUrl,
+			}
+			// the unique name is the email for the accounts of azure active directory and microsoft accounts
+			if strings.Contains(azureAccount.UniqueName, "@") {
+				account.Email = azureAccount.UniqueName
+			}
+			return []interface{}{account}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,223 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+const continuationTokenHeader = "x-ms-continuationtoken"
+
+type AzurePullRequestInput struct {
+	AzureId int
+}
+
+type AzureBuildInput struct {
+	AzureId int
+}
+
+type AzureWorkItemInput struct {
+	AzureId int
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, table string) (*api.RawDataSubTaskArgs, *AzureTaskData) {
+	data := taskCtx.GetData().(*AzureTaskData)
+	rawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: AzureApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Project:      data.Options.Project,
+			RepositoryId: data.Options.RepositoryId,
+		},
+		Table: table,
+	}
+	return rawDataSubTaskArgs, data
+}
+
+// CreateProjectRawDataSubTaskArgs creates the args for the subtasks working on the whole project like the boards,
+// so the raw data are shared by all the repos of the project
+func CreateProjectRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, table string) (*api.RawDataSubTaskArgs, *AzureTaskData) {
+	data := taskCtx.GetData().(*AzureTaskData)
+	rawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: AzureApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Project:      data.Options.Project,
+		},
+		Table: table,
+	}
+	return rawDataSubTaskArgs, data
+}
+
+// getProject loads the project of the task, which might be given by either its id or its name
+func getProject(taskCtx plugin.SubTaskContext) (*models.AzureProject, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+	project := &models.AzureProject{}
+	err := db.First(project, dal.Where(
+		"connection_id = ? AND (azure_id = ? OR name = ?)",
+		data.Options.ConnectionId, data.Options.Project, data.Options.Project,
+	))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to load project %s", data.Options.Project))
+	}
+	return project, nil
+}
+
+// GetQueryForTopSkip builds the query for the apis paged by $top and $skip
+func GetQueryForTopSkip(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("api-version", "7.1-preview.1")
+	query.Set("$top", fmt.Sprintf("%v", reqData.Pager.Size))
+	query.Set("$skip", fmt.Sprintf("%v", reqData.Pager.Skip))
+	return query, nil
+}
+
+// GetQueryForContinuationToken builds the query for the apis paged by continuation tokens
+func GetQueryForContinuationToken(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("api-version", "7.1-preview.1")
+	query.Set("$top", fmt.Sprintf("%v", reqData.Pager.Size))
+	if reqData.CustomData != nil {
+		query.Set("continuationToken", reqData.CustomData.(string))
+	}
+	return query, nil
+}
+
+// GetNextPageCustomData reads the continuation token of the next page from the response headers
+func GetNextPageCustomData(_ *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	token := prevPageResponse.Header.Get(continuationTokenHeader)
+	if token == "" {
+		return "", api.ErrFinishCollect
+	}
+	return token, nil
+}
+
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	if res.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	var data struct {
+		Value []json.RawMessage `json:"value"`
+	}
+	err := api.UnmarshalResponse(res, &data)
+	return data.Value, err
+}
+
+func ignoreHTTPStatus404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusUnauthorized {
+		return errors.Unauthorized.New("authentication failed, please check your personal access token")
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
+
+// skipWithoutRepository tells subtasks working on a single repository to do nothing for project-wide tasks
+func skipWithoutRepository(taskCtx plugin.SubTaskContext) bool {
+	data := taskCtx.GetData().(*AzureTaskData)
+	if data.Options.RepositoryId == "" {
+		taskCtx.GetLogger().Info("no repositoryId given, skip %s", taskCtx.GetName())
+		return true
+	}
+	return false
+}
+
+func formatApiTime(t *time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func GetPullRequestsIterator(taskCtx plugin.SubTaskContext) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+	clauses := []dal.Clause{
+		dal.Select("pr.azure_id"),
+		dal.From("_tool_azure_pull_requests pr"),
+		dal.Where(
+			`pr.repository_id = ? and pr.connection_id = ?`,
+			data.Options.RepositoryId, data.Options.ConnectionId,
+		),
+	}
+	if data.CreatedDateAfter != nil {
+		clauses = append(clauses, dal.Where("pr.creation_date > ?", *data.CreatedDateAfter))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(AzurePullRequestInput{}))
+}
+
+func GetBuildsIterator(taskCtx plugin.SubTaskContext) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+	clauses := []dal.Clause{
+		dal.Select("b.azure_id"),
+		dal.From("_tool_azure_builds b"),
+		dal.Where(
+			`b.repository_id = ? and b.connection_id = ?`,
+			data.Options.RepositoryId, data.Options.ConnectionId,
+		),
+	}
+	if data.CreatedDateAfter != nil {
+		clauses = append(clauses, dal.Where("b.queue_time > ?", *data.CreatedDateAfter))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(AzureBuildInput{}))
+}
+
+// GetWorkItemsIterator iterates the work items of the project, only the ones changed after changedDateAfter are
+// returned if it is given
+func GetWorkItemsIterator(taskCtx plugin.SubTaskContext, projectId string, changedDateAfter *time.Time) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+	clauses := []dal.Clause{
+		dal.Select("wi.azure_id"),
+		dal.From("_tool_azure_work_items wi"),
+		dal.Where(
+			`wi.project_id = ? and wi.connection_id = ?`,
+			projectId, data.Options.ConnectionId,
+		),
+	}
+	if data.CreatedDateAfter != nil {
+		clauses = append(clauses, dal.Where("wi.created_date > ?", *data.CreatedDateAfter))
+	}
+	if changedDateAfter != nil {
+		clauses = append(clauses, dal.Where("wi.changed_date > ?", *changedDateAfter))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(AzureWorkItemInput{}))
+}
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_BUILDS_TABLE = "azure_api_builds"
+
+var CollectApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBuilds",
+	EntryPoint:       CollectApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Collect pipeline runs of the repository from Azure api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILDS_TABLE)
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		UrlTemplate:        "{{ .Params.Project }}/_apis/build/builds",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQueryForContinuationToken(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("api-version", "7.1-preview.7")
+			query.Set("repositoryId", data.Options.RepositoryId)
+			query.Set("repositoryType", "TfsGit")
+			query.Set("queryOrder", "queueTimeDescending")
+			if data.CreatedDateAfter != nil {
+				query.Set("minTime", formatApiTime(data.CreatedDateAfter))
+			}
+			return query, nil
+		},
+		GetNextPageCustomData: GetNextPageCustomData,
+		ResponseParser:        GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertBuildsMeta = plugin.SubTaskMeta{
+	Name:             "convertBuilds",
+	EntryPoint:       ConvertBuilds,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_builds into domain layer table cicd_pipelines and cicd_pipeline_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILDS_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzureBuild{}),
+		dal.Where("connection_id = ? and repository_id = ?", data.Options.ConnectionId, data.Options.RepositoryId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	buildIdGen := didgen.NewDomainIdGenerator(&models.AzureBuild{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzureRepo{})
+	var repoUrl string
+	if data.Repo != nil {
+		repoUrl = data.Repo.WebUrl
+	}
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzureBuild{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			build := inputRow.(*models.AzureBuild)
+			createdDate := time.Now()
+			if build.QueueTime != nil {
+				createdDate = *build.QueueTime
+			}
+			repoId := repoIdGen.Generate(build.ConnectionId, build.RepositoryId)
+			pipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: buildIdGen.Generate(build.ConnectionId, build.AzureId),
+				},
+				Name:         build.DefinitionName,
+				Result:       devops.GetResult(buildResultRule, build.Result),
+				Status:       devops.GetStatus(buildStatusRule, build.Status),
+				CreatedDate:  createdDate,
+				FinishedDate: build.FinishTime,
+				CicdScopeId:  repoId,
+			}
+			if pipeline.Status != devops.DONE {
+				pipeline.FinishedDate = nil
+			} else if build.StartTime != nil && build.FinishTime != nil {
+				pipeline.DurationSec = uint64(build.FinishTime.Sub(*build.StartTime).Seconds())
+			}
+			results := []interface{}{pipeline}
+			if build.SourceVersion != "" {
+				results = append(results, &devops.CiCDPipelineCommit{
+					PipelineId: pipeline.Id,
+					CommitSha:  build.SourceVersion,
+					Branch:     trimRefName(build.SourceBranch),
+					RepoId:     repoId,
+					Repo:       repoUrl,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
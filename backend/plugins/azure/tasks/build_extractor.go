@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+type AzureApiBuild struct {
+	Id          int    `json:"id"`
+	BuildNumber string `json:"buildNumber"`
+	Status      string `json:"status"`
+	Result      string `json:"result"`
+	Reason      string `json:"reason"`
+	Url         string `json:"url"`
+	Definition  struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"definition"`
+	Project struct {
+		Id string `json:"id"`
+	} `json:"project"`
+	Repository struct {
+		Id string `json:"id"`
+	} `json:"repository"`
+	SourceBranch  string           `json:"sourceBranch"`
+	SourceVersion string           `json:"sourceVersion"`
+	QueueTime     *api.Iso8601Time `json:"queueTime"`
+	StartTime     *api.Iso8601Time `json:"startTime"`
+	FinishTime    *api.Iso8601Time `json:"finishTime"`
+}
+
+var ExtractApiBuildsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBuilds",
+	EntryPoint:       ExtractApiBuilds,
+	EnabledByDefault: true,
+	Description:      "Extract raw pipeline runs data into tool layer table azure_builds",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BUILDS_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			build := &AzureApiBuild{}
+			err := errors.Convert(json.Unmarshal(row.Data, build))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.AzureBuild{
+					ConnectionId:   data.Options.ConnectionId,
+					AzureId:        build.Id,
+					ProjectId:      build.Project.Id,
+					RepositoryId:   build.Repository.Id,
+					DefinitionId:   build.Definition.Id,
+					DefinitionName: build.Definition.Name,
+					BuildNumber:    build.BuildNumber,
+					Status:         build.Status,
+					Result:         build.Result,
+					Reason:         build.Reason,
+					SourceBranch:   build.SourceBranch,
+					SourceVersion:  build.SourceVersion,
+					Url:            build.Url,
+					QueueTime:      api.Iso8601TimeToTime(build.QueueTime),
+					StartTime:      api.Iso8601TimeToTime(build.StartTime),
+					FinishTime:     api.Iso8601TimeToTime(build.FinishTime),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_COMMITS_TABLE = "azure_api_commits"
+
+var CollectApiCommitsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiCommits",
+	EntryPoint:       CollectApiCommits,
+	EnabledByDefault: false,
+	Description:      "Collect commits data from Azure api, gitextractor is preferred for the commits of a repository",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func CollectApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMITS_TABLE)
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		UrlTemplate:        "{{ .Params.Project }}/_apis/git/repositories/{{ .Params.RepositoryId }}/commits",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			// the commits api takes the paging parameters as search criteria
+			query := url.Values{}
+			query.Set("api-version", "7.1-preview.1")
+			query.Set("searchCriteria.$top", fmt.Sprintf("%v", reqData.Pager.Size))
+			query.Set("searchCriteria.$skip", fmt.Sprintf("%v", reqData.Pager.Skip))
+			if data.CreatedDateAfter != nil {
+				query.Set("searchCriteria.fromDate", formatApiTime(data.CreatedDateAfter))
+			}
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertCommitsMeta = plugin.SubTaskMeta{
+	Name:             "convertApiCommits",
+	EntryPoint:       ConvertApiCommits,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table azure_commits into domain layer table commits and repo_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMITS_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("c.*"),
+		dal.From("_tool_azure_commits c"),
+		dal.Join(`left join _tool_azure_repo_commits rc on (
+			rc.commit_sha = c.sha
+		)`),
+		dal.Where("rc.connection_id = ? and rc.repository_id = ?", data.Options.ConnectionId, data.Options.RepositoryId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoId := didgen.NewDomainIdGenerator(&models.AzureRepo{}).Generate(data.Options.ConnectionId, data.Options.RepositoryId)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzureCommit{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			azureCommit := inputRow.(*models.AzureCommit)
+			commit := &code.Commit{
+				Sha:            azureCommit.Sha,
+				Message:        azureCommit.Message,
+				AuthorName:     azureCommit.AuthorName,
+				AuthorEmail:    azureCommit.AuthorEmail,
+				AuthoredDate:   azureCommit.AuthoredDate,
+				AuthorId:       azureCommit.AuthorEmail,
+				CommitterName:  azureCommit.CommitterName,
+				CommitterEmail: azureCommit.CommitterEmail,
+				CommittedDate:  azureCommit.CommittedDate,
+				CommitterId:    azureCommit.CommitterEmail,
+			}
+			repoCommit := &code.RepoCommit{
+				RepoId:    repoId,
+				CommitSha: azureCommit.Sha,
+			}
+			return []interface{}{commit, repoCommit}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
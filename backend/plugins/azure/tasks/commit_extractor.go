@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ExtractApiCommitsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiCommits",
+	EntryPoint:       ExtractApiCommits,
+	EnabledByDefault: false,
+	Description:      "Extract raw commits data into tool layer table azure_commits and azure_repo_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ExtractApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMITS_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			commit := &AzureApiCommit{}
+			err := errors.Convert(json.Unmarshal(row.Data, commit))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.AzureCommit{
+					Sha:            commit.CommitId,
+					Message:        commit.Comment,
+					AuthorName:     commit.Author.Name,
+					AuthorEmail:    commit.Author.Email,
+					AuthoredDate:   commit.Author.Date.ToTime(),
+					CommitterName:  commit.Committer.Name,
+					CommitterEmail: commit.Committer.Email,
+					CommittedDate:  commit.Committer.Date.ToTime(),
+					Url:            commit.Url,
+				},
+				&models.AzureRepoCommit{
+					ConnectionId: data.Options.ConnectionId,
+					RepositoryId: data.Options.RepositoryId,
+					CommitSha:    commit.CommitId,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_ITERATIONS_TABLE = "azure_api_iterations"
+
+var CollectApiIterationsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiIterations",
+	EntryPoint:       CollectApiIterations,
+	EnabledByDefault: true,
+	Description:      "Collect iterations data from Azure api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_ITERATIONS_TABLE)
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		UrlTemplate:        "{{ .Params.Project }}/_apis/wit/classificationnodes/Iterations",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("api-version", "7.1-preview.2")
+			query.Set("$depth", "20")
+			return query, nil
+		},
+		// the whole tree of the iterations is returned as the root node
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var root json.RawMessage
+			err := api.UnmarshalResponse(res, &root)
+			return []json.RawMessage{root}, err
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
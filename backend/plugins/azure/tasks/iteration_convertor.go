@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertIterationsMeta = plugin.SubTaskMeta{
+	Name:             "convertIterations",
+	EntryPoint:       ConvertIterations,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_iterations into domain layer table sprints and board_sprints",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_ITERATIONS_TABLE)
+	db := taskCtx.GetDal()
+	project, err := getProject(taskCtx)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzureIteration{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, project.AzureId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	boardId := didgen.NewDomainIdGenerator(&models.AzureProject{}).Generate(project.ConnectionId, project.AzureId)
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.AzureIteration{})
+	now := time.Now()
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzureIteration{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			iteration := inputRow.(*models.AzureIteration)
+			sprint := &ticket.Sprint{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: iterationIdGen.Generate(iteration.ConnectionId, iteration.AzureId),
+				},
+				Name:            iteration.Name,
+				Url:             iteration.Url,
+				Status:          getSprintStatus(iteration.StartDate, iteration.FinishDate, now),
+				StartedDate:     iteration.StartDate,
+				EndedDate:       iteration.FinishDate,
+				OriginalBoardID: boardId,
+			}
+			if sprint.Status == "CLOSED" {
+				sprint.CompletedDate = iteration.FinishDate
+			}
+			boardSprint := &ticket.BoardSprint{
+				BoardId:  boardId,
+				SprintId: sprint.Id,
+			}
+			return []interface{}{sprint, boardSprint}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
+
+// loadIterationIds returns the ids of the iterations of the project, the root iteration is excluded so work items
+// planned in no iteration belong to no sprint
+func loadIterationIds(taskCtx plugin.SubTaskContext, projectId string) (map[int]bool, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*AzureTaskData)
+	var iterations []models.AzureIteration
+	err := db.All(
+		&iterations,
+		dal.Select("azure_id"),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, projectId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	iterationIds := make(map[int]bool, len(iterations))
+	for _, iteration := range iterations {
+		iterationIds[iteration.AzureId] = true
+	}
+	return iterationIds, nil
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+type AzureApiClassificationNode struct {
+	Id         int    `json:"id"`
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Url        string `json:"url"`
+	Attributes *struct {
+		StartDate  *api.Iso8601Time `json:"startDate"`
+		FinishDate *api.Iso8601Time `json:"finishDate"`
+	} `json:"attributes"`
+	Children []AzureApiClassificationNode `json:"children"`
+}
+
+var ExtractApiIterationsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiIterations",
+	EntryPoint:       ExtractApiIterations,
+	EnabledByDefault: true,
+	Description:      "Extract raw iterations data into tool layer table azure_iterations",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ExtractApiIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_ITERATIONS_TABLE)
+	project, err := getProject(taskCtx)
+	if err != nil {
+		return err
+	}
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			root := &AzureApiClassificationNode{}
+			err := errors.Convert(json.Unmarshal(row.Data, root))
+			if err != nil {
+				return nil, err
+			}
+			var results []interface{}
+			for _, node := range flattenIterations(root) {
+				iteration := &models.AzureIteration{
+					ConnectionId: data.Options.ConnectionId,
+					AzureId:      node.Id,
+					Identifier:   node.Identifier,
+					ProjectId:    project.AzureId,
+					Name:         node.Name,
+					Path:         toIterationPath(node.Path),
+					Url:          node.Url,
+				}
+				if node.Attributes != nil {
+					iteration.StartDate = api.Iso8601TimeToTime(node.Attributes.StartDate)
+					iteration.FinishDate = api.Iso8601TimeToTime(node.Attributes.FinishDate)
+				}
+				results = append(results, iteration)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// flattenIterations returns all the descendants of the root, the root itself is the project rather than an iteration
+func flattenIterations(root *AzureApiClassificationNode) []*AzureApiClassificationNode {
+	var nodes []*AzureApiClassificationNode
+	for i := range root.Children {
+		child := &root.Children[i]
+		nodes = append(nodes, child)
+		nodes = append(nodes, flattenIterations(child)...)
+	}
+	return nodes
+}
+
+// toIterationPath converts the path of a classification node like \Project\Iteration\Sprint 1 into the form of
+// System.IterationPath of the work items like Project\Sprint 1
+func toIterationPath(nodePath string) string {
+	parts := strings.Split(strings.TrimPrefix(nodePath, `\`), `\`)
+	if len(parts) > 1 && parts[1] == "Iteration" {
+		parts = append(parts[:1], parts[2:]...)
+	}
+	return strings.Join(parts, `\`)
+}
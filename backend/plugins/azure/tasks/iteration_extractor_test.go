@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenIterations(t *testing.T) {
+	root := &AzureApiClassificationNode{
+		Id:   1,
+		Path: `\project\Iteration`,
+		Children: []AzureApiClassificationNode{
+			{
+				Id:   2,
+				Path: `\project\Iteration\Release 1`,
+				Children: []AzureApiClassificationNode{
+					{Id: 3, Path: `\project\Iteration\Release 1\Sprint 1`},
+				},
+			},
+			{Id: 4, Path: `\project\Iteration\Sprint 2`},
+		},
+	}
+	var ids []int
+	for _, node := range flattenIterations(root) {
+		ids = append(ids, node.Id)
+	}
+	assert.Equal(t, []int{2, 3, 4}, ids)
+}
+
+func TestToIterationPath(t *testing.T) {
+	assert.Equal(t, `project\Release 1\Sprint 1`, toIterationPath(`\project\Iteration\Release 1\Sprint 1`))
+	assert.Equal(t, `project`, toIterationPath(`\project\Iteration`))
+}
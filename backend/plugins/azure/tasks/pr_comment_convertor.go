@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertPullRequestCommentsMeta = plugin.SubTaskMeta{
+	Name:             "convertPullRequestComments",
+	EntryPoint:       ConvertPullRequestComments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_pull_request_comments into domain layer table pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertPullRequestComments(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_THREADS_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("c.*"),
+		dal.From("_tool_azure_pull_request_comments c"),
+		dal.Join(`left join _tool_azure_pull_requests pr on (
+			pr.connection_id = c.connection_id and pr.azure_id = c.pull_request_id
+		)`),
+		dal.Where("c.connection_id = ? and pr.repository_id = ?", data.Options.ConnectionId, data.Options.RepositoryId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	commentIdGen := didgen.NewDomainIdGenerator(&models.AzurePullRequestComment{})
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzurePullRequest{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzureAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzurePullRequestComment{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			comment := inputRow.(*models.AzurePullRequestComment)
+			domainComment := &code.PullRequestComment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: commentIdGen.Generate(comment.ConnectionId, comment.PullRequestId, comment.ThreadId, comment.CommentId),
+				},
+				PullRequestId: prIdGen.Generate(comment.ConnectionId, comment.PullRequestId),
+				Body:          comment.Content,
+				AccountId:     accountIdGen.Generate(comment.ConnectionId, comment.AuthorId),
+				CreatedDate:   comment.PublishedDate,
+				Position:      comment.Line,
+				Type:          code.NORMAL_COMMENT,
+			}
+			if comment.ThreadType == voteUpdateThreadType {
+				domainComment.Type = code.REVIEW
+				domainComment.Status = convertVote(comment.Vote)
+			} else if comment.FilePath != "" {
+				domainComment.Type = code.DIFF_COMMENT
+			}
+			return []interface{}{domainComment}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
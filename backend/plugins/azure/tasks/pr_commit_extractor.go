@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+type AzureApiGitUserDate struct {
+	Name  string          `json:"name"`
+	Email string          `json:"email"`
+	Date  api.Iso8601Time `json:"date"`
+}
+
+type AzureApiCommit struct {
+	CommitId  string              `json:"commitId"`
+	Comment   string              `json:"comment"`
+	Author    AzureApiGitUserDate `json:"author"`
+	Committer AzureApiGitUserDate `json:"committer"`
+	Url       string              `json:"url"`
+}
+
+var ExtractApiPullRequestCommitsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequestCommits",
+	EntryPoint:       ExtractApiPullRequestCommits,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull request commits data into tool layer table azure_pull_request_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ExtractApiPullRequestCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_COMMITS_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			commit := &AzureApiCommit{}
+			err := errors.Convert(json.Unmarshal(row.Data, commit))
+			if err != nil {
+				return nil, err
+			}
+			input := &AzurePullRequestInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.AzurePullRequestCommit{
+					ConnectionId:  data.Options.ConnectionId,
+					PullRequestId: input.AzureId,
+					CommitSha:     commit.CommitId,
+					AuthorName:    commit.Author.Name,
+					AuthorEmail:   commit.Author.Email,
+					AuthoredDate:  commit.Author.Date.ToTime(),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
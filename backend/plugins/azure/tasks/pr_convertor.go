@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "convertPullRequests",
+	EntryPoint:       ConvertPullRequests,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_pull_requests into domain layer table pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUESTS_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzurePullRequest{}),
+		dal.Where("connection_id = ? and repository_id = ?", data.Options.ConnectionId, data.Options.RepositoryId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzurePullRequest{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzureRepo{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzureAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzurePullRequest{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pr := inputRow.(*models.AzurePullRequest)
+			domainPr := &code.PullRequest{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: prIdGen.Generate(pr.ConnectionId, pr.AzureId),
+				},
+				BaseRepoId:     repoIdGen.Generate(pr.ConnectionId, pr.RepositoryId),
+				HeadRepoId:     repoIdGen.Generate(pr.ConnectionId, pr.RepositoryId),
+				Status:         convertPullRequestStatus(pr.Status),
+				Title:          pr.Title,
+				Description:    pr.Description,
+				Url:            pr.Url,
+				AuthorName:     pr.CreatedByName,
+				AuthorId:       accountIdGen.Generate(pr.ConnectionId, pr.CreatedById),
+				PullRequestKey: pr.AzureId,
+				CreatedDate:    pr.CreationDate,
+				ClosedDate:     pr.ClosedDate,
+				MergeCommitSha: pr.LastMergeCommitSha,
+				HeadRef:        trimRefName(pr.SourceRefName),
+				BaseRef:        trimRefName(pr.TargetRefName),
+				HeadCommitSha:  pr.LastMergeSourceCommitSha,
+				BaseCommitSha:  pr.LastMergeTargetCommitSha,
+			}
+			if pr.ForkRepositoryId != "" {
+				domainPr.HeadRepoId = repoIdGen.Generate(pr.ConnectionId, pr.ForkRepositoryId)
+			}
+			// completed pull requests are the merged ones, abandoned ones are closed without merging
+			if pr.Status == "completed" {
+				domainPr.MergedDate = pr.ClosedDate
+			}
+			return []interface{}{domainPr}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+type AzureApiIdentity struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	ImageUrl    string `json:"imageUrl"`
+}
+
+type AzureApiCommitRef struct {
+	CommitId string `json:"commitId"`
+}
+
+type AzureApiPullRequest struct {
+	PullRequestId int    `json:"pullRequestId"`
+	Status        string `json:"status"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	Url           string `json:"url"`
+	Repository    struct {
+		Id string `json:"id"`
+	} `json:"repository"`
+	ForkSource *struct {
+		Repository struct {
+			Id string `json:"id"`
+		} `json:"repository"`
+	} `json:"forkSource"`
+	CreatedBy             AzureApiIdentity  `json:"createdBy"`
+	CreationDate          api.Iso8601Time   `json:"creationDate"`
+	ClosedDate            *api.Iso8601Time  `json:"closedDate"`
+	SourceRefName         string            `json:"sourceRefName"`
+	TargetRefName         string            `json:"targetRefName"`
+	MergeStatus           string            `json:"mergeStatus"`
+	IsDraft               bool              `json:"isDraft"`
+	LastMergeSourceCommit AzureApiCommitRef `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit AzureApiCommitRef `json:"lastMergeTargetCommit"`
+	LastMergeCommit       AzureApiCommitRef `json:"lastMergeCommit"`
+	Reviewers             []struct {
+		AzureApiIdentity
+		Vote       int  `json:"vote"`
+		IsRequired bool `json:"isRequired"`
+	} `json:"reviewers"`
+}
+
+var ExtractApiPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequests",
+	EntryPoint:       ExtractApiPullRequests,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull requests data into tool layer table azure_pull_requests and azure_pull_request_reviewers",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ExtractApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUESTS_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			body := &AzureApiPullRequest{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			pr := &models.AzurePullRequest{
+				ConnectionId:             connectionId,
+				AzureId:                  body.PullRequestId,
+				RepositoryId:             body.Repository.Id,
+				Status:                   body.Status,
+				MergeStatus:              body.MergeStatus,
+				IsDraft:                  body.IsDraft,
+				Title:                    body.Title,
+				Description:              body.Description,
+				Url:                      body.Url,
+				CreatedById:              body.CreatedBy.Id,
+				CreatedByName:            body.CreatedBy.DisplayName,
+				CreationDate:             body.CreationDate.ToTime(),
+				ClosedDate:               api.Iso8601TimeToTime(body.ClosedDate),
+				SourceRefName:            body.SourceRefName,
+				TargetRefName:            body.TargetRefName,
+				LastMergeSourceCommitSha: body.LastMergeSourceCommit.CommitId,
+				LastMergeTargetCommitSha: body.LastMergeTargetCommit.CommitId,
+				LastMergeCommitSha:       body.LastMergeCommit.CommitId,
+			}
+			if body.ForkSource != nil {
+				pr.ForkRepositoryId = body.ForkSource.Repository.Id
+			}
+			results := []interface{}{pr}
+			if account := extractAccount(connectionId, &body.CreatedBy); account != nil {
+				results = append(results, account)
+			}
+			for i := range body.Reviewers {
+				reviewer := &body.Reviewers[i]
+				results = append(results, &models.AzurePullRequestReviewer{
+					ConnectionId:  connectionId,
+					PullRequestId: body.PullRequestId,
+					ReviewerId:    reviewer.Id,
+					DisplayName:   reviewer.DisplayName,
+					UniqueName:    reviewer.UniqueName,
+					Vote:          reviewer.Vote,
+					IsRequired:    reviewer.IsRequired,
+				})
+				if account := extractAccount(connectionId, &reviewer.AzureApiIdentity); account != nil {
+					results = append(results, account)
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// extractAccount returns nil for identities without id, like the ones of deleted users
+func extractAccount(connectionId uint64, identity *AzureApiIdentity) *models.AzureAccount {
+	if identity == nil || identity.Id == "" {
+		return nil
+	}
+	return &models.AzureAccount{
+		ConnectionId: connectionId,
+		AzureId:      identity.Id,
+		DisplayName:  identity.DisplayName,
+		UniqueName:   identity.UniqueName,
+		ImageUrl:     identity.ImageUrl,
+	}
+}
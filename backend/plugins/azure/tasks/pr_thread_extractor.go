@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+const voteUpdateThreadType = "VoteUpdate"
+
+type AzureApiThreadProperty struct {
+	Value string `json:"$value"`
+}
+
+type AzureApiPullRequestThread struct {
+	Id         int    `json:"id"`
+	Status     string `json:"status"`
+	IsDeleted  bool   `json:"isDeleted"`
+	Properties struct {
+		CodeReviewThreadType *AzureApiThreadProperty `json:"CodeReviewThreadType"`
+		CodeReviewVoteResult *AzureApiThreadProperty `json:"CodeReviewVoteResult"`
+	} `json:"properties"`
+	ThreadContext *struct {
+		FilePath       string `json:"filePath"`
+		RightFileStart *struct {
+			Line int `json:"line"`
+		} `json:"rightFileStart"`
+	} `json:"threadContext"`
+	Comments []struct {
+		Id              int              `json:"id"`
+		ParentCommentId int              `json:"parentCommentId"`
+		Author          AzureApiIdentity `json:"author"`
+		Content         string           `json:"content"`
+		CommentType     string           `json:"commentType"`
+		IsDeleted       bool             `json:"isDeleted"`
+		PublishedDate   api.Iso8601Time  `json:"publishedDate"`
+		LastUpdatedDate *api.Iso8601Time `json:"lastUpdatedDate"`
+	} `json:"comments"`
+}
+
+var ExtractApiPullRequestThreadsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequestThreads",
+	EntryPoint:       ExtractApiPullRequestThreads,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull request threads data into tool layer table azure_pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ExtractApiPullRequestThreads(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_THREADS_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			thread := &AzureApiPullRequestThread{}
+			err := errors.Convert(json.Unmarshal(row.Data, thread))
+			if err != nil {
+				return nil, err
+			}
+			input := &AzurePullRequestInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			if thread.IsDeleted {
+				return nil, nil
+			}
+			var filePath string
+			var line int
+			if thread.ThreadContext != nil {
+				filePath = thread.ThreadContext.FilePath
+				if thread.ThreadContext.RightFileStart != nil {
+					line = thread.ThreadContext.RightFileStart.Line
+				}
+			}
+			var threadType string
+			var vote int
+			if thread.Properties.CodeReviewThreadType != nil {
+				threadType = thread.Properties.CodeReviewThreadType.Value
+			}
+			if threadType == voteUpdateThreadType && thread.Properties.CodeReviewVoteResult != nil {
+				vote, err = errors.Convert01(strconv.Atoi(thread.Properties.CodeReviewVoteResult.Value))
+				if err != nil {
+					return nil, err
+				}
+			}
+			results := make([]interface{}, 0, len(thread.Comments))
+			for i := range thread.Comments {
+				comment := &thread.Comments[i]
+				// system comments record pushes, merges and votes, only the votes are reviews of people
+				if comment.IsDeleted || (comment.CommentType == "system" && threadType != voteUpdateThreadType) {
+					continue
+				}
+				results = append(results, &models.AzurePullRequestComment{
+					ConnectionId:    connectionId,
+					PullRequestId:   input.AzureId,
+					ThreadId:        thread.Id,
+					CommentId:       comment.Id,
+					ParentCommentId: comment.ParentCommentId,
+					AuthorId:        comment.Author.Id,
+					AuthorName:      comment.Author.DisplayName,
+					Content:         comment.Content,
+					CommentType:     comment.CommentType,
+					ThreadStatus:    thread.Status,
+					ThreadType:      threadType,
+					Vote:            vote,
+					FilePath:        filePath,
+					Line:            line,
+					PublishedDate:   comment.PublishedDate.ToTime(),
+					LastUpdatedDate: api.Iso8601TimeToTime(comment.LastUpdatedDate),
+				})
+				if account := extractAccount(connectionId, &comment.Author); account != nil {
+					results = append(results, account)
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
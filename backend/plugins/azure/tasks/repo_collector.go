@@ -38,20 +38,31 @@ var CollectApiRepoMeta = plugin.SubTaskMeta{
 }
 
 func CollectApiRepositories(taskCtx plugin.SubTaskContext) errors.Error {
-	data := taskCtx.GetData().(*AzureTaskData)
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REPOSITORIES_TABLE)
+
+	urlTemplate := "{{ .Params.Project }}/_apis/git/repositories?api-version=7.1-preview.1"
+	responseParser := func(res *http.Response) ([]json.RawMessage, errors.Error) {
+		var data struct {
+			Repos []json.RawMessage `json:"value"`
+		}
+		err := api.UnmarshalResponse(res, &data)
+		return data.Repos, err
+	}
+	// only the repo in scope is collected, so the repos of the same project scoped with other settings are left as they are
+	if data.Options.RepositoryId != "" {
+		urlTemplate = "{{ .Params.Project }}/_apis/git/repositories/{{ .Params.RepositoryId }}?api-version=7.1-preview.1"
+		responseParser = func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var repo json.RawMessage
+			err := api.UnmarshalResponse(res, &repo)
+			return []json.RawMessage{repo}, err
+		}
+	}
 
 	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			Params: AzureApiParams{
-				ConnectionId: data.Options.ConnectionId,
-				Project:      data.Options.Project,
-			},
-			Table: RAW_REPOSITORIES_TABLE,
-		},
-		ApiClient: data.ApiClient,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
 
-		UrlTemplate: "{{ .Params.Project }}/_apis/git/repositories?api-version=7.1-preview.1",
+		UrlTemplate: urlTemplate,
 		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
 			query := url.Values{}
 			query.Set("state", "all")
@@ -61,13 +72,7 @@ func CollectApiRepositories(taskCtx plugin.SubTaskContext) errors.Error {
 
 			return query, nil
 		},
-		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
-			var data struct {
-				Repos []json.RawMessage `json:"value"`
-			}
-			err := api.UnmarshalResponse(res, &data)
-			return data.Repos, err
-		},
+		ResponseParser: responseParser,
 	})
 
 	if err != nil {
@@ -59,23 +59,9 @@ var ExtractApiRepoMeta = plugin.SubTaskMeta{
 type ApiRepoResponse AzureApiRepo
 
 func ExtractApiRepositories(taskCtx plugin.SubTaskContext) errors.Error {
-	data := taskCtx.GetData().(*AzureTaskData)
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_REPOSITORIES_TABLE)
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
-		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
-			Ctx: taskCtx,
-			/*
-				This struct will be JSONEncoded and stored into database along with raw data itself, to identity minimal
-				set of data to be process, for example, we process JiraIssues by Board
-			*/
-			Params: AzureApiParams{
-				ConnectionId: data.Options.ConnectionId,
-				Project:      data.Options.Project,
-			},
-			/*
-				Table store raw data
-			*/
-			Table: RAW_REPOSITORIES_TABLE,
-		},
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
 		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
 			body := &ApiRepoResponse{}
 			err := errors.Convert(json.Unmarshal(row.Data, body))
@@ -99,6 +85,9 @@ func ExtractApiRepositories(taskCtx plugin.SubTaskContext) errors.Error {
 				WebUrl:        body.WebURL,
 				IsDisabled:    body.IsDisabled,
 			}
+			if body.ID == data.Options.RepositoryId {
+				azureRepository.TransformationRuleId = data.Options.TransformationRuleId
+			}
 			data.Repo = azureRepository
 
 			results = append(results, azureRepository)
@@ -0,0 +1,169 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+// defaultStageName is the implicit stage azure adds to yaml pipelines which define no stages
+const defaultStageName = "__default"
+
+var buildResultRule = &devops.ResultRule{
+	Success: []string{"succeeded", "partiallySucceeded"},
+	Failed:  []string{"failed"},
+	Abort:   []string{"canceled"},
+	Default: "",
+}
+
+var buildStatusRule = &devops.StatusRule{
+	InProgress: []string{"notStarted", "inProgress", "cancelling", "postponed"},
+	Default:    devops.DONE,
+}
+
+var timelineResultRule = &devops.ResultRule{
+	Success: []string{"succeeded", "succeededWithIssues"},
+	Failed:  []string{"failed"},
+	Abort:   []string{"canceled", "skipped", "abandoned"},
+	Default: "",
+}
+
+var timelineStatusRule = &devops.StatusRule{
+	InProgress: []string{"pending", "inProgress"},
+	Default:    devops.DONE,
+}
+
+// convertPullRequestStatus maps active, completed and abandoned to the statuses used by other code review tools
+func convertPullRequestStatus(status string) string {
+	switch status {
+	case "active":
+		return "open"
+	case "completed":
+		return "merged"
+	case "abandoned":
+		return "closed"
+	}
+	return status
+}
+
+// convertVote maps the vote of a reviewer to the status of a review comment
+func convertVote(vote int) string {
+	if vote > 0 {
+		return "APPROVED"
+	}
+	if vote < 0 {
+		return "CHANGES_REQUESTED"
+	}
+	return ""
+}
+
+func trimRefName(ref string) string {
+	return strings.TrimPrefix(ref, "refs/heads/")
+}
+
+// selectTaskRecords picks the records to be converted into cicd tasks, stages are preferred and jobs are used
+// for classic pipelines and yaml pipelines without stages
+func selectTaskRecords(records []models.AzureTimelineRecord) []models.AzureTimelineRecord {
+	var stages, jobs []models.AzureTimelineRecord
+	for _, record := range records {
+		switch record.Type {
+		case models.TimelineRecordTypeStage:
+			if record.Name != defaultStageName {
+				stages = append(stages, record)
+			}
+		case models.TimelineRecordTypeJob:
+			jobs = append(jobs, record)
+		}
+	}
+	if len(stages) > 0 {
+		return stages
+	}
+	return jobs
+}
+
+// defaultTypeMappings covers the work item types of the agile, scrum, basic and cmmi process templates
+var defaultTypeMappings = map[string]string{
+	"Bug":                  ticket.BUG,
+	"Epic":                 ticket.REQUIREMENT,
+	"Feature":              ticket.REQUIREMENT,
+	"User Story":           ticket.REQUIREMENT,
+	"Product Backlog Item": ticket.REQUIREMENT,
+	"Requirement":          ticket.REQUIREMENT,
+	"Issue":                ticket.REQUIREMENT,
+	"Task":                 ticket.TASK,
+}
+
+// defaultStatusMappings covers the work item states of the agile, scrum, basic and cmmi process templates
+var defaultStatusMappings = map[string]string{
+	"New":         ticket.TODO,
+	"To Do":       ticket.TODO,
+	"Proposed":    ticket.TODO,
+	"Approved":    ticket.TODO,
+	"Design":      ticket.TODO,
+	"Active":      ticket.IN_PROGRESS,
+	"Committed":   ticket.IN_PROGRESS,
+	"In Progress": ticket.IN_PROGRESS,
+	"Doing":       ticket.IN_PROGRESS,
+	"Open":        ticket.IN_PROGRESS,
+	"Resolved":    ticket.DONE,
+	"Closed":      ticket.DONE,
+	"Done":        ticket.DONE,
+	"Removed":     ticket.DONE,
+	"Completed":   ticket.DONE,
+	"Inactive":    ticket.DONE,
+}
+
+// getStdType maps the type of a work item to the standard issue type, the mappings of the transformation rule
+// take precedence over the defaults
+func getStdType(typeMappings map[string]interface{}, workItemType string) string {
+	if stdType, ok := typeMappings[workItemType].(string); ok && stdType != "" {
+		return stdType
+	}
+	return defaultTypeMappings[workItemType]
+}
+
+// getStdStatus maps the state of a work item to the standard issue status, the mappings of the transformation rule
+// take precedence over the defaults
+func getStdStatus(statusMappings map[string]interface{}, state string) string {
+	if state == "" {
+		return ""
+	}
+	if stdStatus, ok := statusMappings[state].(string); ok && stdStatus != "" {
+		return stdStatus
+	}
+	if stdStatus, ok := defaultStatusMappings[state]; ok {
+		return stdStatus
+	}
+	return ticket.OTHER
+}
+
+// getSprintStatus tells the status of an iteration at the given time by its dates
+func getSprintStatus(startDate, finishDate *time.Time, now time.Time) string {
+	if finishDate != nil && finishDate.Before(now) {
+		return "CLOSED"
+	}
+	if startDate != nil && !startDate.After(now) {
+		return "ACTIVE"
+	}
+	return "FUTURE"
+}
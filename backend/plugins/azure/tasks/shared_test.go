@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertPullRequestStatus(t *testing.T) {
+	assert.Equal(t, "open", convertPullRequestStatus("active"))
+	assert.Equal(t, "merged", convertPullRequestStatus("completed"))
+	assert.Equal(t, "closed", convertPullRequestStatus("abandoned"))
+	assert.Equal(t, "notSet", convertPullRequestStatus("notSet"))
+}
+
+func TestConvertVote(t *testing.T) {
+	assert.Equal(t, "APPROVED", convertVote(10))
+	assert.Equal(t, "APPROVED", convertVote(5))
+	assert.Equal(t, "", convertVote(0))
+	assert.Equal(t, "CHANGES_REQUESTED", convertVote(-5))
+	assert.Equal(t, "CHANGES_REQUESTED", convertVote(-10))
+}
+
+func TestBuildRules(t *testing.T) {
+	assert.Equal(t, devops.SUCCESS, devops.GetResult(buildResultRule, "partiallySucceeded"))
+	assert.Equal(t, devops.FAILURE, devops.GetResult(buildResultRule, "failed"))
+	assert.Equal(t, devops.ABORT, devops.GetResult(buildResultRule, "canceled"))
+	assert.Equal(t, devops.IN_PROGRESS, devops.GetStatus(buildStatusRule, "notStarted"))
+	assert.Equal(t, devops.DONE, devops.GetStatus(buildStatusRule, "completed"))
+	assert.Equal(t, devops.ABORT, devops.GetResult(timelineResultRule, "skipped"))
+	assert.Equal(t, devops.IN_PROGRESS, devops.GetStatus(timelineStatusRule, "pending"))
+}
+
+func TestSelectTaskRecords(t *testing.T) {
+	stage := models.AzureTimelineRecord{RecordId: "s1", Type: models.TimelineRecordTypeStage, Name: "deploy"}
+	defaultStage := models.AzureTimelineRecord{RecordId: "s0", Type: models.TimelineRecordTypeStage, Name: defaultStageName}
+	job := models.AzureTimelineRecord{RecordId: "j1", Type: models.TimelineRecordTypeJob, Name: "build"}
+
+	assert.Equal(t, []models.AzureTimelineRecord{stage}, selectTaskRecords([]models.AzureTimelineRecord{stage, job}))
+	assert.Equal(t, []models.AzureTimelineRecord{job}, selectTaskRecords([]models.AzureTimelineRecord{defaultStage, job}))
+	assert.Equal(t, []models.AzureTimelineRecord{job}, selectTaskRecords([]models.AzureTimelineRecord{job}))
+	assert.Empty(t, selectTaskRecords(nil))
+}
+
+func TestGetNextPageCustomData(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	_, err := GetNextPageCustomData(nil, res)
+	assert.Equal(t, api.ErrFinishCollect, err)
+
+	res.Header.Set(continuationTokenHeader, "token")
+	token, err := GetNextPageCustomData(nil, res)
+	assert.Nil(t, err)
+	assert.Equal(t, "token", token)
+}
+
+func TestGetStdType(t *testing.T) {
+	typeMappings := map[string]interface{}{"Issue": ticket.INCIDENT, "Impediment": ticket.TASK}
+	assert.Equal(t, ticket.BUG, getStdType(typeMappings, "Bug"))
+	assert.Equal(t, ticket.REQUIREMENT, getStdType(typeMappings, "User Story"))
+	assert.Equal(t, ticket.INCIDENT, getStdType(typeMappings, "Issue"))
+	assert.Equal(t, ticket.TASK, getStdType(typeMappings, "Impediment"))
+	assert.Equal(t, "", getStdType(typeMappings, "Test Case"))
+	assert.Equal(t, ticket.REQUIREMENT, getStdType(nil, "Product Backlog Item"))
+}
+
+func TestGetStdStatus(t *testing.T) {
+	statusMappings := map[string]interface{}{"Resolved": ticket.IN_PROGRESS, "Ready": ticket.TODO}
+	assert.Equal(t, ticket.TODO, getStdStatus(statusMappings, "New"))
+	assert.Equal(t, ticket.TODO, getStdStatus(statusMappings, "Ready"))
+	assert.Equal(t, ticket.IN_PROGRESS, getStdStatus(statusMappings, "Active"))
+	assert.Equal(t, ticket.IN_PROGRESS, getStdStatus(statusMappings, "Resolved"))
+	assert.Equal(t, ticket.DONE, getStdStatus(statusMappings, "Closed"))
+	assert.Equal(t, ticket.OTHER, getStdStatus(statusMappings, "Blocked"))
+	assert.Equal(t, ticket.DONE, getStdStatus(nil, "Resolved"))
+	assert.Equal(t, "", getStdStatus(nil, ""))
+}
+
+func TestGetSprintStatus(t *testing.T) {
+	now := time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC)
+	lastWeek := now.AddDate(0, 0, -7)
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+	nextWeek := now.AddDate(0, 0, 7)
+	assert.Equal(t, "CLOSED", getSprintStatus(&lastWeek, &yesterday, now))
+	assert.Equal(t, "ACTIVE", getSprintStatus(&yesterday, &tomorrow, now))
+	assert.Equal(t, "FUTURE", getSprintStatus(&tomorrow, &nextWeek, now))
+	assert.Equal(t, "FUTURE", getSprintStatus(nil, nil, now))
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package tasks
 
 import (
+	"time"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/azure/models"
@@ -26,20 +28,26 @@ import (
 type AzureApiParams struct {
 	ConnectionId uint64
 	Project      string
+	RepositoryId string `json:",omitempty"`
 }
 
 type AzureOptions struct {
-	ConnectionId uint64 `json:"connectionId"`
-	Project      string
-	Since        string
-	Tasks        []string `json:"tasks,omitempty"`
+	ConnectionId                    uint64 `json:"connectionId"`
+	Project                         string
+	RepositoryId                    string `mapstructure:"repositoryId" json:"repositoryId"`
+	TransformationRuleId            uint64 `mapstructure:"transformationRuleId" json:"transformationRuleId"`
+	CreatedDateAfter                string `mapstructure:"createdDateAfter" json:"createdDateAfter"`
+	Since                           string
+	Tasks                           []string `json:"tasks,omitempty"`
+	*models.AzureTransformationRule `mapstructure:"transformationRules" json:"transformationRules"`
 }
 
 type AzureTaskData struct {
-	Options    *AzureOptions
-	ApiClient  *api.ApiAsyncClient
-	Connection *models.AzureConnection
-	Repo       *models.AzureRepo
+	Options          *AzureOptions
+	ApiClient        *api.ApiAsyncClient
+	Connection       *models.AzureConnection
+	Repo             *models.AzureRepo
+	CreatedDateAfter *time.Time
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*AzureOptions, errors.Error) {
@@ -52,5 +60,8 @@ func DecodeAndValidateTaskOptions(options map[string]interface{}) (*AzureOptions
 	if op.ConnectionId == 0 {
 		return nil, errors.BadInput.New("Azure connectionId is invalid")
 	}
+	if op.Project == "" && op.RepositoryId == "" {
+		return nil, errors.BadInput.New("one of Azure project and repositoryId is required")
+	}
 	return &op, nil
 }
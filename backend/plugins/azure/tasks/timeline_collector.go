@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_TIMELINE_RECORDS_TABLE = "azure_api_timeline_records"
+
+var CollectApiTimelineRecordsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiTimelineRecords",
+	EntryPoint:       CollectApiTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Collect stages and jobs of the pipeline runs from Azure api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CollectApiTimelineRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_RECORDS_TABLE)
+
+	iterator, err := GetBuildsIterator(taskCtx)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "{{ .Params.Project }}/_apis/build/builds/{{ .Input.AzureId }}/timeline",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("api-version", "7.1-preview.2")
+			return query, nil
+		},
+		// builds which never started have no timeline and get a 204
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			if res.StatusCode == http.StatusNoContent {
+				return nil, nil
+			}
+			var data struct {
+				Records []json.RawMessage `json:"records"`
+			}
+			err := api.UnmarshalResponse(res, &data)
+			return data.Records, err
+		},
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
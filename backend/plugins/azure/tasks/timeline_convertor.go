@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertTimelineRecordsMeta = plugin.SubTaskMeta{
+	Name:             "convertTimelineRecords",
+	EntryPoint:       ConvertTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Convert stages or jobs of the pipeline runs into domain layer table cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertTimelineRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_RECORDS_TABLE)
+	db := taskCtx.GetDal()
+	var deploymentPattern, productionPattern string
+	if data.Options.AzureTransformationRule != nil {
+		deploymentPattern = data.Options.DeploymentPattern
+		productionPattern = data.Options.ProductionPattern
+	}
+	regexEnricher := api.NewRegexEnricher()
+	err := regexEnricher.AddRegexp(deploymentPattern, productionPattern)
+	if err != nil {
+		return err
+	}
+
+	// the builds are iterated to decide between stages and jobs with all records of a build at hand
+	cursor, err := db.Cursor(
+		dal.From(&models.AzureBuild{}),
+		dal.Where("connection_id = ? and repository_id = ?", data.Options.ConnectionId, data.Options.RepositoryId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	recordIdGen := didgen.NewDomainIdGenerator(&models.AzureTimelineRecord{})
+	buildIdGen := didgen.NewDomainIdGenerator(&models.AzureBuild{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzureRepo{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzureBuild{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			build := inputRow.(*models.AzureBuild)
+			var records []models.AzureTimelineRecord
+			err := db.All(&records, dal.Where("connection_id = ? and build_id = ?", build.ConnectionId, build.AzureId))
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, len(records))
+			for _, record := range selectTaskRecords(records) {
+				// skipped stages never start, the queue time of the build is the best guess
+				startedDate := record.StartTime
+				if startedDate == nil {
+					startedDate = build.QueueTime
+				}
+				if startedDate == nil {
+					continue
+				}
+				task := &devops.CICDTask{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: recordIdGen.Generate(record.ConnectionId, record.BuildId, record.RecordId),
+					},
+					Name:         record.Name,
+					PipelineId:   buildIdGen.Generate(build.ConnectionId, build.AzureId),
+					Result:       devops.GetResult(timelineResultRule, record.Result),
+					Status:       devops.GetStatus(timelineStatusRule, record.State),
+					StartedDate:  *startedDate,
+					FinishedDate: record.FinishTime,
+					CicdScopeId:  repoIdGen.Generate(build.ConnectionId, build.RepositoryId),
+				}
+				if task.Status != devops.DONE {
+					task.FinishedDate = nil
+				} else if record.StartTime != nil && record.FinishTime != nil {
+					task.DurationSec = uint64(record.FinishTime.Sub(*record.StartTime).Seconds())
+				}
+				task.Type = regexEnricher.GetEnrichResult(deploymentPattern, record.Name, devops.DEPLOYMENT)
+				task.Environment = regexEnricher.GetEnrichResult(productionPattern, record.Name, devops.PRODUCTION)
+				results = append(results, task)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
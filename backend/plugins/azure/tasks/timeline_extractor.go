@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+type AzureApiTimelineRecord struct {
+	Id         string           `json:"id"`
+	ParentId   string           `json:"parentId"`
+	Type       string           `json:"type"`
+	Name       string           `json:"name"`
+	State      string           `json:"state"`
+	Result     string           `json:"result"`
+	StartTime  *api.Iso8601Time `json:"startTime"`
+	FinishTime *api.Iso8601Time `json:"finishTime"`
+}
+
+var ExtractApiTimelineRecordsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTimelineRecords",
+	EntryPoint:       ExtractApiTimelineRecords,
+	EnabledByDefault: true,
+	Description:      "Extract stages and jobs of the pipeline runs into tool layer table azure_timeline_records",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiTimelineRecords(taskCtx plugin.SubTaskContext) errors.Error {
+	if skipWithoutRepository(taskCtx) {
+		return nil
+	}
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TIMELINE_RECORDS_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			record := &AzureApiTimelineRecord{}
+			err := errors.Convert(json.Unmarshal(row.Data, record))
+			if err != nil {
+				return nil, err
+			}
+			if record.Type != models.TimelineRecordTypeStage && record.Type != models.TimelineRecordTypeJob {
+				return nil, nil
+			}
+			input := &AzureBuildInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.AzureTimelineRecord{
+					ConnectionId: data.Options.ConnectionId,
+					RecordId:     record.Id,
+					BuildId:      input.AzureId,
+					ParentId:     record.ParentId,
+					Type:         record.Type,
+					Name:         record.Name,
+					State:        record.State,
+					Result:       record.Result,
+					StartTime:    api.Iso8601TimeToTime(record.StartTime),
+					FinishTime:   api.Iso8601TimeToTime(record.FinishTime),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertWorkItemChangelogsMeta = plugin.SubTaskMeta{
+	Name:             "convertWorkItemChangelogs",
+	EntryPoint:       ConvertWorkItemChangelogs,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_work_item_changes into domain layer table issue_changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertWorkItemChangelogs(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEM_UPDATES_TABLE)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+	project, err := getProject(taskCtx)
+	if err != nil {
+		return err
+	}
+	iterationIds, err := loadIterationIds(taskCtx, project.AzureId)
+	if err != nil {
+		return err
+	}
+	var statusMappings map[string]interface{}
+	if data.Options.AzureTransformationRule != nil {
+		statusMappings = data.Options.StatusMappings
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("c.*"),
+		dal.From("_tool_azure_work_item_changes c"),
+		dal.Join(`LEFT JOIN _tool_azure_work_items wi ON (wi.connection_id = c.connection_id AND wi.azure_id = c.work_item_id)`),
+		dal.Where("c.connection_id = ? AND wi.project_id = ?", connectionId, project.AzureId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	changeIdGen := didgen.NewDomainIdGenerator(&models.AzureWorkItemChange{})
+	workItemIdGen := didgen.NewDomainIdGenerator(&models.AzureWorkItem{})
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.AzureIteration{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzureAccount{})
+	convertAccountId := func(accountId string) string {
+		if accountId == "" {
+			return ""
+		}
+		return accountIdGen.Generate(connectionId, accountId)
+	}
+	convertIterationId := func(iterationId string) string {
+		id, err := strconv.Atoi(iterationId)
+		if err != nil || !iterationIds[id] {
+			return ""
+		}
+		return iterationIdGen.Generate(connectionId, id)
+	}
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzureWorkItemChange{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			change := inputRow.(*models.AzureWorkItemChange)
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: changeIdGen.Generate(change.ConnectionId, change.WorkItemId, change.UpdateId, change.Field),
+				},
+				IssueId:     workItemIdGen.Generate(change.ConnectionId, change.WorkItemId),
+				AuthorId:    convertAccountId(change.AuthorId),
+				AuthorName:  change.AuthorName,
+				FieldId:     change.Field,
+				CreatedDate: change.ChangedDate,
+			}
+			// the field names and values follow the conventions of the other ticket tools, so the dashboards work
+			switch change.Field {
+			case fieldState:
+				changelog.FieldName = "status"
+				changelog.OriginalFromValue = change.OldValue
+				changelog.OriginalToValue = change.NewValue
+				changelog.FromValue = getStdStatus(statusMappings, change.OldValue)
+				changelog.ToValue = getStdStatus(statusMappings, change.NewValue)
+			case fieldAssignedTo:
+				changelog.FieldName = "assignee"
+				changelog.OriginalFromValue = convertAccountId(change.OldValue)
+				changelog.OriginalToValue = convertAccountId(change.NewValue)
+			case fieldIterationId:
+				changelog.FieldName = "Sprint"
+				changelog.OriginalFromValue = convertIterationId(change.OldValue)
+				changelog.OriginalToValue = convertIterationId(change.NewValue)
+			default:
+				return nil, nil
+			}
+			return []interface{}{changelog}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,202 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_WORK_ITEMS_TABLE = "azure_api_work_items"
+
+// wiqlMaxResults is the max number of work items returned by a wiql query
+const wiqlMaxResults = 20000
+
+// workItemsBatchSize is the max number of work items returned by the batch api
+const workItemsBatchSize = 200
+
+var CollectApiWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiWorkItems",
+	EntryPoint:       CollectApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Collect work items data from Azure api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+}
+
+type AzureWorkItemIdsInput struct {
+	Ids string
+}
+
+func CollectApiWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEMS_TABLE)
+	collectorWithState, err := api.NewApiCollectorWithState(*rawDataSubTaskArgs, data.CreatedDateAfter)
+	if err != nil {
+		return err
+	}
+
+	// the ids of the work items are queried by wiql first, then the work items are fetched in batches
+	conditions := []string{"[System.TeamProject] = @project"}
+	if data.CreatedDateAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("[System.CreatedDate] >= '%s'", formatApiTime(data.CreatedDateAfter)))
+	}
+	incremental := collectorWithState.IsIncremental()
+	if incremental {
+		conditions = append(conditions, fmt.Sprintf("[System.ChangedDate] >= '%s'", formatApiTime(collectorWithState.LatestState.LatestSuccessStart)))
+	} else if collectorWithState.IsBackfill() {
+		// the window was widened to an earlier date, collect only work items created in the missing ranges
+		conditions = append(conditions, buildCreatedRangesWiql(collectorWithState.GetMissingRanges()))
+		incremental = true
+	}
+	ids, err := queryWorkItemIds(data.ApiClient, data.Options.Project, conditions)
+	if err != nil {
+		return err
+	}
+	taskCtx.GetLogger().Info("%d work items to be collected", len(ids))
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Incremental: incremental,
+		Input:       newWorkItemIdsIterator(ids, workItemsBatchSize),
+		UrlTemplate: "{{ .Params.Project }}/_apis/wit/workitems",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("api-version", "7.1-preview.3")
+			query.Set("ids", reqData.Input.(*AzureWorkItemIdsInput).Ids)
+			query.Set("$expand", "all")
+			// work items deleted after being queried are returned as null instead of failing the whole batch
+			query.Set("errorPolicy", "omit")
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			messages, err := GetRawMessageFromResponse(res)
+			if err != nil {
+				return nil, err
+			}
+			workItems := make([]json.RawMessage, 0, len(messages))
+			for _, message := range messages {
+				if string(message) != "null" {
+					workItems = append(workItems, message)
+				}
+			}
+			return workItems, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
+
+// queryWorkItemIds runs the wiql query page by page, a page is started after the last id of the previous one
+// because wiql returns no more than wiqlMaxResults work items
+func queryWorkItemIds(apiClient *api.ApiAsyncClient, project string, conditions []string) ([]int, errors.Error) {
+	var ids []int
+	lastId := 0
+	for {
+		query := url.Values{}
+		query.Set("api-version", "7.1-preview.2")
+		query.Set("timePrecision", "true")
+		query.Set("$top", fmt.Sprintf("%v", wiqlMaxResults))
+		body := map[string]string{"query": buildWorkItemsWiql(conditions, lastId)}
+		res, err := apiClient.Post(fmt.Sprintf("%s/_apis/wit/wiql", url.PathEscape(project)), query, body, nil)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("failed to query work items of project %s", project))
+		}
+		var result struct {
+			WorkItems []struct {
+				Id int `json:"id"`
+			} `json:"workItems"`
+		}
+		err = api.UnmarshalResponse(res, &result)
+		if err != nil {
+			return nil, err
+		}
+		for _, workItem := range result.WorkItems {
+			ids = append(ids, workItem.Id)
+		}
+		if len(result.WorkItems) < wiqlMaxResults {
+			return ids, nil
+		}
+		lastId = ids[len(ids)-1]
+	}
+}
+
+// buildWorkItemsWiql returns the wiql selecting the ids of the work items matching all the conditions,
+// ordered by id so that the query can be paged by the last id
+func buildWorkItemsWiql(conditions []string, lastId int) string {
+	if lastId > 0 {
+		conditions = append(append([]string{}, conditions...), fmt.Sprintf("[System.Id] > %d", lastId))
+	}
+	return fmt.Sprintf("SELECT [System.Id] FROM WorkItems WHERE %s ORDER BY [System.Id]", strings.Join(conditions, " AND "))
+}
+
+// buildCreatedRangesWiql returns the wiql condition matching work items created in any of the ranges
+func buildCreatedRangesWiql(ranges []coreModels.TimeRange) string {
+	conditions := make([]string, 0, len(ranges))
+	for _, timeRange := range ranges {
+		condition := fmt.Sprintf("[System.CreatedDate] < '%s'", formatApiTime(timeRange.Before))
+		if timeRange.After != nil {
+			condition = fmt.Sprintf("[System.CreatedDate] >= '%s' AND %s", formatApiTime(timeRange.After), condition)
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s)", condition))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(conditions, " OR "))
+}
+
+// workItemIdsIterator splits the ids into batches for the batch api
+type workItemIdsIterator struct {
+	ids       []int
+	batchSize int
+}
+
+func newWorkItemIdsIterator(ids []int, batchSize int) *workItemIdsIterator {
+	return &workItemIdsIterator{ids: ids, batchSize: batchSize}
+}
+
+func (it *workItemIdsIterator) HasNext() bool {
+	return len(it.ids) > 0
+}
+
+func (it *workItemIdsIterator) Fetch() (interface{}, errors.Error) {
+	size := it.batchSize
+	if size > len(it.ids) {
+		size = len(it.ids)
+	}
+	batch := make([]string, 0, size)
+	for _, id := range it.ids[:size] {
+		batch = append(batch, fmt.Sprintf("%d", id))
+	}
+	it.ids = it.ids[size:]
+	return &AzureWorkItemIdsInput{Ids: strings.Join(batch, ",")}, nil
+}
+
+func (it *workItemIdsIterator) Close() errors.Error {
+	return nil
+}
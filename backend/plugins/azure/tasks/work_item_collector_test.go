@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWorkItemsWiql(t *testing.T) {
+	conditions := []string{"[System.TeamProject] = @project"}
+	assert.Equal(
+		t,
+		"SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project ORDER BY [System.Id]",
+		buildWorkItemsWiql(conditions, 0),
+	)
+	assert.Equal(
+		t,
+		"SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.Id] > 20000 ORDER BY [System.Id]",
+		buildWorkItemsWiql(conditions, 20000),
+	)
+	assert.Equal(t, []string{"[System.TeamProject] = @project"}, conditions)
+}
+
+func TestBuildCreatedRangesWiql(t *testing.T) {
+	jan := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(
+		t,
+		"(([System.CreatedDate] < '2023-01-01T00:00:00Z') OR ([System.CreatedDate] >= '2023-02-01T00:00:00Z' AND [System.CreatedDate] < '2023-03-01T00:00:00Z'))",
+		buildCreatedRangesWiql([]models.TimeRange{{Before: &jan}, {After: &feb, Before: &mar}}),
+	)
+}
+
+func TestWorkItemIdsIterator(t *testing.T) {
+	iterator := newWorkItemIdsIterator([]int{1, 2, 3, 4, 5}, 2)
+	var batches []string
+	for iterator.HasNext() {
+		input, err := iterator.Fetch()
+		assert.Nil(t, err)
+		batches = append(batches, input.(*AzureWorkItemIdsInput).Ids)
+	}
+	assert.Equal(t, []string{"1,2", "3,4", "5"}, batches)
+	assert.False(t, newWorkItemIdsIterator(nil, 2).HasNext())
+}
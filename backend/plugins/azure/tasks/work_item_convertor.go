@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+var ConvertWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "convertWorkItems",
+	EntryPoint:       ConvertWorkItems,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azure_work_items into domain layer table issues, board_issues and sprint_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEMS_TABLE)
+	db := taskCtx.GetDal()
+	project, err := getProject(taskCtx)
+	if err != nil {
+		return err
+	}
+	iterationIds, err := loadIterationIds(taskCtx, project.AzureId)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.AzureWorkItem{}),
+		dal.Where("connection_id = ? AND project_id = ?", data.Options.ConnectionId, project.AzureId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	boardId := didgen.NewDomainIdGenerator(&models.AzureProject{}).Generate(project.ConnectionId, project.AzureId)
+	workItemIdGen := didgen.NewDomainIdGenerator(&models.AzureWorkItem{})
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.AzureIteration{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzureAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzureWorkItem{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			workItem := inputRow.(*models.AzureWorkItem)
+			issue := &ticket.Issue{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: workItemIdGen.Generate(workItem.ConnectionId, workItem.AzureId),
+				},
+				Url:                     workItem.Url,
+				IssueKey:                strconv.Itoa(workItem.AzureId),
+				Title:                   workItem.Title,
+				Description:             workItem.Description,
+				Type:                    workItem.StdType,
+				OriginalType:            workItem.Type,
+				Status:                  workItem.StdStatus,
+				OriginalStatus:          workItem.State,
+				StoryPoint:              int64(workItem.StoryPoint),
+				CreatedDate:             &workItem.CreatedDate,
+				UpdatedDate:             &workItem.ChangedDate,
+				Priority:                workItem.Priority,
+				Severity:                workItem.Severity,
+				OriginalEstimateMinutes: int64(workItem.OriginalEstimateHours * 60),
+				TimeSpentMinutes:        int64(workItem.CompletedWorkHours * 60),
+				TimeRemainingMinutes:    int64(workItem.RemainingWorkHours * 60),
+				CreatorName:             workItem.CreatorName,
+				AssigneeName:            workItem.AssigneeName,
+				OriginalProject:         project.Name,
+			}
+			issue.ResolutionDate = workItem.ResolvedDate
+			if issue.ResolutionDate == nil {
+				issue.ResolutionDate = workItem.ClosedDate
+			}
+			if issue.ResolutionDate != nil {
+				issue.LeadTimeMinutes = int64(issue.ResolutionDate.Sub(workItem.CreatedDate).Minutes())
+			}
+			if workItem.ParentId != 0 {
+				issue.ParentIssueId = workItemIdGen.Generate(workItem.ConnectionId, workItem.ParentId)
+			}
+			if workItem.CreatorId != "" {
+				issue.CreatorId = accountIdGen.Generate(workItem.ConnectionId, workItem.CreatorId)
+			}
+			if workItem.AssigneeId != "" {
+				issue.AssigneeId = accountIdGen.Generate(workItem.ConnectionId, workItem.AssigneeId)
+			}
+			results := []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issue.Id,
+				},
+			}
+			if iterationIds[workItem.IterationId] {
+				results = append(results, &ticket.SprintIssue{
+					SprintId: iterationIdGen.Generate(workItem.ConnectionId, workItem.IterationId),
+					IssueId:  issue.Id,
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
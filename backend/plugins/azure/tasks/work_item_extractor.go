@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+type AzureApiWorkItem struct {
+	Id     int `json:"id"`
+	Rev    int `json:"rev"`
+	Fields struct {
+		WorkItemType     string            `json:"System.WorkItemType"`
+		State            string            `json:"System.State"`
+		Title            string            `json:"System.Title"`
+		Description      string            `json:"System.Description"`
+		CreatedDate      api.Iso8601Time   `json:"System.CreatedDate"`
+		ChangedDate      api.Iso8601Time   `json:"System.ChangedDate"`
+		CreatedBy        *AzureApiIdentity `json:"System.CreatedBy"`
+		AssignedTo       *AzureApiIdentity `json:"System.AssignedTo"`
+		IterationId      int               `json:"System.IterationId"`
+		IterationPath    string            `json:"System.IterationPath"`
+		AreaPath         string            `json:"System.AreaPath"`
+		Parent           int               `json:"System.Parent"`
+		Tags             string            `json:"System.Tags"`
+		Priority         *int              `json:"Microsoft.VSTS.Common.Priority"`
+		Severity         string            `json:"Microsoft.VSTS.Common.Severity"`
+		ResolvedDate     *api.Iso8601Time  `json:"Microsoft.VSTS.Common.ResolvedDate"`
+		ClosedDate       *api.Iso8601Time  `json:"Microsoft.VSTS.Common.ClosedDate"`
+		StoryPoints      *float64          `json:"Microsoft.VSTS.Scheduling.StoryPoints"`
+		Effort           *float64          `json:"Microsoft.VSTS.Scheduling.Effort"`
+		Size             *float64          `json:"Microsoft.VSTS.Scheduling.Size"`
+		OriginalEstimate float64           `json:"Microsoft.VSTS.Scheduling.OriginalEstimate"`
+		CompletedWork    float64           `json:"Microsoft.VSTS.Scheduling.CompletedWork"`
+		RemainingWork    float64           `json:"Microsoft.VSTS.Scheduling.RemainingWork"`
+	} `json:"fields"`
+	Links struct {
+		Html struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"_links"`
+}
+
+var ExtractApiWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiWorkItems",
+	EntryPoint:       ExtractApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Extract raw work items data into tool layer table azure_work_items",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+}
+
+func ExtractApiWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEMS_TABLE)
+	connectionId := data.Options.ConnectionId
+	project, err := getProject(taskCtx)
+	if err != nil {
+		return err
+	}
+	var typeMappings, statusMappings map[string]interface{}
+	if data.Options.AzureTransformationRule != nil {
+		typeMappings = data.Options.TypeMappings
+		statusMappings = data.Options.StatusMappings
+	}
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			body := &AzureApiWorkItem{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			fields := &body.Fields
+			workItem := &models.AzureWorkItem{
+				ConnectionId:          connectionId,
+				AzureId:               body.Id,
+				ProjectId:             project.AzureId,
+				Rev:                   body.Rev,
+				Type:                  fields.WorkItemType,
+				StdType:               getStdType(typeMappings, fields.WorkItemType),
+				State:                 fields.State,
+				StdStatus:             getStdStatus(statusMappings, fields.State),
+				Title:                 fields.Title,
+				Description:           fields.Description,
+				Url:                   body.Links.Html.Href,
+				ParentId:              fields.Parent,
+				IterationId:           fields.IterationId,
+				IterationPath:         fields.IterationPath,
+				AreaPath:              fields.AreaPath,
+				Severity:              fields.Severity,
+				Tags:                  fields.Tags,
+				OriginalEstimateHours: fields.OriginalEstimate,
+				CompletedWorkHours:    fields.CompletedWork,
+				RemainingWorkHours:    fields.RemainingWork,
+				CreatedDate:           fields.CreatedDate.ToTime(),
+				ChangedDate:           fields.ChangedDate.ToTime(),
+				ResolvedDate:          api.Iso8601TimeToTime(fields.ResolvedDate),
+				ClosedDate:            api.Iso8601TimeToTime(fields.ClosedDate),
+			}
+			if fields.Priority != nil {
+				workItem.Priority = strconv.Itoa(*fields.Priority)
+			}
+			// the estimate is named after the process template, story points for agile, effort for scrum
+			// and size for cmmi
+			for _, storyPoint := range []*float64{fields.StoryPoints, fields.Effort, fields.Size} {
+				if storyPoint != nil {
+					workItem.StoryPoint = *storyPoint
+					break
+				}
+			}
+			results := []interface{}{workItem}
+			if fields.CreatedBy != nil {
+				workItem.CreatorId = fields.CreatedBy.Id
+				workItem.CreatorName = fields.CreatedBy.DisplayName
+				if account := extractAccount(connectionId, fields.CreatedBy); account != nil {
+					results = append(results, account)
+				}
+			}
+			if fields.AssignedTo != nil {
+				workItem.AssigneeId = fields.AssignedTo.Id
+				workItem.AssigneeName = fields.AssignedTo.DisplayName
+				if account := extractAccount(connectionId, fields.AssignedTo); account != nil {
+					results = append(results, account)
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_WORK_ITEM_UPDATES_TABLE = "azure_api_work_item_updates"
+
+var CollectApiWorkItemUpdatesMeta = plugin.SubTaskMeta{
+	Name:             "collectApiWorkItemUpdates",
+	EntryPoint:       CollectApiWorkItemUpdates,
+	EnabledByDefault: true,
+	Description:      "Collect work item updates data from Azure api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectApiWorkItemUpdates(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEM_UPDATES_TABLE)
+	project, err := getProject(taskCtx)
+	if err != nil {
+		return err
+	}
+	collectorWithState, err := api.NewApiCollectorWithState(*rawDataSubTaskArgs, data.CreatedDateAfter)
+	if err != nil {
+		return err
+	}
+
+	// only the work items changed since the last successful collection have new updates
+	var changedDateAfter *time.Time
+	incremental := collectorWithState.IsIncremental()
+	if incremental {
+		changedDateAfter = collectorWithState.LatestState.LatestSuccessStart
+	}
+	iterator, err := GetWorkItemsIterator(taskCtx, project.AzureId, changedDateAfter)
+	if err != nil {
+		return err
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    200,
+		Incremental: incremental,
+		Input:       iterator,
+		UrlTemplate: "{{ .Params.Project }}/_apis/wit/workItems/{{ .Input.AzureId }}/updates",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQueryForTopSkip(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("api-version", "7.1-preview.3")
+			return query, nil
+		},
+		ResponseParser: GetRawMessageFromResponse,
+		AfterResponse:  ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
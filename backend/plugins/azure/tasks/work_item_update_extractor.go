@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azure/models"
+)
+
+const (
+	fieldState       = "System.State"
+	fieldAssignedTo  = "System.AssignedTo"
+	fieldIterationId = "System.IterationId"
+	fieldChangedDate = "System.ChangedDate"
+)
+
+type AzureApiFieldChange struct {
+	OldValue json.RawMessage `json:"oldValue"`
+	NewValue json.RawMessage `json:"newValue"`
+}
+
+type AzureApiWorkItemUpdate struct {
+	Id          int                            `json:"id"`
+	WorkItemId  int                            `json:"workItemId"`
+	Rev         int                            `json:"rev"`
+	RevisedBy   AzureApiIdentity               `json:"revisedBy"`
+	RevisedDate api.Iso8601Time                `json:"revisedDate"`
+	Fields      map[string]AzureApiFieldChange `json:"fields"`
+}
+
+var ExtractApiWorkItemUpdatesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiWorkItemUpdates",
+	EntryPoint:       ExtractApiWorkItemUpdates,
+	EnabledByDefault: true,
+	Description:      "Extract raw work item updates data into tool layer table azure_work_item_changes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ExtractApiWorkItemUpdates(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RAW_WORK_ITEM_UPDATES_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			body := &AzureApiWorkItemUpdate{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			// the revised date of the latest update is 9999-01-01, the changed date is the real one
+			changedDate := body.RevisedDate.ToTime()
+			if change, ok := body.Fields[fieldChangedDate]; ok {
+				var date api.Iso8601Time
+				if json.Unmarshal(change.NewValue, &date) == nil {
+					changedDate = date.ToTime()
+				}
+			}
+			results := make([]interface{}, 0, len(body.Fields))
+			if account := extractAccount(connectionId, &body.RevisedBy); account != nil {
+				results = append(results, account)
+			}
+			for _, field := range []string{fieldState, fieldAssignedTo, fieldIterationId} {
+				change, ok := body.Fields[field]
+				if !ok {
+					continue
+				}
+				workItemChange := &models.AzureWorkItemChange{
+					ConnectionId: connectionId,
+					WorkItemId:   body.WorkItemId,
+					UpdateId:     body.Id,
+					Field:        field,
+					AuthorId:     body.RevisedBy.Id,
+					AuthorName:   body.RevisedBy.DisplayName,
+					ChangedDate:  changedDate,
+				}
+				if field == fieldAssignedTo {
+					oldAssignee, newAssignee := parseIdentity(change.OldValue), parseIdentity(change.NewValue)
+					workItemChange.OldValue = oldAssignee.Id
+					workItemChange.NewValue = newAssignee.Id
+					for _, assignee := range []*AzureApiIdentity{oldAssignee, newAssignee} {
+						if account := extractAccount(connectionId, assignee); account != nil {
+							results = append(results, account)
+						}
+					}
+				} else {
+					workItemChange.OldValue = parseFieldValue(change.OldValue)
+					workItemChange.NewValue = parseFieldValue(change.NewValue)
+				}
+				results = append(results, workItemChange)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// parseIdentity returns an empty identity for unassigned work items
+func parseIdentity(value json.RawMessage) *AzureApiIdentity {
+	identity := &AzureApiIdentity{}
+	if len(value) > 0 {
+		_ = json.Unmarshal(value, identity)
+	}
+	return identity
+}
+
+// parseFieldValue returns strings as they are and numbers in their decimal form
+func parseFieldValue(value json.RawMessage) string {
+	if len(value) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return s
+	}
+	var n int64
+	if json.Unmarshal(value, &n) == nil {
+		return strconv.FormatInt(n, 10)
+	}
+	return string(value)
+}
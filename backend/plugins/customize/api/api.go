@@ -29,8 +29,9 @@ import (
 )
 
 type field struct {
-	ColumnName string `json:"columnName"`
-	ColumnType string `json:"columnType"`
+	ColumnName string           `json:"columnName"`
+	ColumnType string           `json:"columnType"`
+	Type       models.FieldType `json:"type"`
 }
 
 func getFields(d dal.Dal, tbl string) ([]field, errors.Error) {
@@ -42,9 +43,11 @@ func getFields(d dal.Dal, tbl string) ([]field, errors.Error) {
 	}
 	var result []field
 	for _, col := range columns {
+		fieldType := models.FieldTypeOfColumn(col.DatabaseTypeName())
 		result = append(result, field{
 			ColumnName: col.Name(),
-			ColumnType: "VARCHAR(255)",
+			ColumnType: fieldType.ColumnType(d.Dialect()),
+			Type:       fieldType,
 		})
 	}
 	return result, nil
@@ -65,7 +68,8 @@ func checkField(d dal.Dal, table, field string) (bool, errors.Error) {
 	return false, nil
 }
 
-func CreateField(d dal.Dal, table, field string) errors.Error {
+// CreateField adds the customized field with the FieldType to the table if it doesn't exist yet
+func CreateField(d dal.Dal, table, field string, fieldType models.FieldType) errors.Error {
 	exists, err := checkField(d, table, field)
 	if err != nil {
 		return err
@@ -73,7 +77,7 @@ func CreateField(d dal.Dal, table, field string) errors.Error {
 	if exists {
 		return nil
 	}
-	err = d.AddColumn(table, field, "VARCHAR(255)")
+	err = d.AddColumn(table, field, fieldType.ColumnType(d.Dialect()))
 	if err != nil {
 		return errors.Default.Wrap(err, "AddColumn error")
 	}
@@ -98,6 +102,7 @@ func deleteField(d dal.Dal, table, field string) errors.Error {
 //nolint:unused
 type input struct {
 	Name string `json:"name" example:"x_new_column"`
+	Type string `json:"type" example:"varchar" enums:"varchar,text,integer,float,datetime,boolean"`
 }
 type Handlers struct {
 	dal dal.Dal
@@ -125,7 +130,7 @@ func (h *Handlers) ListFields(input *plugin.ApiResourceInput) (*plugin.ApiResour
 
 // CreateFields create a customized field
 // @Summary create a customized field
-// @Description create a customized field
+// @Description create a customized field, the type can be varchar(default), text, integer, float, datetime or boolean
 // @Tags plugins/customize
 // @Param request body input true "request body"
 // @Success 200  {object} shared.ApiBody "Success"
@@ -138,11 +143,16 @@ func (h *Handlers) CreateFields(input *plugin.ApiResourceInput) (*plugin.ApiReso
 	if !ok {
 		return &plugin.ApiResourceOutput{Status: http.StatusBadRequest}, errors.BadInput.New("the name is not string")
 	}
-	err := CreateField(h.dal, table, fld)
+	typ, _ := input.Body["type"].(string)
+	fieldType, err := models.NewFieldType(typ)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Status: http.StatusBadRequest}, err
+	}
+	err = CreateField(h.dal, table, fld, fieldType)
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "CreateField error")
 	}
-	return &plugin.ApiResourceOutput{Body: field{fld, "varchar(255)"}, Status: http.StatusOK}, nil
+	return &plugin.ApiResourceOutput{Body: field{fld, fieldType.ColumnType(h.dal.Dialect()), fieldType}, Status: http.StatusOK}, nil
 }
 
 // DeleteField delete a customized fields
@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/customize/api"
 	"github.com/apache/incubator-devlake/plugins/customize/impl"
+	"github.com/apache/incubator-devlake/plugins/customize/models"
 	"github.com/apache/incubator-devlake/plugins/customize/tasks"
 	"testing"
 )
@@ -42,7 +43,7 @@ func TestBoardDataFlow(t *testing.T) {
 	// import raw data table
 	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jira_api_issues.csv", "_raw_jira_api_issues")
 	dataflowTester.ImportCsvIntoTabler("./raw_tables/issues.csv", &ticket.Issue{})
-	err := api.CreateField(dataflowTester.Dal, "issues", "x_test")
+	err := api.CreateField(dataflowTester.Dal, "issues", "x_test", models.FieldTypeVarchar)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"github.com/apache/incubator-devlake/core/errors"
+	"strings"
+)
+
+// FieldType is the data type of a customized field
+type FieldType string
+
+const (
+	FieldTypeVarchar  FieldType = "varchar"
+	FieldTypeText     FieldType = "text"
+	FieldTypeInteger  FieldType = "integer"
+	FieldTypeFloat    FieldType = "float"
+	FieldTypeDatetime FieldType = "datetime"
+	FieldTypeBoolean  FieldType = "boolean"
+)
+
+// NewFieldType validates `s` and returns the FieldType, an empty string falls back to varchar
+func NewFieldType(s string) (FieldType, errors.Error) {
+	if s == "" {
+		return FieldTypeVarchar, nil
+	}
+	t := FieldType(strings.ToLower(s))
+	switch t {
+	case FieldTypeVarchar, FieldTypeText, FieldTypeInteger, FieldTypeFloat, FieldTypeDatetime, FieldTypeBoolean:
+		return t, nil
+	}
+	return "", errors.BadInput.New(fmt.Sprintf("unsupported field type %s", s))
+}
+
+// ColumnType returns the SQL column type of the FieldType for the database dialect
+func (t FieldType) ColumnType(dialect string) string {
+	switch t {
+	case FieldTypeText:
+		return "TEXT"
+	case FieldTypeInteger:
+		return "BIGINT"
+	case FieldTypeFloat:
+		if dialect == "postgres" {
+			return "DOUBLE PRECISION"
+		}
+		return "DOUBLE"
+	case FieldTypeDatetime:
+		if dialect == "postgres" {
+			return "TIMESTAMPTZ"
+		}
+		return "DATETIME(3)"
+	case FieldTypeBoolean:
+		return "BOOLEAN"
+	}
+	return "VARCHAR(255)"
+}
+
+// FieldTypeOfColumn returns the FieldType by the database type name of a column, columns of unknown
+// types are treated as varchar
+func FieldTypeOfColumn(databaseTypeName string) FieldType {
+	switch strings.ToLower(databaseTypeName) {
+	case "text", "mediumtext", "longtext":
+		return FieldTypeText
+	case "bigint", "int", "int8", "int4", "integer":
+		return FieldTypeInteger
+	case "double", "float", "float8", "double precision", "numeric", "decimal":
+		return FieldTypeFloat
+	case "datetime", "timestamp", "timestamptz":
+		return FieldTypeDatetime
+	case "tinyint", "bool", "boolean":
+		return FieldTypeBoolean
+	}
+	return FieldTypeVarchar
+}
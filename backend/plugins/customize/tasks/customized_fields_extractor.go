@@ -22,9 +22,14 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/customize/models"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tidwall/gjson"
 )
@@ -44,9 +49,10 @@ func ExtractCustomizedFields(taskCtx plugin.SubTaskContext) errors.Error {
 		return nil
 	}
 	d := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
 	var err error
 	for _, rule := range data.Options.TransformationRules {
-		err = extractCustomizedFields(taskCtx.GetContext(), d, rule.Table, rule.RawDataTable, rule.RawDataParams, rule.Mapping)
+		err = extractCustomizedFields(taskCtx.GetContext(), d, logger, rule.Table, rule.RawDataTable, rule.RawDataParams, rule.Mapping)
 		if err != nil {
 			return errors.Default.Wrap(err, "error extracting customized fields")
 		}
@@ -54,11 +60,22 @@ func ExtractCustomizedFields(taskCtx plugin.SubTaskContext) errors.Error {
 	return nil
 }
 
-func extractCustomizedFields(ctx context.Context, d dal.Dal, table, rawTable, rawDataParams string, extractor map[string]string) error {
+func extractCustomizedFields(ctx context.Context, d dal.Dal, logger log.Logger, table, rawTable, rawDataParams string, extractor map[string]string) error {
 	pkFields, err := dal.GetPrimarykeyColumns(d, &models.Table{Name: table})
 	if err != nil {
 		return err
 	}
+	// values are converted according to the types of the customized fields
+	columns, err := d.GetColumns(&models.Table{Name: table}, func(columnMeta dal.ColumnMeta) bool {
+		return strings.HasPrefix(columnMeta.Name(), "x_")
+	})
+	if err != nil {
+		return err
+	}
+	fieldTypes := make(map[string]models.FieldType)
+	for _, column := range columns {
+		fieldTypes[column.Name()] = models.FieldTypeOfColumn(column.DatabaseTypeName())
+	}
 	rawDataField := fmt.Sprintf("%s.data", rawTable)
 	// `fields` only include `_raw_data_id` and primary keys coming from the domain layer table, and `data` coming from the raw layer
 	fields := []string{fmt.Sprintf("%s.%s", table, "_raw_data_id")}
@@ -78,6 +95,7 @@ func extractCustomizedFields(ctx context.Context, d dal.Dal, table, rawTable, ra
 	}
 	defer rows.Close()
 
+	badValues := 0
 	for rows.Next() {
 		select {
 		case <-ctx.Done():
@@ -85,28 +103,39 @@ func extractCustomizedFields(ctx context.Context, d dal.Dal, table, rawTable, ra
 		default:
 		}
 		row := make(map[string]interface{})
-		updates := make(map[string]string)
+		updates := make(map[string]interface{})
 		err = d.Fetch(rows, &row)
 		if err != nil {
 			return err
 		}
+		results := make(map[string]gjson.Result)
 		switch blob := row["data"].(type) {
 		case []byte:
 			for field, path := range extractor {
-				updates[field] = gjson.GetBytes(blob, path).String()
+				results[field] = gjson.GetBytes(blob, path)
 			}
 		case string:
 			for field, path := range extractor {
-				updates[field] = gjson.Get(blob, path).String()
+				results[field] = gjson.Get(blob, path)
 			}
 		default:
 			return nil
 		}
 
+		// remove columns that are not primary key
+		delete(row, "_raw_data_id")
+		delete(row, "data")
+		for field, result := range results {
+			value, err := convertFieldValue(result, fieldTypes[field])
+			if err != nil {
+				// bad values are reported and left empty, so they won't fail the whole extraction
+				logger.Warn(err, "failed to convert %s of %s %v", field, table, row)
+				badValues++
+			}
+			updates[field] = value
+		}
+
 		if len(updates) > 0 {
-			// remove columns that are not primary key
-			delete(row, "_raw_data_id")
-			delete(row, "data")
 			query, params := mkUpdate(table, updates, row)
 			err = d.Exec(query, params...)
 			if err != nil {
@@ -114,10 +143,90 @@ func extractCustomizedFields(ctx context.Context, d dal.Dal, table, rawTable, ra
 			}
 		}
 	}
+	if badValues > 0 {
+		logger.Warn(nil, "%d values of %s could not be converted and were left empty", badValues, table)
+	}
 	return nil
 }
 
-func mkUpdate(table string, updates map[string]string, pk map[string]interface{}) (string, []interface{}) {
+// convertFieldValue converts the json value to the FieldType, for types other than varchar and text,
+// nil is returned for null or missing values
+func convertFieldValue(result gjson.Result, fieldType models.FieldType) (interface{}, errors.Error) {
+	if fieldType == models.FieldTypeVarchar || fieldType == models.FieldTypeText || fieldType == "" {
+		return result.String(), nil
+	}
+	if !result.Exists() || result.Type == gjson.Null {
+		return nil, nil
+	}
+	switch fieldType {
+	case models.FieldTypeInteger:
+		if result.Type == gjson.Number {
+			if result.Num != math.Trunc(result.Num) {
+				return nil, errors.BadInput.New(fmt.Sprintf("%s is not an integer", result.Raw))
+			}
+			return result.Int(), nil
+		}
+		s := strings.TrimSpace(result.String())
+		if s == "" {
+			return nil, nil
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("%s is not an integer", result.Raw))
+		}
+		return i, nil
+	case models.FieldTypeFloat:
+		if result.Type == gjson.Number {
+			return result.Float(), nil
+		}
+		s := strings.TrimSpace(result.String())
+		if s == "" {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("%s is not a number", result.Raw))
+		}
+		return f, nil
+	case models.FieldTypeBoolean:
+		switch result.Type {
+		case gjson.True, gjson.False:
+			return result.Bool(), nil
+		case gjson.Number:
+			return result.Num != 0, nil
+		}
+		s := strings.TrimSpace(result.String())
+		if s == "" {
+			return nil, nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("%s is not a boolean", result.Raw))
+		}
+		return b, nil
+	case models.FieldTypeDatetime:
+		// numbers are treated as unix timestamps in either seconds or milliseconds
+		if result.Type == gjson.Number {
+			ts := result.Int()
+			if ts > 1e11 {
+				return time.UnixMilli(ts).UTC(), nil
+			}
+			return time.Unix(ts, 0).UTC(), nil
+		}
+		s := strings.TrimSpace(result.String())
+		if s == "" {
+			return nil, nil
+		}
+		t, err := api.ConvertStringToTime(s)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("%s is not a datetime", result.Raw))
+		}
+		return t, nil
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unsupported field type %s", fieldType))
+}
+
+func mkUpdate(table string, updates map[string]interface{}, pk map[string]interface{}) (string, []interface{}) {
 	var params []interface{}
 	stat := fmt.Sprintf("UPDATE %s SET ", table)
 	var uu []string
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/plugins/customize/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+	"testing"
+	"time"
+)
+
+func TestConvertFieldValue(t *testing.T) {
+	blob := `{"points":5,"pointsStr":" 8 ","ratio":"1.5","flag":"true","num":0,"created":"2023-03-01T10:00:00Z",
+		"ts":1677664800,"tsMs":1677664800000,"day":"2023-03-01","bad":"abc","half":2.5,"empty":"","nil":null}`
+	get := func(path string) gjson.Result {
+		return gjson.Get(blob, path)
+	}
+
+	v, err := convertFieldValue(get("points"), models.FieldTypeVarchar)
+	assert.Nil(t, err)
+	assert.Equal(t, "5", v)
+	v, err = convertFieldValue(get("missing"), models.FieldTypeText)
+	assert.Nil(t, err)
+	assert.Equal(t, "", v)
+
+	v, err = convertFieldValue(get("points"), models.FieldTypeInteger)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), v)
+	v, err = convertFieldValue(get("pointsStr"), models.FieldTypeInteger)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), v)
+	_, err = convertFieldValue(get("half"), models.FieldTypeInteger)
+	assert.NotNil(t, err)
+	_, err = convertFieldValue(get("bad"), models.FieldTypeInteger)
+	assert.NotNil(t, err)
+
+	v, err = convertFieldValue(get("ratio"), models.FieldTypeFloat)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, v)
+
+	v, err = convertFieldValue(get("flag"), models.FieldTypeBoolean)
+	assert.Nil(t, err)
+	assert.Equal(t, true, v)
+	v, err = convertFieldValue(get("num"), models.FieldTypeBoolean)
+	assert.Nil(t, err)
+	assert.Equal(t, false, v)
+
+	expected := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	for _, path := range []string{"created", "ts", "tsMs"} {
+		v, err = convertFieldValue(get(path), models.FieldTypeDatetime)
+		assert.Nil(t, err)
+		assert.True(t, expected.Equal(v.(time.Time)), path)
+	}
+	v, err = convertFieldValue(get("day"), models.FieldTypeDatetime)
+	assert.Nil(t, err)
+	assert.True(t, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC).Equal(v.(time.Time)))
+	_, err = convertFieldValue(get("bad"), models.FieldTypeDatetime)
+	assert.NotNil(t, err)
+
+	for _, path := range []string{"empty", "nil", "missing"} {
+		v, err = convertFieldValue(get(path), models.FieldTypeInteger)
+		assert.Nil(t, err)
+		assert.Nil(t, v, path)
+	}
+}
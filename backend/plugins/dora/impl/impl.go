@@ -84,6 +84,10 @@ func (p Dora) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.EnrichTaskEnvMeta,
 		tasks.CalculateChangeLeadTimeMeta,
 		tasks.ConnectIncidentToDeploymentMeta,
+		tasks.CalculateDeploymentFrequencyMeta,
+		tasks.CalculateMedianChangeLeadTimeMeta,
+		tasks.CalculateChangeFailureRateMeta,
+		tasks.CalculateTimeToRestoreServiceMeta,
 		tasks.CalculateChangeLeadTimeOldMeta,
 		tasks.ConnectIncidentToDeploymentOldMeta,
 	}
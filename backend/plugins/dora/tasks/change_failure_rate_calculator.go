@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"time"
+)
+
+var CalculateChangeFailureRateMeta = plugin.SubTaskMeta{
+	Name:             "calculateChangeFailureRate",
+	EntryPoint:       CalculateChangeFailureRate,
+	EnabledByDefault: true,
+	Description:      "Calculate daily, weekly and monthly change failure rate of the project",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CalculateChangeFailureRate(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	deployments, err := getProductionDeployments(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	failedDeploymentIds, err := getFailedDeploymentIds(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	metrics := calculateChangeFailureRateMetrics(data.Options.ProjectName, deployments, failedDeploymentIds)
+	return saveDoraMetrics(taskCtx, crossdomain.DORA_CHANGE_FAILURE_RATE, "cicd_tasks", metrics)
+}
+
+// calculateChangeFailureRateMetrics divides the number of deployments causing incidents by the number of deployments
+// finished within the same period, so the rate never exceeds 1
+func calculateChangeFailureRateMetrics(projectName string, deployments []doraSample, failedDeploymentIds map[string]bool) []*crossdomain.ProjectDoraMetric {
+	metrics := make([]*crossdomain.ProjectDoraMetric, 0)
+	for _, period := range doraPeriods {
+		totals := make(map[time.Time]int)
+		failures := make(map[time.Time]int)
+		for _, deployment := range deployments {
+			if deployment.Date == nil {
+				continue
+			}
+			start := getPeriodStart(*deployment.Date, period)
+			totals[start]++
+			if failedDeploymentIds[deployment.Id] {
+				failures[start]++
+			}
+		}
+		for start, total := range totals {
+			rate := float64(failures[start]) / float64(total)
+			metrics = append(metrics, &crossdomain.ProjectDoraMetric{
+				ProjectName: projectName,
+				Metric:      crossdomain.DORA_CHANGE_FAILURE_RATE,
+				Period:      period,
+				PeriodStart: start,
+				Value:       &rate,
+				SampleCount: total,
+				Level:       getChangeFailureRateLevel(rate),
+			})
+		}
+	}
+	return metrics
+}
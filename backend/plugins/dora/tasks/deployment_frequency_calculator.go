@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"time"
+)
+
+var CalculateDeploymentFrequencyMeta = plugin.SubTaskMeta{
+	Name:             "calculateDeploymentFrequency",
+	EntryPoint:       CalculateDeploymentFrequency,
+	EnabledByDefault: true,
+	Description:      "Calculate daily, weekly and monthly deployment frequency of the project",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func CalculateDeploymentFrequency(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	deployments, err := getProductionDeployments(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	metrics := calculateDeploymentFrequencyMetrics(data.Options.ProjectName, deployments, time.Now())
+	return saveDoraMetrics(taskCtx, crossdomain.DORA_DEPLOYMENT_FREQUENCY, "cicd_tasks", metrics)
+}
+
+// calculateDeploymentFrequencyMetrics generates a record for every day, week and month from the first deployment
+// until `until`, periods without deployments are kept since they are what drags the level down.
+// The value of a record is the number of days with at least one deployment, and the level follows the DORA
+// dashboard: the median number of deployment days per week decides Elite/High, a deployment within the month
+// decides Medium. Daily records carry no level since it can not be told from a single day.
+func calculateDeploymentFrequencyMetrics(projectName string, deployments []doraSample, until time.Time) []*crossdomain.ProjectDoraMetric {
+	metrics := make([]*crossdomain.ProjectDoraMetric, 0)
+	deploymentsPerDay := groupSamplesByPeriod(deployments, crossdomain.DORA_PERIOD_DAY)
+	if len(deploymentsPerDay) == 0 {
+		return metrics
+	}
+	var firstDay, lastDay time.Time
+	for day := range deploymentsPerDay {
+		if firstDay.IsZero() || day.Before(firstDay) {
+			firstDay = day
+		}
+		if day.After(lastDay) {
+			lastDay = day
+		}
+	}
+	if untilDay := getPeriodStart(until, crossdomain.DORA_PERIOD_DAY); untilDay.After(lastDay) {
+		lastDay = untilDay
+	}
+
+	deploymentDays := make(map[string]map[time.Time]int)
+	deploymentCounts := make(map[string]map[time.Time]int)
+	for _, period := range doraPeriods {
+		deploymentDays[period] = make(map[time.Time]int)
+		deploymentCounts[period] = make(map[time.Time]int)
+	}
+	for day, samples := range deploymentsPerDay {
+		for _, period := range doraPeriods {
+			start := getPeriodStart(day, period)
+			deploymentDays[period][start]++
+			deploymentCounts[period][start] += len(samples)
+		}
+	}
+
+	newMetric := func(period string, start time.Time, level string) *crossdomain.ProjectDoraMetric {
+		days := float64(deploymentDays[period][start])
+		return &crossdomain.ProjectDoraMetric{
+			ProjectName: projectName,
+			Metric:      crossdomain.DORA_DEPLOYMENT_FREQUENCY,
+			Period:      period,
+			PeriodStart: start,
+			Value:       &days,
+			SampleCount: deploymentCounts[period][start],
+			Level:       level,
+		}
+	}
+
+	for day := firstDay; !day.After(lastDay); day = getNextPeriodStart(day, crossdomain.DORA_PERIOD_DAY) {
+		metrics = append(metrics, newMetric(crossdomain.DORA_PERIOD_DAY, day, ""))
+	}
+
+	weeklyDeploymentDaysPerMonth := make(map[time.Time][]float64)
+	lastWeek := getPeriodStart(lastDay, crossdomain.DORA_PERIOD_WEEK)
+	for week := getPeriodStart(firstDay, crossdomain.DORA_PERIOD_WEEK); !week.After(lastWeek); week = getNextPeriodStart(week, crossdomain.DORA_PERIOD_WEEK) {
+		days := deploymentDays[crossdomain.DORA_PERIOD_WEEK][week]
+		month := getPeriodStart(week, crossdomain.DORA_PERIOD_MONTH)
+		weeklyDeploymentDaysPerMonth[month] = append(weeklyDeploymentDaysPerMonth[month], float64(days))
+		deployedInMonth := deploymentDays[crossdomain.DORA_PERIOD_MONTH][month] > 0
+		metrics = append(metrics, newMetric(crossdomain.DORA_PERIOD_WEEK, week, getDeploymentFrequencyLevel(float64(days), deployedInMonth)))
+	}
+
+	lastMonth := getPeriodStart(lastDay, crossdomain.DORA_PERIOD_MONTH)
+	for month := getPeriodStart(firstDay, crossdomain.DORA_PERIOD_MONTH); !month.After(lastMonth); month = getNextPeriodStart(month, crossdomain.DORA_PERIOD_MONTH) {
+		medianDaysPerWeek := 0.0
+		if median := getMedian(weeklyDeploymentDaysPerMonth[month]); median != nil {
+			medianDaysPerWeek = *median
+		}
+		deployedInMonth := deploymentDays[crossdomain.DORA_PERIOD_MONTH][month] > 0
+		metrics = append(metrics, newMetric(crossdomain.DORA_PERIOD_MONTH, month, getDeploymentFrequencyLevel(medianDaysPerWeek, deployedInMonth)))
+	}
+	return metrics
+}
+
+// getDeploymentFrequencyLevel returns the DORA level by the median number of deployment days per week
+func getDeploymentFrequencyLevel(medianDaysPerWeek float64, deployedInMonth bool) string {
+	switch {
+	case medianDaysPerWeek >= 3:
+		return crossdomain.DORA_LEVEL_ELITE
+	case medianDaysPerWeek >= 1:
+		return crossdomain.DORA_LEVEL_HIGH
+	case deployedInMonth:
+		return crossdomain.DORA_LEVEL_MEDIUM
+	}
+	return crossdomain.DORA_LEVEL_LOW
+}
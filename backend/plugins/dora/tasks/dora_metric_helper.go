@@ -0,0 +1,256 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"reflect"
+	"sort"
+	"time"
+)
+
+var doraPeriods = []string{
+	crossdomain.DORA_PERIOD_DAY,
+	crossdomain.DORA_PERIOD_WEEK,
+	crossdomain.DORA_PERIOD_MONTH,
+}
+
+// doraSample is a single data point (a deployment, a merged pull request or an incident) of a DORA metric
+type doraSample struct {
+	Id    string
+	Date  *time.Time
+	Value float64
+}
+
+// doraMetricParams is stored as _raw_data_params of project_dora_metrics so every metric can be flushed separately
+type doraMetricParams struct {
+	ProjectName string
+	Metric      string
+}
+
+// getPeriodStart returns the beginning of the day, week (Monday) or month that `t` falls into, in UTC
+func getPeriodStart(t time.Time, period string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case crossdomain.DORA_PERIOD_WEEK:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case crossdomain.DORA_PERIOD_MONTH:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// getNextPeriodStart returns the beginning of the period following the one starts at `start`
+func getNextPeriodStart(start time.Time, period string) time.Time {
+	switch period {
+	case crossdomain.DORA_PERIOD_WEEK:
+		return start.AddDate(0, 0, 7)
+	case crossdomain.DORA_PERIOD_MONTH:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// groupSamplesByPeriod puts samples into buckets keyed by the start of the period they fall into
+func groupSamplesByPeriod(samples []doraSample, period string) map[time.Time][]float64 {
+	groups := make(map[time.Time][]float64)
+	for _, sample := range samples {
+		if sample.Date == nil {
+			continue
+		}
+		start := getPeriodStart(*sample.Date, period)
+		groups[start] = append(groups[start], sample.Value)
+	}
+	return groups
+}
+
+// getMedian returns the lower median of values, which is how the DORA dashboard computes it
+// with `percent_rank() <= 0.5`, nil is returned when values is empty
+func getMedian(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	median := sorted[(len(sorted)-1)/2]
+	return &median
+}
+
+// getLeadTimeLevel returns the DORA level of a median change lead time in minutes
+func getLeadTimeLevel(minutes float64) string {
+	switch {
+	case minutes < 60:
+		return crossdomain.DORA_LEVEL_ELITE
+	case minutes < 7*24*60:
+		return crossdomain.DORA_LEVEL_HIGH
+	case minutes < 180*24*60:
+		return crossdomain.DORA_LEVEL_MEDIUM
+	}
+	return crossdomain.DORA_LEVEL_LOW
+}
+
+// getTimeToRestoreLevel returns the DORA level of a median time to restore service in minutes
+func getTimeToRestoreLevel(minutes float64) string {
+	switch {
+	case minutes < 60:
+		return crossdomain.DORA_LEVEL_ELITE
+	case minutes < 24*60:
+		return crossdomain.DORA_LEVEL_HIGH
+	case minutes < 7*24*60:
+		return crossdomain.DORA_LEVEL_MEDIUM
+	}
+	return crossdomain.DORA_LEVEL_LOW
+}
+
+// getChangeFailureRateLevel returns the DORA level of a change failure rate
+func getChangeFailureRateLevel(rate float64) string {
+	switch {
+	case rate <= .15:
+		return crossdomain.DORA_LEVEL_ELITE
+	case rate <= .20:
+		return crossdomain.DORA_LEVEL_HIGH
+	case rate <= .30:
+		return crossdomain.DORA_LEVEL_MEDIUM
+	}
+	return crossdomain.DORA_LEVEL_LOW
+}
+
+// calculateMedianMetrics rolls samples up into one record per period holding their median
+func calculateMedianMetrics(projectName string, metric string, samples []doraSample, getLevel func(float64) string) []*crossdomain.ProjectDoraMetric {
+	metrics := make([]*crossdomain.ProjectDoraMetric, 0)
+	for _, period := range doraPeriods {
+		for start, values := range groupSamplesByPeriod(samples, period) {
+			median := getMedian(values)
+			metrics = append(metrics, &crossdomain.ProjectDoraMetric{
+				ProjectName: projectName,
+				Metric:      metric,
+				Period:      period,
+				PeriodStart: start,
+				Value:       median,
+				SampleCount: len(values),
+				Level:       getLevel(*median),
+			})
+		}
+	}
+	return metrics
+}
+
+// saveDoraMetrics replaces all records of the metric of the project with the newly calculated ones
+func saveDoraMetrics(taskCtx plugin.SubTaskContext, metric string, table string, metrics []*crossdomain.ProjectDoraMetric) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	params, err := json.Marshal(doraMetricParams{
+		ProjectName: data.Options.ProjectName,
+		Metric:      metric,
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "unable to serialize dora metric params")
+	}
+	err = db.Delete(
+		&crossdomain.ProjectDoraMetric{},
+		dal.Where("project_name = ? AND metric = ?", data.Options.ProjectName, metric),
+	)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectDoraMetric{}), 500)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	defer batch.Close()
+	for _, m := range metrics {
+		m.NoPKModel = common.NewNoPKModel()
+		m.RawDataTable = table
+		m.RawDataParams = string(params)
+		err = batch.Add(m)
+		if err != nil {
+			return errors.Convert(err)
+		}
+	}
+	return errors.Convert(batch.Close())
+}
+
+// deploymentIdExpr identifies a deployment by its pipeline, tasks without a pipeline are deployments on their own
+const deploymentIdExpr = "COALESCE(NULLIF(ct.pipeline_id, ''), ct.id)"
+
+// getProductionDeployments returns all successful production deployments of the project, a deployment is a pipeline
+// finished at its last production deployment task no matter how many of them it has
+func getProductionDeployments(db dal.Dal, projectName string) ([]doraSample, errors.Error) {
+	samples := make([]doraSample, 0)
+	err := db.All(
+		&samples,
+		dal.Select(deploymentIdExpr+" AS id, MAX(ct.finished_date) AS date, 1 AS value"),
+		dal.From("cicd_tasks ct"),
+		dal.Join("left join project_mapping pm on pm.row_id = ct.cicd_scope_id"),
+		dal.Where(
+			`ct.environment = ? and ct.type = ? and ct.result = ? and ct.finished_date IS NOT NULL
+				and pm.project_name = ? and pm.table = ?`,
+			devops.PRODUCTION, devops.DEPLOYMENT, devops.SUCCESS, projectName, "cicd_scopes",
+		),
+		dal.Groupby(deploymentIdExpr),
+	)
+	return samples, err
+}
+
+// getFailedDeploymentIds returns ids of the deployments which caused incidents, the incidents are connected to
+// the deployment tasks by ConnectIncidentToDeployment
+func getFailedDeploymentIds(db dal.Dal, projectName string) (map[string]bool, errors.Error) {
+	var ids []string
+	err := db.Pluck(
+		"DISTINCT "+deploymentIdExpr,
+		&ids,
+		dal.From("cicd_tasks ct"),
+		dal.Join("join project_issue_metrics pim on pim.deployment_id = ct.id"),
+		dal.Where("pim.project_name = ?", projectName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	failed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		failed[id] = true
+	}
+	return failed, nil
+}
+
+// getResolvedIncidents returns all resolved incidents of the project with their created dates and lead time in minutes
+func getResolvedIncidents(db dal.Dal, projectName string) ([]doraSample, errors.Error) {
+	samples := make([]doraSample, 0)
+	err := db.All(
+		&samples,
+		dal.Select("DISTINCT i.id, i.created_date AS date, i.lead_time_minutes AS value"),
+		dal.From("issues i"),
+		dal.Join("left join board_issues bi on bi.issue_id = i.id"),
+		dal.Join("left join project_mapping pm on pm.row_id = bi.board_id"),
+		dal.Where(
+			`i.type = ? and pm.project_name = ? and pm.table = ?
+				and i.resolution_date IS NOT NULL and i.lead_time_minutes IS NOT NULL`,
+			"INCIDENT", projectName, "boards",
+		),
+	)
+	return samples, err
+}
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func date(s string) *time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return &t
+}
+
+func TestGetPeriodStart(t *testing.T) {
+	// 2023-03-08 is a Wednesday
+	d := *date("2023-03-08T13:14:15Z")
+	assert.Equal(t, *date("2023-03-08T00:00:00Z"), getPeriodStart(d, crossdomain.DORA_PERIOD_DAY))
+	assert.Equal(t, *date("2023-03-06T00:00:00Z"), getPeriodStart(d, crossdomain.DORA_PERIOD_WEEK))
+	assert.Equal(t, *date("2023-03-01T00:00:00Z"), getPeriodStart(d, crossdomain.DORA_PERIOD_MONTH))
+	// Sunday belongs to the week started on the previous Monday
+	assert.Equal(t, *date("2023-03-06T00:00:00Z"), getPeriodStart(*date("2023-03-12T23:00:00Z"), crossdomain.DORA_PERIOD_WEEK))
+}
+
+func TestGetMedian(t *testing.T) {
+	assert.Nil(t, getMedian(nil))
+	assert.Equal(t, 3.0, *getMedian([]float64{5, 1, 3}))
+	assert.Equal(t, 2.0, *getMedian([]float64{4, 1, 2, 3}))
+}
+
+func TestCalculateDeploymentFrequencyMetrics(t *testing.T) {
+	deployments := []doraSample{
+		{Date: date("2023-03-06T10:00:00Z"), Value: 1},
+		{Date: date("2023-03-06T11:00:00Z"), Value: 1},
+		{Date: date("2023-03-07T10:00:00Z"), Value: 1},
+		{Date: date("2023-03-09T10:00:00Z"), Value: 1},
+		{Date: date("2023-03-22T10:00:00Z"), Value: 1},
+	}
+	metrics := calculateDeploymentFrequencyMetrics("p", deployments, *date("2023-03-31T00:00:00Z"))
+	byKey := make(map[string]*crossdomain.ProjectDoraMetric)
+	for _, m := range metrics {
+		byKey[m.Period+m.PeriodStart.Format("2006-01-02")] = m
+	}
+	assert.Equal(t, 26+4+1, len(metrics))
+
+	day := byKey["DAY2023-03-06"]
+	assert.Equal(t, 1.0, *day.Value)
+	assert.Equal(t, 2, day.SampleCount)
+	assert.Equal(t, 0.0, *byKey["DAY2023-03-08"].Value)
+
+	assert.Equal(t, crossdomain.DORA_LEVEL_ELITE, byKey["WEEK2023-03-06"].Level)
+	assert.Equal(t, 3.0, *byKey["WEEK2023-03-06"].Value)
+	assert.Equal(t, 4, byKey["WEEK2023-03-06"].SampleCount)
+	assert.Equal(t, crossdomain.DORA_LEVEL_MEDIUM, byKey["WEEK2023-03-13"].Level)
+	assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, byKey["WEEK2023-03-20"].Level)
+
+	// weekly deployment days are 3, 0, 1, 0 and the lower median is 0
+	month := byKey["MONTH2023-03-01"]
+	assert.Equal(t, crossdomain.DORA_LEVEL_MEDIUM, month.Level)
+	assert.Equal(t, 4.0, *month.Value)
+	assert.Equal(t, 5, month.SampleCount)
+}
+
+func TestCalculateChangeFailureRateMetrics(t *testing.T) {
+	deployments := []doraSample{
+		{Id: "pipeline:1", Date: date("2023-03-06T10:00:00Z"), Value: 1},
+		{Id: "pipeline:2", Date: date("2023-03-07T10:00:00Z"), Value: 1},
+		{Id: "pipeline:3", Date: date("2023-03-08T10:00:00Z"), Value: 1},
+		{Id: "pipeline:4", Date: date("2023-03-09T10:00:00Z"), Value: 1},
+		{Id: "pipeline:5", Date: date("2023-03-14T10:00:00Z"), Value: 1},
+	}
+	// several incidents caused by the same deployment count once
+	failed := map[string]bool{"pipeline:2": true, "pipeline:9": true}
+	metrics := calculateChangeFailureRateMetrics("p", deployments, failed)
+	byKey := make(map[string]*crossdomain.ProjectDoraMetric)
+	for _, m := range metrics {
+		byKey[m.Period+m.PeriodStart.Format("2006-01-02")] = m
+	}
+	assert.Equal(t, 1.0, *byKey["DAY2023-03-07"].Value)
+	assert.Equal(t, crossdomain.DORA_LEVEL_LOW, byKey["DAY2023-03-07"].Level)
+	assert.Equal(t, 0.0, *byKey["DAY2023-03-06"].Value)
+	assert.Equal(t, 0.25, *byKey["WEEK2023-03-06"].Value)
+	assert.Equal(t, crossdomain.DORA_LEVEL_MEDIUM, byKey["WEEK2023-03-06"].Level)
+	assert.Equal(t, 0.0, *byKey["WEEK2023-03-13"].Value)
+	assert.Equal(t, 0.2, *byKey["MONTH2023-03-01"].Value)
+	assert.Equal(t, 5, byKey["MONTH2023-03-01"].SampleCount)
+	for _, m := range metrics {
+		assert.LessOrEqual(t, *m.Value, 1.0)
+	}
+}
+
+func TestCalculateMedianMetrics(t *testing.T) {
+	samples := []doraSample{
+		{Date: date("2023-03-06T10:00:00Z"), Value: 30},
+		{Date: date("2023-03-07T10:00:00Z"), Value: 90},
+		{Date: date("2023-03-08T10:00:00Z"), Value: 20 * 24 * 60},
+		{Date: nil, Value: 1},
+	}
+	metrics := calculateMedianMetrics("p", crossdomain.DORA_CHANGE_LEAD_TIME, samples, getLeadTimeLevel)
+	assert.Equal(t, 3+1+1, len(metrics))
+	for _, m := range metrics {
+		if m.Period == crossdomain.DORA_PERIOD_WEEK {
+			assert.Equal(t, 90.0, *m.Value)
+			assert.Equal(t, 3, m.SampleCount)
+			assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, m.Level)
+		}
+	}
+}
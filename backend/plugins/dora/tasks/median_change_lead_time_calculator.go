@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var CalculateMedianChangeLeadTimeMeta = plugin.SubTaskMeta{
+	Name:             "calculateMedianChangeLeadTime",
+	EntryPoint:       CalculateMedianChangeLeadTime,
+	EnabledByDefault: true,
+	Description:      "Calculate daily, weekly and monthly median change lead time of the project",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE},
+}
+
+// CalculateMedianChangeLeadTime rolls the pr_cycle_time calculated by calculateChangeLeadTime up by the merged date
+func CalculateMedianChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	samples := make([]doraSample, 0)
+	err := db.All(
+		&samples,
+		dal.Select("pr.merged_date AS date, prm.pr_cycle_time AS value"),
+		dal.From("pull_requests pr"),
+		dal.Join("join project_pr_metrics prm on prm.id = pr.id"),
+		dal.Where(
+			"pr.merged_date IS NOT NULL and prm.pr_cycle_time IS NOT NULL and prm.project_name = ?",
+			data.Options.ProjectName,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	metrics := calculateMedianMetrics(data.Options.ProjectName, crossdomain.DORA_CHANGE_LEAD_TIME, samples, getLeadTimeLevel)
+	return saveDoraMetrics(taskCtx, crossdomain.DORA_CHANGE_LEAD_TIME, "project_pr_metrics", metrics)
+}
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var CalculateTimeToRestoreServiceMeta = plugin.SubTaskMeta{
+	Name:             "calculateTimeToRestoreService",
+	EntryPoint:       CalculateTimeToRestoreService,
+	EnabledByDefault: true,
+	Description:      "Calculate daily, weekly and monthly median time to restore service of the project",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// CalculateTimeToRestoreService rolls the lead time of resolved incidents up by their created date
+func CalculateTimeToRestoreService(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	incidents, err := getResolvedIncidents(db, data.Options.ProjectName)
+	if err != nil {
+		return err
+	}
+	metrics := calculateMedianMetrics(data.Options.ProjectName, crossdomain.DORA_TIME_TO_RESTORE, incidents, getTimeToRestoreLevel)
+	return saveDoraMetrics(taskCtx, crossdomain.DORA_TIME_TO_RESTORE, "issues", metrics)
+}
@@ -52,6 +52,7 @@ func (p GitExtractor) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectGitBranchMeta,
 		tasks.CollectGitTagMeta,
 		tasks.CollectGitDiffLineMeta,
+		tasks.CollectGitCodeOwnershipMeta,
 	}
 }
 
@@ -99,5 +100,9 @@ func NewGitRepo(logger log.Logger, storage models.Store, op tasks.GitExtractorOp
 	} else {
 		return nil, errors.BadInput.New(fmt.Sprintf("unsupported url [%s]", op.Url))
 	}
-	return repo, err
+	if err != nil {
+		return nil, err
+	}
+	repo.SetChurnDays(op.ChurnDays)
+	return repo, nil
 }
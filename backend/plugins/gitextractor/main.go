@@ -42,6 +42,7 @@ func main() {
 	password := flag.String("password", "", "-password")
 	output := flag.String("output", "", "-output")
 	dbUrl := flag.String("db", "", "-db")
+	churnDays := flag.Int("churnDays", models.DefaultChurnDays, "-churnDays")
 	flag.Parse()
 	cfg := config.GetConfig()
 	logger := logruslog.Global.Nested("git extractor")
@@ -79,11 +80,12 @@ func main() {
 		nil,
 	)
 	repo, err := impl.NewGitRepo(logger, storage, tasks.GitExtractorOptions{
-		RepoId:   *id,
-		Url:      *url,
-		User:     *user,
-		Password: *password,
-		Proxy:    *proxy,
+		RepoId:    *id,
+		Url:       *url,
+		User:      *user,
+		Password:  *password,
+		Proxy:     *proxy,
+		ChurnDays: *churnDays,
 	})
 	if err != nil {
 		panic(err)
@@ -31,5 +31,7 @@ type Store interface {
 	CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error
 	CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error
 	RepoSnapshot(snapshot *code.RepoSnapshot) errors.Error
+	CodeOwnership(ownership *code.CodeOwnership) errors.Error
+	CodeOwnershipMetric(metric *code.CodeOwnershipMetric) errors.Error
 	Close() errors.Error
 }
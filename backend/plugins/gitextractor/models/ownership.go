@@ -0,0 +1,243 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"math"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+)
+
+// DefaultChurnDays is the period after being authored in which rewriting a line is counted as churn
+const DefaultChurnDays = 21
+
+// hotspotRatio is the share of the files with the highest hotspot scores marked as hotspots
+const hotspotRatio = 0.1
+
+type CommitAuthor struct {
+	Name  string
+	Email string
+	When  time.Time
+}
+
+// OwnershipCalculator derives the code ownership from the blame snapshot of the files, and the churn from the lines
+// rewritten while walking through the commits
+type OwnershipCalculator struct {
+	repoId    string
+	churnDays int
+	authors   map[string] /*commit sha*/ *CommitAuthor
+	churns    map[string] /*file path*/ int
+}
+
+func NewOwnershipCalculator(repoId string, churnDays int) *OwnershipCalculator {
+	if churnDays <= 0 {
+		churnDays = DefaultChurnDays
+	}
+	return &OwnershipCalculator{
+		repoId:    repoId,
+		churnDays: churnDays,
+		authors:   make(map[string]*CommitAuthor),
+		churns:    make(map[string]int),
+	}
+}
+
+// AddCommit records the author of a commit, commits must be added before the lines they rewrite
+func (c *OwnershipCalculator) AddCommit(commitSha string, author *CommitAuthor) {
+	c.authors[commitSha] = author
+}
+
+// AddRewrite records a line of the file authored in prevCommitSha being deleted or modified in commitSha
+func (c *OwnershipCalculator) AddRewrite(filePath, prevCommitSha, commitSha string) {
+	prev, ok := c.authors[prevCommitSha]
+	if !ok {
+		return
+	}
+	current, ok := c.authors[commitSha]
+	if !ok {
+		return
+	}
+	if current.When.Sub(prev.When) <= time.Duration(c.churnDays)*24*time.Hour {
+		c.churns[filePath]++
+	}
+}
+
+// Rename moves the churn of the file to its new path, so the history is kept after renaming
+func (c *OwnershipCalculator) Rename(oldPath, newPath string) {
+	if churn, ok := c.churns[oldPath]; ok {
+		c.churns[newPath] += churn
+		delete(c.churns, oldPath)
+	}
+}
+
+type pathOwnership struct {
+	pathType  string
+	fileCount int
+	lines     int
+	churn     int
+	owners    map[string] /*email*/ *code.CodeOwnership
+}
+
+func (p *pathOwnership) addLines(repoId, filePath, email, name string, lines int) {
+	owner, ok := p.owners[email]
+	if !ok {
+		owner = &code.CodeOwnership{
+			RepoId:      repoId,
+			Path:        filePath,
+			AuthorEmail: email,
+			PathType:    p.pathType,
+			AuthorName:  name,
+		}
+		p.owners[email] = owner
+	}
+	owner.Lines += lines
+	p.lines += lines
+}
+
+// Calculate returns the owners and the metrics of all the files in the snapshot and their directories
+func (c *OwnershipCalculator) Calculate(snapshot map[string] /*file path*/ *FileBlame) ([]*code.CodeOwnership, []*code.CodeOwnershipMetric) {
+	paths := make(map[string]*pathOwnership)
+	getPath := func(p, pathType string) *pathOwnership {
+		if _, ok := paths[p]; !ok {
+			paths[p] = &pathOwnership{pathType: pathType, owners: make(map[string]*code.CodeOwnership)}
+		}
+		return paths[p]
+	}
+	for filePath, fileBlame := range snapshot {
+		// the lines of each commit in the file
+		commitLines := make(map[string]int)
+		for e := fileBlame.Lines.Front(); e != nil; e = e.Next() {
+			if commitSha, ok := e.Value.(string); ok {
+				commitLines[commitSha]++
+			}
+		}
+		if len(commitLines) == 0 {
+			// the file was deleted
+			continue
+		}
+		// the file and all of its parent directories
+		ancestors := []*pathOwnership{getPath(filePath, code.PATH_TYPE_FILE)}
+		ancestorPaths := []string{filePath}
+		for dir := path.Dir(filePath); ; dir = path.Dir(dir) {
+			ancestors = append(ancestors, getPath(dir, code.PATH_TYPE_DIRECTORY))
+			ancestorPaths = append(ancestorPaths, dir)
+			if dir == "." || dir == "/" {
+				break
+			}
+		}
+		for i, ancestor := range ancestors {
+			ancestor.fileCount++
+			ancestor.churn += c.churns[filePath]
+			for commitSha, lines := range commitLines {
+				author := c.authors[commitSha]
+				if author == nil {
+					author = &CommitAuthor{}
+				}
+				ancestor.addLines(c.repoId, ancestorPaths[i], author.Email, author.Name, lines)
+			}
+		}
+	}
+
+	var owners []*code.CodeOwnership
+	var metrics []*code.CodeOwnershipMetric
+	maxLines, maxChurn := 0, 0
+	for p, ownership := range paths {
+		metric := &code.CodeOwnershipMetric{
+			RepoId:     c.repoId,
+			Path:       p,
+			PathType:   ownership.pathType,
+			Lines:      ownership.lines,
+			FileCount:  ownership.fileCount,
+			OwnerCount: len(ownership.owners),
+			ChurnLines: ownership.churn,
+		}
+		pathOwners := make([]*code.CodeOwnership, 0, len(ownership.owners))
+		for _, owner := range ownership.owners {
+			owner.Ratio = float64(owner.Lines) / float64(ownership.lines)
+			pathOwners = append(pathOwners, owner)
+		}
+		sortOwners(pathOwners)
+		metric.BusFactor = getBusFactor(pathOwners, ownership.lines)
+		metric.PrimaryOwnerEmail = pathOwners[0].AuthorEmail
+		metric.PrimaryOwnerRatio = pathOwners[0].Ratio
+		if metric.PathType == code.PATH_TYPE_FILE {
+			if metric.Lines > maxLines {
+				maxLines = metric.Lines
+			}
+			if metric.ChurnLines > maxChurn {
+				maxChurn = metric.ChurnLines
+			}
+		}
+		owners = append(owners, pathOwners...)
+		metrics = append(metrics, metric)
+	}
+	markHotspots(metrics, maxLines, maxChurn)
+	return owners, metrics
+}
+
+// sortOwners sorts the owners by their lines in descending order, the email breaks ties to keep the result stable
+func sortOwners(owners []*code.CodeOwnership) {
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].Lines != owners[j].Lines {
+			return owners[i].Lines > owners[j].Lines
+		}
+		return owners[i].AuthorEmail < owners[j].AuthorEmail
+	})
+}
+
+// getBusFactor returns the least number of owners who own more than half of the lines, owners must be sorted
+func getBusFactor(owners []*code.CodeOwnership, lines int) int {
+	owned := 0
+	for i, owner := range owners {
+		owned += owner.Lines
+		if owned*2 > lines {
+			return i + 1
+		}
+	}
+	return len(owners)
+}
+
+// markHotspots scores the files by the product of their normalized churn and size, then marks the top hotspotRatio
+// of the files with a positive score as hotspots
+func markHotspots(metrics []*code.CodeOwnershipMetric, maxLines, maxChurn int) {
+	if maxLines == 0 || maxChurn == 0 {
+		return
+	}
+	var files []*code.CodeOwnershipMetric
+	for _, metric := range metrics {
+		if metric.PathType != code.PATH_TYPE_FILE {
+			continue
+		}
+		metric.HotspotScore = float64(metric.ChurnLines) / float64(maxChurn) * float64(metric.Lines) / float64(maxLines)
+		files = append(files, metric)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].HotspotScore != files[j].HotspotScore {
+			return files[i].HotspotScore > files[j].HotspotScore
+		}
+		return files[i].Path < files[j].Path
+	})
+	count := int(math.Ceil(float64(len(files)) * hotspotRatio))
+	for _, file := range files[:count] {
+		if file.HotspotScore > 0 {
+			file.IsHotspot = true
+		}
+	}
+}
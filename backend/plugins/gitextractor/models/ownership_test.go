@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFileBlame(t *testing.T, commits ...string) *FileBlame {
+	fileBlame, err := NewFileBlame()
+	assert.Nil(t, err)
+	for i, commit := range commits {
+		fileBlame.AddLine(i+1, commit)
+	}
+	return fileBlame
+}
+
+func TestOwnershipCalculator(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	calculator := NewOwnershipCalculator("repo", 0)
+	calculator.AddCommit("c1", &CommitAuthor{Name: "alice", Email: "alice@example.com", When: start})
+	calculator.AddCommit("c2", &CommitAuthor{Name: "bob", Email: "bob@example.com", When: start.Add(10 * day)})
+	calculator.AddCommit("c3", &CommitAuthor{Name: "carol", Email: "carol@example.com", When: start.Add(40 * day)})
+	// rewritten 10 days after being authored
+	calculator.AddRewrite("src/a.go", "c1", "c2")
+	calculator.AddRewrite("src/a.go", "c1", "c2")
+	// rewritten 30 days after being authored, which is not churn
+	calculator.AddRewrite("src/b.go", "c2", "c3")
+
+	owners, metrics := calculator.Calculate(map[string]*FileBlame{
+		"src/a.go":   newTestFileBlame(t, "c1", "c1", "c2", "c2", "c2", "c3"),
+		"src/b.go":   newTestFileBlame(t, "c3", "c3"),
+		"README.md":  newTestFileBlame(t, "c1"),
+		"deleted.go": newTestFileBlame(t),
+	})
+
+	metricsByPath := make(map[string]*code.CodeOwnershipMetric)
+	for _, metric := range metrics {
+		metricsByPath[metric.Path] = metric
+	}
+	assert.Len(t, metricsByPath, 5)
+
+	a := metricsByPath["src/a.go"]
+	assert.Equal(t, code.PATH_TYPE_FILE, a.PathType)
+	assert.Equal(t, 6, a.Lines)
+	assert.Equal(t, 3, a.OwnerCount)
+	assert.Equal(t, 2, a.BusFactor)
+	assert.Equal(t, "bob@example.com", a.PrimaryOwnerEmail)
+	assert.Equal(t, 0.5, a.PrimaryOwnerRatio)
+	assert.Equal(t, 2, a.ChurnLines)
+	assert.Equal(t, 1.0, a.HotspotScore)
+	assert.True(t, a.IsHotspot)
+
+	b := metricsByPath["src/b.go"]
+	assert.Equal(t, 1, b.BusFactor)
+	assert.Equal(t, 0, b.ChurnLines)
+	assert.False(t, b.IsHotspot)
+
+	src := metricsByPath["src"]
+	assert.Equal(t, code.PATH_TYPE_DIRECTORY, src.PathType)
+	assert.Equal(t, 8, src.Lines)
+	assert.Equal(t, 2, src.FileCount)
+	// bob and carol own 3 lines each, the tie is broken by the email
+	assert.Equal(t, "bob@example.com", src.PrimaryOwnerEmail)
+	assert.Equal(t, 2, src.ChurnLines)
+	assert.False(t, src.IsHotspot)
+
+	root := metricsByPath["."]
+	assert.Equal(t, 9, root.Lines)
+	assert.Equal(t, 3, root.FileCount)
+	assert.Equal(t, 2, root.BusFactor)
+
+	var readmeOwners []*code.CodeOwnership
+	for _, owner := range owners {
+		if owner.Path == "README.md" {
+			readmeOwners = append(readmeOwners, owner)
+		}
+	}
+	assert.Equal(t, []*code.CodeOwnership{{
+		RepoId:      "repo",
+		Path:        "README.md",
+		AuthorEmail: "alice@example.com",
+		PathType:    code.PATH_TYPE_FILE,
+		AuthorName:  "alice",
+		Lines:       1,
+		Ratio:       1,
+	}}, readmeOwners)
+}
+
+func TestOwnershipCalculatorRename(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	calculator := NewOwnershipCalculator("repo", 0)
+	calculator.AddCommit("c1", &CommitAuthor{Name: "alice", Email: "alice@example.com", When: start})
+	calculator.AddCommit("c2", &CommitAuthor{Name: "bob", Email: "bob@example.com", When: start.Add(time.Hour)})
+	calculator.AddRewrite("old.go", "c1", "c2")
+	calculator.Rename("old.go", "new.go")
+	calculator.AddRewrite("new.go", "c1", "c2")
+
+	_, metrics := calculator.Calculate(map[string]*FileBlame{
+		"new.go": newTestFileBlame(t, "c1", "c2"),
+	})
+	for _, metric := range metrics {
+		assert.NotEqual(t, "old.go", metric.Path)
+		if metric.Path == "new.go" {
+			assert.Equal(t, 2, metric.ChurnLines)
+		}
+	}
+}
+
+func TestGetBusFactor(t *testing.T) {
+	owners := []*code.CodeOwnership{{Lines: 5}, {Lines: 3}, {Lines: 2}}
+	assert.Equal(t, 2, getBusFactor(owners, 10))
+	assert.Equal(t, 1, getBusFactor(owners[:1], 5))
+}
@@ -39,11 +39,17 @@ import (
 var TypeNotMatchError = "the requested type does not match the type in the ODB"
 
 type GitRepo struct {
-	store   models.Store
-	logger  log.Logger
-	id      string
-	repo    *git.Repository
-	cleanup func()
+	store     models.Store
+	logger    log.Logger
+	id        string
+	repo      *git.Repository
+	cleanup   func()
+	churnDays int
+}
+
+// SetChurnDays sets the period after being authored in which rewriting a line is counted as churn
+func (r *GitRepo) SetChurnDays(churnDays int) {
+	r.churnDays = churnDays
 }
 
 // CollectAll The main parser subtask
@@ -406,9 +412,20 @@ func (r *GitRepo) storeCommitFilesFromDiff(commitSha string, diff *git.Diff, com
 	return errors.Convert(err)
 }
 
-// CollectDiffLine get line diff data from a specific branch
-func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error {
-	//Using this subtask,we can get every line change in every commit.
+// blameHandlers are called back while walking through the commits of the current branch
+type blameHandlers struct {
+	// commit is called before the lines changed by the commit
+	commit func(commit *git.Commit)
+	// rename is called when a file was renamed, its snapshot would be moved to the new path. Renames are only
+	// detected when it is set, a renamed file is seen as deleted and added otherwise
+	rename func(oldPath, newPath string)
+	// line is called for every line changed, prevCommitSha is the commit which authored the deleted line
+	line func(commit *git.Commit, file git.DiffDelta, hunkNum int, line git.DiffLine, prevCommitSha string) errors.Error
+}
+
+// blame walks through the first parents of the head commit from the very first one, and returns the snapshot of the
+// commit authoring each line of the files in the end, renamed files keep their snapshot if handlers.rename is set
+func (r *GitRepo) blame(handlers *blameHandlers) (map[string] /*file path*/ *models.FileBlame, errors.Error) {
 	//We maintain a snapshot structure to get which commit each deleted line belongs to
 	snapshot := make(map[string] /*file path*/ *models.FileBlame)
 	repo := r.repo
@@ -418,12 +435,12 @@ func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error
 	// check branch, if not master, checkout to branch's head
 	commitOid, err1 := repo.Head()
 	if err1 != nil && err1.Error() != TypeNotMatchError {
-		return errors.Convert(err1)
+		return nil, errors.Convert(err1)
 	}
 	//get head commit object and add into commitList
 	commit, err1 := repo.LookupCommit(commitOid.Target())
 	if err1 != nil && err1.Error() != TypeNotMatchError {
-		return errors.Convert(err1)
+		return nil, errors.Convert(err1)
 	}
 	commitList = append(commitList, *commit)
 	// if current head has parents, get parent commitsha
@@ -431,7 +448,7 @@ func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error
 		pid := commit.ParentId(0)
 		commit, err1 = repo.LookupCommit(pid)
 		if err1 != nil && err1.Error() != TypeNotMatchError {
-			return errors.Convert(err1)
+			return nil, errors.Convert(err1)
 		}
 		commitList = append(commitList, *commit)
 	}
@@ -444,13 +461,16 @@ func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error
 	for _, commitsha := range commitList {
 		curcommit, err := repo.LookupCommit(commitsha.Id())
 		if err != nil {
-			return errors.Convert(err)
+			return nil, errors.Convert(err)
+		}
+		if handlers.commit != nil {
+			handlers.commit(curcommit)
 		}
 		if curcommit.ParentCount() == 0 || curcommit.ParentCount() > 0 {
 			var parentTree, tree *git.Tree
 			tree, err = curcommit.Tree()
 			if err != nil {
-				return errors.Convert(err)
+				return nil, errors.Convert(err)
 			}
 			var diff *git.Diff
 			//FIXME error type convert
@@ -459,7 +479,7 @@ func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error
 				return nil
 			}
 			if err != nil {
-				return errors.Convert(err)
+				return nil, errors.Convert(err)
 			}
 			if curcommit.ParentCount() > 0 {
 				parent := curcommit.Parent(0)
@@ -467,21 +487,41 @@ func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error
 			}
 			diff, err = repo.DiffTreeToTree(parentTree, tree, &opts)
 			if err != nil {
-				return errors.Convert(err)
+				return nil, errors.Convert(err)
+			}
+			if handlers.rename != nil {
+				// detect renames, or the renamed files would lose their history as being deleted and added
+				findOpts, err := git.DefaultDiffFindOptions()
+				if err != nil {
+					return nil, errors.Convert(err)
+				}
+				findOpts.Flags = git.DiffFindRenames
+				err = diff.FindSimilar(&findOpts)
+				if err != nil {
+					return nil, errors.Convert(err)
+				}
 			}
 			deleted := make(models.DiffLines, 0)
 			added := make(models.DiffLines, 0)
 			var lastFile string
 			lastFile = ""
 			err = diff.ForEach(func(file git.DiffDelta, progress float64) (git.DiffForEachHunkCallback, error) {
+				// the snapshot of the renamed file is moved to the new path before applying the changes
+				if file.Status == git.DeltaRenamed && file.OldFile.Path != file.NewFile.Path {
+					if fileBlame, ok := snapshot[file.OldFile.Path]; ok {
+						snapshot[file.NewFile.Path] = fileBlame
+						delete(snapshot, file.OldFile.Path)
+					}
+					handlers.rename(file.OldFile.Path, file.NewFile.Path)
+				}
 				//if doesn't exist in snapshot, create a new one
-				if _, ok := snapshot[file.OldFile.Path]; !ok {
+				if _, ok := snapshot[file.NewFile.Path]; !ok {
 					fileBlame, err := models.NewFileBlame()
 					if err != nil {
 						r.logger.Info("Create FileBlame Error")
 						return nil, err
 					}
-					snapshot[file.OldFile.Path] = (*models.FileBlame)(fileBlame)
+					snapshot[file.NewFile.Path] = (*models.FileBlame)(fileBlame)
 				}
 				if lastFile == "" {
 					lastFile = file.NewFile.Path
@@ -496,46 +536,64 @@ func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error
 				return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
 					hunkNum++
 					return func(line git.DiffLine) error {
-						commitLineChange := &code.CommitLineChange{}
-						commitLineChange.CommitSha = curcommit.Id().String()
-						commitLineChange.ChangedType = line.Origin.String()
-						commitLineChange.LineNoNew = line.NewLineno
-						commitLineChange.LineNoOld = line.OldLineno
-						commitLineChange.OldFilePath = file.OldFile.Path
-						commitLineChange.NewFilePath = file.NewFile.Path
-						commitLineChange.HunkNum = hunkNum
-						commitLineChange.Id = curcommit.Id().String() + ":" + file.NewFile.Path + ":" + strconv.Itoa(line.OldLineno) + ":" + strconv.Itoa(line.NewLineno)
+						prevCommitSha := ""
 						if line.Origin == git.DiffLineAddition {
 							added = append(added, line)
 						} else if line.Origin == git.DiffLineDeletion {
-							fb := snapshot[file.OldFile.Path]
-							l := fb.Find(line.OldLineno)
+							l := snapshot[file.NewFile.Path].Find(line.OldLineno)
 							if l != nil && l.Value != nil {
-								temp := snapshot[file.OldFile.Path].Find(line.OldLineno)
-								commitLineChange.PrevCommit = temp.Value.(string)
+								prevCommitSha = l.Value.(string)
 							} else {
 								r.logger.Info("err", file.OldFile.Path, line.OldLineno, curcommit.Id().String())
 							}
 							deleted = append(deleted, line)
 						}
-						err = r.store.CommitLineChange(commitLineChange)
-						if err != nil {
-							return errors.Convert(err)
+						if handlers.line != nil {
+							err := handlers.line(curcommit, file, hunkNum, line, prevCommitSha)
+							if err != nil {
+								return err
+							}
 						}
 						return nil
 					}, nil
 				}, nil
 			}, git.DiffDetailLines)
 			if err != nil {
-				return errors.Convert(err)
+				return nil, errors.Convert(err)
 			}
 			//finally,process the last file in diff
-			updateSnapshotFileBlame(curcommit, deleted, added, lastFile, snapshot)
+			if lastFile != "" {
+				updateSnapshotFileBlame(curcommit, deleted, added, lastFile, snapshot)
+			}
 		}
 	}
+	return snapshot, nil
+}
+
+// CollectDiffLine get line diff data from a specific branch
+func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error {
+	//Using this subtask,we can get every line change in every commit.
+	snapshot, err := r.blame(&blameHandlers{
+		line: func(commit *git.Commit, file git.DiffDelta, hunkNum int, line git.DiffLine, prevCommitSha string) errors.Error {
+			commitLineChange := &code.CommitLineChange{}
+			commitLineChange.CommitSha = commit.Id().String()
+			commitLineChange.ChangedType = line.Origin.String()
+			commitLineChange.LineNoNew = line.NewLineno
+			commitLineChange.LineNoOld = line.OldLineno
+			commitLineChange.OldFilePath = file.OldFile.Path
+			commitLineChange.NewFilePath = file.NewFile.Path
+			commitLineChange.HunkNum = hunkNum
+			commitLineChange.PrevCommit = prevCommitSha
+			commitLineChange.Id = commit.Id().String() + ":" + file.NewFile.Path + ":" + strconv.Itoa(line.OldLineno) + ":" + strconv.Itoa(line.NewLineno)
+			return r.store.CommitLineChange(commitLineChange)
+		},
+	})
+	if err != nil {
+		return err
+	}
 	r.logger.Info("line change collect success")
 	db := subtaskCtx.GetDal()
-	err := db.Delete(&code.RepoSnapshot{}, dal.Where("repo_id= ?", r.id))
+	err = db.Delete(&code.RepoSnapshot{}, dal.Where("repo_id= ?", r.id))
 	if err != nil {
 		return errors.Convert(err)
 	}
@@ -562,6 +620,56 @@ func (r *GitRepo) CollectDiffLine(subtaskCtx plugin.SubTaskContext) errors.Error
 	return nil
 }
 
+// CollectCodeOwnership derives the code ownership from the snapshot of the current branch, and the churn from the
+// lines rewritten in each commit
+func (r *GitRepo) CollectCodeOwnership(subtaskCtx plugin.SubTaskContext) errors.Error {
+	ownership := models.NewOwnershipCalculator(r.id, r.churnDays)
+	snapshot, err := r.blame(&blameHandlers{
+		commit: func(commit *git.Commit) {
+			author := commit.Author()
+			ownership.AddCommit(commit.Id().String(), &models.CommitAuthor{
+				Name:  author.Name,
+				Email: author.Email,
+				When:  author.When,
+			})
+		},
+		rename: ownership.Rename,
+		line: func(commit *git.Commit, file git.DiffDelta, hunkNum int, line git.DiffLine, prevCommitSha string) errors.Error {
+			if prevCommitSha != "" {
+				ownership.AddRewrite(file.NewFile.Path, prevCommitSha, commit.Id().String())
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	db := subtaskCtx.GetDal()
+	err = db.Delete(&code.CodeOwnership{}, dal.Where("repo_id = ?", r.id))
+	if err != nil {
+		return err
+	}
+	err = db.Delete(&code.CodeOwnershipMetric{}, dal.Where("repo_id = ?", r.id))
+	if err != nil {
+		return err
+	}
+	owners, metrics := ownership.Calculate(snapshot)
+	for _, owner := range owners {
+		err = r.store.CodeOwnership(owner)
+		if err != nil {
+			return err
+		}
+	}
+	for _, metric := range metrics {
+		err = r.store.CodeOwnershipMetric(metric)
+		if err != nil {
+			return err
+		}
+	}
+	r.logger.Info("collect code ownership finished")
+	return nil
+}
+
 func updateSnapshotFileBlame(currentCommit *git.Commit, deleted models.DiffLines, added models.DiffLines, lastFile string, snapshot map[string]*models.FileBlame) {
 	sort.Sort(deleted)
 	for _, line := range deleted {
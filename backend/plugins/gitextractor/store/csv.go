@@ -86,6 +86,8 @@ type CsvStore struct {
 	commitFileComponentWriter *csvWriter
 	commitLineChangeWriter    *csvWriter
 	snapshotWriter            *csvWriter
+	ownershipWriter           *csvWriter
+	ownershipMetricWriter     *csvWriter
 }
 
 func NewCsvStore(dir string) (*CsvStore, errors.Error) {
@@ -129,6 +131,14 @@ func NewCsvStore(dir string) (*CsvStore, errors.Error) {
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
+	s.ownershipWriter, err = newCsvWriter(filepath.Join(dir, "code_ownerships.csv"), code.CodeOwnership{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	s.ownershipMetricWriter, err = newCsvWriter(filepath.Join(dir, "code_ownership_metrics.csv"), code.CodeOwnershipMetric{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
 	return s, nil
 }
 
@@ -160,6 +170,14 @@ func (c *CsvStore) RepoSnapshot(ss *code.RepoSnapshot) errors.Error {
 	return c.snapshotWriter.Write(ss)
 }
 
+func (c *CsvStore) CodeOwnership(ownership *code.CodeOwnership) errors.Error {
+	return c.ownershipWriter.Write(ownership)
+}
+
+func (c *CsvStore) CodeOwnershipMetric(metric *code.CodeOwnershipMetric) errors.Error {
+	return c.ownershipMetricWriter.Write(metric)
+}
+
 func (c *CsvStore) CommitParents(pp []*code.CommitParent) errors.Error {
 	var err error
 	for _, p := range pp {
@@ -190,5 +208,11 @@ func (c *CsvStore) Close() errors.Error {
 	if c.snapshotWriter != nil {
 		c.snapshotWriter.Close()
 	}
+	if c.ownershipWriter != nil {
+		c.ownershipWriter.Close()
+	}
+	if c.ownershipMetricWriter != nil {
+		c.ownershipMetricWriter.Close()
+	}
 	return nil
 }
@@ -124,6 +124,24 @@ func (d *Database) RepoSnapshot(snapshotElement *code.RepoSnapshot) errors.Error
 	return batch.Add(snapshotElement)
 }
 
+func (d *Database) CodeOwnership(ownership *code.CodeOwnership) errors.Error {
+	batch, err := d.driver.ForType(reflect.TypeOf(ownership))
+	if err != nil {
+		return err
+	}
+	d.updateRawDataFields(&ownership.RawDataOrigin)
+	return batch.Add(ownership)
+}
+
+func (d *Database) CodeOwnershipMetric(metric *code.CodeOwnershipMetric) errors.Error {
+	batch, err := d.driver.ForType(reflect.TypeOf(metric))
+	if err != nil {
+		return err
+	}
+	d.updateRawDataFields(&metric.RawDataOrigin)
+	return batch.Add(metric)
+}
+
 func (d *Database) CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error {
 	batch, err := d.driver.ForType(reflect.TypeOf(commitLineChange))
 	if err != nil {
@@ -32,6 +32,8 @@ type GitExtractorOptions struct {
 	PrivateKey string `json:"privateKey"`
 	Passphrase string `json:"passphrase"`
 	Proxy      string `json:"proxy"`
+	// ChurnDays is the period after being authored in which rewriting a line is counted as churn
+	ChurnDays int `json:"churnDays"`
 }
 
 func (o GitExtractorOptions) Valid() errors.Error {
@@ -96,6 +98,11 @@ func CollectGitDiffLines(subTaskCtx plugin.SubTaskContext) errors.Error {
 	return repo.CollectDiffLine(subTaskCtx)
 }
 
+func CollectGitCodeOwnership(subTaskCtx plugin.SubTaskContext) errors.Error {
+	repo := getGitRepo(subTaskCtx)
+	return repo.CollectCodeOwnership(subTaskCtx)
+}
+
 func getGitRepo(subTaskCtx plugin.SubTaskContext) *parser.GitRepo {
 	repo, ok := subTaskCtx.GetData().(*parser.GitRepo)
 	if !ok {
@@ -132,6 +139,14 @@ var CollectGitDiffLineMeta = plugin.SubTaskMeta{
 	Name:             "collectDiffLine",
 	EntryPoint:       CollectGitDiffLines,
 	EnabledByDefault: false,
-	Description:      "collect git commit diff line into Domain Layer Tables",
+	Description:      "collect git commit diff line and repo snapshot into Domain Layer Tables",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+var CollectGitCodeOwnershipMeta = plugin.SubTaskMeta{
+	Name:             "collectCodeOwnership",
+	EntryPoint:       CollectGitCodeOwnership,
+	EnabledByDefault: false,
+	Description:      "collect code ownership, bus factor, churn and hotspots of the current branch into Domain Layer Tables, it walks through the whole history of the branch",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 }
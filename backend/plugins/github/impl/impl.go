@@ -66,6 +66,8 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubAccountOrg{},
 		&models.GithubCommit{},
 		&models.GithubCommitStat{},
+		&models.GithubDeployment{},
+		&models.GithubDeploymentStatus{},
 		&models.GithubIssue{},
 		&models.GithubIssueComment{},
 		&models.GithubIssueEvent{},
@@ -78,6 +80,7 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubPrLabel{},
 		&models.GithubPrReview{},
 		&models.GithubPullRequest{},
+		&models.GithubRelease{},
 		&models.GithubRepo{},
 		&models.GithubRepoAccount{},
 		&models.GithubRepoCommit{},
@@ -122,6 +125,13 @@ func (p Github) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectJobsMeta,
 		tasks.ExtractJobsMeta,
 		tasks.ConvertJobsMeta,
+		tasks.CollectDeploymentsMeta,
+		tasks.ExtractDeploymentsMeta,
+		tasks.CollectDeploymentStatusesMeta,
+		tasks.ExtractDeploymentStatusesMeta,
+		tasks.ConvertDeploymentsMeta,
+		tasks.CollectReleasesMeta,
+		tasks.ExtractReleasesMeta,
 		tasks.EnrichPullRequestIssuesMeta,
 		tasks.ConvertRepoMeta,
 		tasks.ConvertIssuesMeta,
@@ -135,6 +145,7 @@ func (p Github) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertIssueCommentsMeta,
 		tasks.ConvertPullRequestCommentsMeta,
 		tasks.ConvertMilestonesMeta,
+		tasks.ConvertReleasesMeta,
 		tasks.ConvertAccountsMeta,
 	}
 }
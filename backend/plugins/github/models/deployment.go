@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"time"
+)
+
+// GithubDeployment is a deployment created by the Deployments API, i.e. by GitHub Actions jobs with an environment
+type GithubDeployment struct {
+	common.NoPKModel
+	ConnectionId          uint64    `gorm:"primaryKey"`
+	ID                    int64     `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	RepoId                int       `gorm:"index"`
+	NodeId                string    `gorm:"type:varchar(255)"`
+	Sha                   string    `gorm:"type:varchar(255)"`
+	Ref                   string    `gorm:"type:varchar(255)"`
+	Task                  string    `gorm:"type:varchar(255)"`
+	Environment           string    `gorm:"type:varchar(255)"`
+	OriginalEnvironment   string    `gorm:"type:varchar(255)"`
+	Description           string    `gorm:"type:text"`
+	ProductionEnvironment bool      `json:"production_environment"`
+	TransientEnvironment  bool      `json:"transient_environment"`
+	CreatorId             int       `json:"creator_id"`
+	GithubCreatedAt       time.Time `json:"created_at"`
+	GithubUpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (GithubDeployment) TableName() string {
+	return "_tool_github_deployments"
+}
+
+// GithubDeploymentStatus is a status of a deployment, the latest one tells the state of the deployment
+type GithubDeploymentStatus struct {
+	common.NoPKModel
+	ConnectionId    uint64    `gorm:"primaryKey"`
+	ID              int64     `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	DeploymentId    int64     `gorm:"index"`
+	RepoId          int       `gorm:"index"`
+	State           string    `gorm:"type:varchar(50)"`
+	Environment     string    `gorm:"type:varchar(255)"`
+	EnvironmentUrl  string    `gorm:"type:varchar(255)"`
+	LogUrl          string    `gorm:"type:varchar(255)"`
+	Description     string    `gorm:"type:text"`
+	GithubCreatedAt time.Time `json:"created_at"`
+	GithubUpdatedAt time.Time `json:"updated_at"`
+}
+
+func (GithubDeploymentStatus) TableName() string {
+	return "_tool_github_deployment_statuses"
+}
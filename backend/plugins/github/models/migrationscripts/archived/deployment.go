@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"time"
+)
+
+type GithubDeployment struct {
+	archived.NoPKModel
+	ConnectionId          uint64 `gorm:"primaryKey"`
+	ID                    int64  `gorm:"primaryKey;autoIncrement:false"`
+	RepoId                int    `gorm:"index"`
+	NodeId                string `gorm:"type:varchar(255)"`
+	Sha                   string `gorm:"type:varchar(255)"`
+	Ref                   string `gorm:"type:varchar(255)"`
+	Task                  string `gorm:"type:varchar(255)"`
+	Environment           string `gorm:"type:varchar(255)"`
+	OriginalEnvironment   string `gorm:"type:varchar(255)"`
+	Description           string `gorm:"type:text"`
+	ProductionEnvironment bool
+	TransientEnvironment  bool
+	CreatorId             int
+	GithubCreatedAt       time.Time
+	GithubUpdatedAt       time.Time
+}
+
+func (GithubDeployment) TableName() string {
+	return "_tool_github_deployments"
+}
+
+type GithubDeploymentStatus struct {
+	archived.NoPKModel
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ID              int64  `gorm:"primaryKey;autoIncrement:false"`
+	DeploymentId    int64  `gorm:"index"`
+	RepoId          int    `gorm:"index"`
+	State           string `gorm:"type:varchar(50)"`
+	Environment     string `gorm:"type:varchar(255)"`
+	EnvironmentUrl  string `gorm:"type:varchar(255)"`
+	LogUrl          string `gorm:"type:varchar(255)"`
+	Description     string `gorm:"type:text"`
+	GithubCreatedAt time.Time
+	GithubUpdatedAt time.Time
+}
+
+func (GithubDeploymentStatus) TableName() string {
+	return "_tool_github_deployment_statuses"
+}
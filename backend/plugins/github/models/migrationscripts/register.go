@@ -34,5 +34,7 @@ func All() []plugin.MigrationScript {
 		new(addTransformationRule20221124),
 		new(concatOwnerAndName),
 		new(addStdTypeToIssue221230),
+		new(addDeploymentTables),
+		new(addReleaseTable),
 	}
 }
@@ -0,0 +1,209 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_deployments into domain layer table cicd_pipelines and cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	repoId := data.Options.GithubId
+	productionPattern := data.Options.ProductionPattern
+	regexEnricher := api.NewRegexEnricher()
+	err := regexEnricher.AddRegexp(productionPattern)
+	if err != nil {
+		return err
+	}
+
+	// statuses of all deployments of the repo are loaded at once rather than by each deployment
+	statusesByDeployment, err := getDeploymentStatuses(db, data.Options.ConnectionId, repoId)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubDeployment{}),
+		dal.Where("repo_id = ? and connection_id=?", repoId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.GithubDeployment{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_DEPLOYMENT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubDeployment{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployment := inputRow.(*models.GithubDeployment)
+			statuses := statusesByDeployment[deployment.ID]
+
+			deploymentId := deploymentIdGen.Generate(deployment.ConnectionId, deployment.ID)
+			scopeId := repoIdGen.Generate(deployment.ConnectionId, deployment.RepoId)
+			result, status, startedDate, finishedDate := getDeploymentState(deployment, statuses)
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         deployment.Environment,
+				Result:       result,
+				Status:       status,
+				Type:         devops.DEPLOYMENT,
+				Environment:  getDeploymentEnvironment(regexEnricher, productionPattern, deployment),
+				CreatedDate:  deployment.GithubCreatedAt,
+				FinishedDate: finishedDate,
+				CicdScopeId:  scopeId,
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         deployment.Environment,
+				PipelineId:   deploymentId,
+				Result:       result,
+				Status:       status,
+				Type:         devops.DEPLOYMENT,
+				Environment:  domainPipeline.Environment,
+				StartedDate:  startedDate,
+				FinishedDate: finishedDate,
+				CicdScopeId:  scopeId,
+			}
+			if finishedDate != nil {
+				domainPipeline.DurationSec = uint64(finishedDate.Sub(deployment.GithubCreatedAt).Seconds())
+				domainTask.DurationSec = uint64(finishedDate.Sub(startedDate).Seconds())
+			}
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId: deploymentId,
+				CommitSha:  deployment.Sha,
+				Branch:     deployment.Ref,
+				RepoId:     scopeId,
+			}
+
+			return []interface{}{
+				domainPipeline,
+				domainTask,
+				domainPipelineCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getDeploymentStatuses returns the statuses of the deployments of the repo grouped by deployment, sorted by creation time
+func getDeploymentStatuses(db dal.Dal, connectionId uint64, repoId int) (map[int64][]models.GithubDeploymentStatus, errors.Error) {
+	var statuses []models.GithubDeploymentStatus
+	err := db.All(
+		&statuses,
+		dal.Select("s.*"),
+		dal.From("_tool_github_deployment_statuses s"),
+		dal.Join("JOIN _tool_github_deployments d ON (d.connection_id = s.connection_id AND d.id = s.deployment_id)"),
+		dal.Where("d.connection_id = ? and d.repo_id = ?", connectionId, repoId),
+		dal.Orderby("s.github_created_at, s.id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	statusesByDeployment := make(map[int64][]models.GithubDeploymentStatus)
+	for _, status := range statuses {
+		statusesByDeployment[status.DeploymentId] = append(statusesByDeployment[status.DeploymentId], status)
+	}
+	return statusesByDeployment, nil
+}
+
+// getDeploymentEnvironment returns PRODUCTION for production deployments and the real environment name otherwise,
+// a deployment is considered production if github flags it so, or its environment matches the ProductionPattern
+func getDeploymentEnvironment(regexEnricher *api.RegexEnricher, productionPattern string, deployment *models.GithubDeployment) string {
+	if deployment.ProductionEnvironment {
+		return devops.PRODUCTION
+	}
+	if productionPattern == "" {
+		if strings.EqualFold(deployment.Environment, "production") {
+			return devops.PRODUCTION
+		}
+		return deployment.Environment
+	}
+	if regexEnricher.GetEnrichResult(productionPattern, deployment.Environment, devops.PRODUCTION) != "" {
+		return devops.PRODUCTION
+	}
+	return deployment.Environment
+}
+
+// getDeploymentState derives result, status, started date and finished date of a deployment from its statuses,
+// which must be sorted by creation time. `inactive` only means the deployment was superseded by a newer one, so
+// it is ignored unless it is the only state we have.
+func getDeploymentState(deployment *models.GithubDeployment, statuses []models.GithubDeploymentStatus) (string, string, time.Time, *time.Time) {
+	startedDate := deployment.GithubCreatedAt
+	var latest *models.GithubDeploymentStatus
+	for i := range statuses {
+		switch statuses[i].State {
+		case "in_progress":
+			if latest == nil || latest.State == "queued" || latest.State == "pending" {
+				startedDate = statuses[i].GithubCreatedAt
+			}
+			latest = &statuses[i]
+		case "inactive":
+		default:
+			latest = &statuses[i]
+		}
+	}
+	if latest == nil {
+		if len(statuses) > 0 {
+			finishedDate := statuses[len(statuses)-1].GithubCreatedAt
+			return devops.ABORT, devops.DONE, startedDate, &finishedDate
+		}
+		return "", devops.IN_PROGRESS, startedDate, nil
+	}
+	finishedDate := latest.GithubCreatedAt
+	switch latest.State {
+	case "success":
+		return devops.SUCCESS, devops.DONE, startedDate, &finishedDate
+	case "failure", "error":
+		return devops.FAILURE, devops.DONE, startedDate, &finishedDate
+	default:
+		return "", devops.IN_PROGRESS, startedDate, nil
+	}
+}
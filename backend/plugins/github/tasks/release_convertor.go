@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertReleasesMeta = plugin.SubTaskMeta{
+	Name:             "convertReleases",
+	EntryPoint:       ConvertReleases,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_releases into domain layer table releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+var commitShaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+func ConvertReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubRelease{}),
+		dal.Where("repo_id = ? and connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	releaseIdGen := didgen.NewDomainIdGenerator(&models.GithubRelease{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GithubAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_RELEASE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubRelease{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			githubRelease := inputRow.(*models.GithubRelease)
+			release := &code.Release{
+				DomainEntity:  domainlayer.DomainEntity{Id: releaseIdGen.Generate(githubRelease.ConnectionId, githubRelease.GithubId)},
+				RepoId:        repoIdGen.Generate(githubRelease.ConnectionId, githubRelease.RepoId),
+				Name:          githubRelease.Name,
+				TagName:       githubRelease.TagName,
+				Description:   githubRelease.Body,
+				Url:           githubRelease.HtmlUrl,
+				IsDraft:       githubRelease.Draft,
+				IsPrerelease:  githubRelease.Prerelease,
+				CreatedDate:   githubRelease.GithubCreatedAt,
+				PublishedDate: githubRelease.PublishedAt,
+			}
+			// target_commitish is usually a branch name, refdiff resolves the commit of the tag in that case
+			if commitShaPattern.MatchString(githubRelease.TargetCommitish) {
+				release.CommitSha = githubRelease.TargetCommitish
+			}
+			if githubRelease.AuthorId != 0 {
+				release.AuthorId = accountIdGen.Generate(githubRelease.ConnectionId, githubRelease.AuthorId)
+			}
+			return []interface{}{release}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -84,6 +84,16 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		githubTasks.ExtractRunsMeta,
 		tasks.CollectCheckRunMeta,
 
+		// collect deployment & deployment status
+		githubTasks.CollectDeploymentsMeta,
+		githubTasks.ExtractDeploymentsMeta,
+		githubTasks.CollectDeploymentStatusesMeta,
+		githubTasks.ExtractDeploymentStatusesMeta,
+
+		// collect release
+		githubTasks.CollectReleasesMeta,
+		githubTasks.ExtractReleasesMeta,
+
 		// collect others
 		githubTasks.CollectApiCommentsMeta,
 		githubTasks.ExtractApiCommentsMeta,
@@ -98,6 +108,7 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		// convert to domain layer
 		githubTasks.ConvertRunsMeta,
 		githubTasks.ConvertJobsMeta,
+		githubTasks.ConvertDeploymentsMeta,
 		githubTasks.EnrichPullRequestIssuesMeta,
 		githubTasks.ConvertRepoMeta,
 		githubTasks.ConvertIssuesMeta,
@@ -111,6 +122,7 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		githubTasks.ConvertIssueCommentsMeta,
 		githubTasks.ConvertPullRequestCommentsMeta,
 		githubTasks.ConvertMilestonesMeta,
+		githubTasks.ConvertReleasesMeta,
 		githubTasks.ConvertAccountsMeta,
 	}
 }
@@ -163,6 +175,10 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 		&oauth2.Token{AccessToken: tokens[0]},
 	)
 	httpClient := oauth2.NewClient(taskCtx.GetContext(), src)
+	httpClient.Transport, err = helper.WrapTransportWithCassette(taskCtx, httpClient.Transport)
+	if err != nil {
+		return nil, err
+	}
 	endpoint, err := errors.Convert01(url.JoinPath(connection.Endpoint, `graphql`))
 	if err != nil {
 		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("malformed connection endpoint supplied: %s", connection.Endpoint))
@@ -72,6 +72,8 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabConnection{},
 		&models.GitlabAccount{},
 		&models.GitlabCommit{},
+		&models.GitlabDeployment{},
+		&models.GitlabEnvironment{},
 		&models.GitlabIssue{},
 		&models.GitlabIssueLabel{},
 		&models.GitlabJob{},
@@ -84,6 +86,7 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabPipelineProject{},
 		&models.GitlabProject{},
 		&models.GitlabProjectCommit{},
+		&models.GitlabRelease{},
 		&models.GitlabReviewer{},
 		&models.GitlabTag{},
 	}
@@ -111,6 +114,12 @@ func (p Gitlab) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractApiPipelineDetailsMeta,
 		tasks.CollectApiJobsMeta,
 		tasks.ExtractApiJobsMeta,
+		tasks.CollectApiEnvironmentsMeta,
+		tasks.ExtractApiEnvironmentsMeta,
+		tasks.CollectApiDeploymentsMeta,
+		tasks.ExtractApiDeploymentsMeta,
+		tasks.CollectApiReleasesMeta,
+		tasks.ExtractApiReleasesMeta,
 		tasks.EnrichMergeRequestsMeta,
 		tasks.CollectAccountsMeta,
 		tasks.ExtractAccountsMeta,
@@ -128,6 +137,8 @@ func (p Gitlab) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertPipelineMeta,
 		tasks.ConvertPipelineCommitMeta,
 		tasks.ConvertJobMeta,
+		tasks.ConvertDeploymentMeta,
+		tasks.ConvertReleaseMeta,
 	}
 }
 
@@ -32,5 +32,7 @@ func All() []plugin.MigrationScript {
 		new(addTransformationRule20221125),
 		new(addStdTypeToIssue221230),
 		new(addIsDetailRequired20230210),
+		new(addDeploymentTables20230309),
+		new(addReleaseTable20230309),
 	}
 }
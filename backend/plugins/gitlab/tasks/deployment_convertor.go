@@ -0,0 +1,173 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	gitlabModels "github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ConvertDeploymentMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_deployments into domain layer table cicd_pipelines and cicd_tasks",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GitlabTaskData)
+
+	// the environment embedded in a deployment may lack the tier on older gitlab versions
+	var environments []gitlabModels.GitlabEnvironment
+	err := db.All(&environments, dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+	environmentTiers := make(map[int]string, len(environments))
+	for _, environment := range environments {
+		environmentTiers[environment.GitlabId] = environment.Tier
+	}
+
+	cursor, err := db.Cursor(dal.From(gitlabModels.GitlabDeployment{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deploymentIdGen := didgen.NewDomainIdGenerator(&gitlabModels.GitlabDeployment{})
+	projectIdGen := didgen.NewDomainIdGenerator(&gitlabModels.GitlabProject{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(gitlabModels.GitlabDeployment{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GitlabApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ProjectId:    data.Options.ProjectId,
+			},
+			Table: RAW_DEPLOYMENT_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			gitlabDeployment := inputRow.(*gitlabModels.GitlabDeployment)
+
+			createdAt := time.Now()
+			if gitlabDeployment.GitlabCreatedAt != nil {
+				createdAt = *gitlabDeployment.GitlabCreatedAt
+			}
+			startedAt := createdAt
+			if gitlabDeployment.StartedAt != nil {
+				startedAt = *gitlabDeployment.StartedAt
+			}
+			tier := gitlabDeployment.EnvironmentTier
+			if tier == "" {
+				tier = environmentTiers[gitlabDeployment.EnvironmentId]
+			}
+
+			deploymentId := deploymentIdGen.Generate(data.Options.ConnectionId, gitlabDeployment.GitlabId)
+			scopeId := projectIdGen.Generate(data.Options.ConnectionId, gitlabDeployment.ProjectId)
+			result := devops.GetResult(&devops.ResultRule{
+				Failed:  []string{"failed"},
+				Abort:   []string{"canceled", "skipped"},
+				Success: []string{"success"},
+				Default: "",
+			}, gitlabDeployment.Status)
+			status := devops.GetStatus(&devops.StatusRule{
+				InProgress: []string{"created", "running", "blocked"},
+				Default:    devops.DONE,
+			}, gitlabDeployment.Status)
+			environment := getEnvironmentByTier(tier, gitlabDeployment.Environment)
+
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         gitlabDeployment.Environment,
+				Result:       result,
+				Status:       status,
+				Type:         devops.DEPLOYMENT,
+				Environment:  environment,
+				CreatedDate:  createdAt,
+				CicdScopeId:  scopeId,
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         gitlabDeployment.Environment,
+				PipelineId:   deploymentId,
+				Result:       result,
+				Status:       status,
+				Type:         devops.DEPLOYMENT,
+				Environment:  environment,
+				StartedDate:  startedAt,
+				CicdScopeId:  scopeId,
+			}
+			if status == devops.DONE {
+				finishedAt := gitlabDeployment.FinishedAt
+				if finishedAt == nil {
+					finishedAt = gitlabDeployment.GitlabUpdatedAt
+				}
+				if finishedAt != nil {
+					domainPipeline.FinishedDate = finishedAt
+					domainPipeline.DurationSec = uint64(finishedAt.Sub(createdAt).Seconds())
+					domainTask.FinishedDate = finishedAt
+					domainTask.DurationSec = uint64(finishedAt.Sub(startedAt).Seconds())
+				}
+			}
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId: deploymentId,
+				CommitSha:  gitlabDeployment.Sha,
+				Branch:     gitlabDeployment.Ref,
+				RepoId:     scopeId,
+			}
+
+			return []interface{}{
+				domainPipeline,
+				domainTask,
+				domainPipelineCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getEnvironmentByTier maps the gitlab environment tier to the domain environment,
+// falls back to the environment name for tiers that have no counterpart in domain layer
+func getEnvironmentByTier(tier string, name string) string {
+	switch tier {
+	case "production":
+		return devops.PRODUCTION
+	case "staging":
+		return devops.STAGING
+	case "testing":
+		return devops.TESTING
+	}
+	return name
+}
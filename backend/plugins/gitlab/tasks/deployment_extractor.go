@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+type ApiDeployment struct {
+	Id     int    `json:"id"`
+	Iid    int    `json:"iid"`
+	Ref    string `json:"ref"`
+	Sha    string `json:"sha"`
+	Status string `json:"status"`
+	User   *struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Environment *ApiEnvironment `json:"environment"`
+	Deployable  *struct {
+		Id         int              `json:"id"`
+		Duration   float64          `json:"duration"`
+		StartedAt  *api.Iso8601Time `json:"started_at"`
+		FinishedAt *api.Iso8601Time `json:"finished_at"`
+		Pipeline   *struct {
+			Id int `json:"id"`
+		} `json:"pipeline"`
+	} `json:"deployable"`
+
+	CreatedAt *api.Iso8601Time `json:"created_at"`
+	UpdatedAt *api.Iso8601Time `json:"updated_at"`
+}
+
+var ExtractApiDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiDeployments",
+	EntryPoint:       ExtractApiDeployments,
+	EnabledByDefault: true,
+	Description:      "Extract raw deployments data into tool layer table GitlabDeployment",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractApiDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			gitlabApiDeployment := &ApiDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Data, gitlabApiDeployment))
+			if err != nil {
+				return nil, err
+			}
+
+			gitlabDeployment := &models.GitlabDeployment{
+				ConnectionId:    data.Options.ConnectionId,
+				GitlabId:        gitlabApiDeployment.Id,
+				Iid:             gitlabApiDeployment.Iid,
+				ProjectId:       data.Options.ProjectId,
+				Ref:             gitlabApiDeployment.Ref,
+				Sha:             gitlabApiDeployment.Sha,
+				Status:          gitlabApiDeployment.Status,
+				GitlabCreatedAt: api.Iso8601TimeToTime(gitlabApiDeployment.CreatedAt),
+				GitlabUpdatedAt: api.Iso8601TimeToTime(gitlabApiDeployment.UpdatedAt),
+			}
+			if gitlabApiDeployment.User != nil {
+				gitlabDeployment.UserName = gitlabApiDeployment.User.Username
+			}
+			if gitlabApiDeployment.Environment != nil {
+				gitlabDeployment.EnvironmentId = gitlabApiDeployment.Environment.Id
+				gitlabDeployment.Environment = gitlabApiDeployment.Environment.Name
+				gitlabDeployment.EnvironmentTier = gitlabApiDeployment.Environment.Tier
+			}
+			// deployable is the job which performed the deployment, it is absent for deployments created by api
+			if gitlabApiDeployment.Deployable != nil {
+				gitlabDeployment.JobId = gitlabApiDeployment.Deployable.Id
+				gitlabDeployment.Duration = gitlabApiDeployment.Deployable.Duration
+				gitlabDeployment.StartedAt = api.Iso8601TimeToTime(gitlabApiDeployment.Deployable.StartedAt)
+				gitlabDeployment.FinishedAt = api.Iso8601TimeToTime(gitlabApiDeployment.Deployable.FinishedAt)
+				if gitlabApiDeployment.Deployable.Pipeline != nil {
+					gitlabDeployment.PipelineId = gitlabApiDeployment.Deployable.Pipeline.Id
+				}
+			}
+
+			return []interface{}{gitlabDeployment}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
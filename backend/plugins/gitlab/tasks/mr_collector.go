@@ -19,6 +19,7 @@ package tasks
 
 import (
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"net/url"
@@ -43,10 +44,15 @@ func CollectApiMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 
 	incremental := collectorWithState.IsIncremental()
+	// collect only merge requests created in the missing range if the window was widened to an earlier date
+	var backfillRange *models.TimeRange
+	if missingRanges := collectorWithState.GetMissingRanges(); collectorWithState.IsBackfill() && len(missingRanges) == 1 {
+		backfillRange = &missingRanges[0]
+	}
 	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
 		ApiClient:      data.ApiClient,
 		PageSize:       100,
-		Incremental:    incremental,
+		Incremental:    incremental || backfillRange != nil,
 		UrlTemplate:    "projects/{{ .Params.ProjectId }}/merge_requests",
 		GetTotalPages:  GetTotalPagesFromResponse,
 		ResponseParser: GetRawMessageFromResponse,
@@ -58,7 +64,12 @@ func CollectApiMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
 			if incremental {
 				query.Set("updated_after", collectorWithState.LatestState.LatestSuccessStart.Format(time.RFC3339))
 			}
-			if collectorWithState.CreatedDateAfter != nil {
+			if backfillRange != nil {
+				if backfillRange.After != nil {
+					query.Set("created_after", backfillRange.After.Format(time.RFC3339))
+				}
+				query.Set("created_before", backfillRange.Before.Format(time.RFC3339))
+			} else if collectorWithState.CreatedDateAfter != nil {
 				query.Set("created_after", collectorWithState.CreatedDateAfter.Format(time.RFC3339))
 			}
 			return query, nil
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	gitlabModels "github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+var ConvertReleaseMeta = plugin.SubTaskMeta{
+	Name:             "convertReleases",
+	EntryPoint:       ConvertReleases,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_releases into domain layer table releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+}
+
+func ConvertReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GitlabTaskData)
+
+	cursor, err := db.Cursor(dal.From(gitlabModels.GitlabRelease{}),
+		dal.Where("project_id = ? and connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	releaseIdGen := didgen.NewDomainIdGenerator(&gitlabModels.GitlabRelease{})
+	projectIdGen := didgen.NewDomainIdGenerator(&gitlabModels.GitlabProject{})
+	domainUserIdGen := didgen.NewDomainIdGenerator(&gitlabModels.GitlabAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(gitlabModels.GitlabRelease{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GitlabApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				ProjectId:    data.Options.ProjectId,
+			},
+			Table: RAW_RELEASE_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			gitlabRelease := inputRow.(*gitlabModels.GitlabRelease)
+
+			createdAt := time.Now()
+			if gitlabRelease.GitlabCreatedAt != nil {
+				createdAt = *gitlabRelease.GitlabCreatedAt
+			}
+			release := &code.Release{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: releaseIdGen.Generate(data.Options.ConnectionId, gitlabRelease.ProjectId, gitlabRelease.TagName),
+				},
+				RepoId:        projectIdGen.Generate(data.Options.ConnectionId, gitlabRelease.ProjectId),
+				Name:          gitlabRelease.Name,
+				TagName:       gitlabRelease.TagName,
+				CommitSha:     gitlabRelease.CommitSha,
+				Description:   gitlabRelease.Description,
+				Url:           gitlabRelease.WebUrl,
+				IsPrerelease:  gitlabRelease.UpcomingRelease,
+				CreatedDate:   createdAt,
+				PublishedDate: gitlabRelease.ReleasedAt,
+			}
+			if gitlabRelease.AuthorId != 0 {
+				release.AuthorId = domainUserIdGen.Generate(data.Options.ConnectionId, gitlabRelease.AuthorId)
+			}
+
+			return []interface{}{release}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -76,6 +76,7 @@ func (p Jira) GetTablesInfo() []dal.Tabler {
 		&models.JiraIssue{},
 		&models.JiraIssueChangelogItems{},
 		&models.JiraIssueChangelogs{},
+		&models.JiraIssueComment{},
 		&models.JiraIssueCommit{},
 		&models.JiraIssueLabel{},
 		&models.JiraIssueType{},
@@ -118,6 +119,9 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectRemotelinksMeta,
 		tasks.ExtractRemotelinksMeta,
 
+		tasks.CollectIssueCommentsMeta,
+		tasks.ExtractIssueCommentsMeta,
+
 		tasks.CollectSprintsMeta,
 		tasks.ExtractSprintsMeta,
 
@@ -127,6 +131,8 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.ConvertWorklogsMeta,
 
+		tasks.ConvertIssueCommentsMeta,
+
 		tasks.ConvertIssueChangelogsMeta,
 
 		tasks.ConvertSprintsMeta,
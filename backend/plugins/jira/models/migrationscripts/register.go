@@ -30,5 +30,6 @@ func All() []plugin.MigrationScript {
 		new(addTransformationRule20221116),
 		new(addProjectName20221215),
 		new(addJiraMultiAuth20230129),
+		new(addIssueComments20230308),
 	}
 }
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"time"
+)
+
+type Comment struct {
+	ID           string              `json:"id"`
+	Self         string              `json:"self"`
+	Author       *Account            `json:"author"`
+	UpdateAuthor *Account            `json:"updateAuthor"`
+	Body         string              `json:"body"`
+	Created      helper.Iso8601Time  `json:"created"`
+	Updated      *helper.Iso8601Time `json:"updated"`
+}
+
+func (c Comment) ToToolLayer(connectionId, issueId uint64, issueUpdated *time.Time) *models.JiraIssueComment {
+	result := &models.JiraIssueComment{
+		ConnectionId: connectionId,
+		IssueId:      issueId,
+		CommentId:    c.ID,
+		Self:         c.Self,
+		Body:         c.Body,
+		Created:      c.Created.ToTime(),
+		Updated:      c.Updated.ToNullableTime(),
+		IssueUpdated: issueUpdated,
+	}
+	if c.Author != nil {
+		result.CreatorAccountId = c.Author.getAccountId()
+		result.CreatorDisplayName = c.Author.DisplayName
+	}
+	return result
+}
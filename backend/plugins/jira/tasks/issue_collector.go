@@ -21,11 +21,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const RAW_ISSUE_TABLE = "jira_api_issues"
@@ -75,6 +77,10 @@ func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
 	incremental := collectorWithState.IsIncremental()
 	if incremental {
 		jql = fmt.Sprintf("updated >= '%v' AND %v", collectorWithState.LatestState.LatestSuccessStart.Format("2006/01/02 15:04"), jql)
+	} else if collectorWithState.IsBackfill() {
+		// the window was widened to an earlier date, collect only issues created in the missing ranges
+		jql = fmt.Sprintf("(%v) AND %v", buildCreatedRangesJql(collectorWithState.GetMissingRanges()), jql)
+		incremental = true
 	}
 
 	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
@@ -142,3 +148,16 @@ func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return collectorWithState.Execute()
 }
+
+// buildCreatedRangesJql returns the jql matching issues created in any of the ranges
+func buildCreatedRangesJql(ranges []models.TimeRange) string {
+	conditions := make([]string, 0, len(ranges))
+	for _, timeRange := range ranges {
+		condition := fmt.Sprintf("created < '%v'", timeRange.Before.Format("2006/01/02 15:04"))
+		if timeRange.After != nil {
+			condition = fmt.Sprintf("created >= '%v' AND %v", timeRange.After.Format("2006/01/02 15:04"), condition)
+		}
+		conditions = append(conditions, condition)
+	}
+	return strings.Join(conditions, " OR ")
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"reflect"
+)
+
+var ConvertIssueCommentsMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueComments",
+	EntryPoint:       ConvertIssueComments,
+	EnabledByDefault: true,
+	Description:      "convert Jira issue comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIssueComments(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+	boardId := data.Options.BoardId
+	logger := taskCtx.GetLogger()
+	logger.Info("convert issue comments")
+	// select all comments belongs to the board
+	clauses := []dal.Clause{
+		dal.From(&models.JiraIssueComment{}),
+		dal.Select("_tool_jira_issue_comments.*"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues
+              ON _tool_jira_board_issues.connection_id = _tool_jira_issue_comments.connection_id
+                   AND _tool_jira_board_issues.issue_id = _tool_jira_issue_comments.issue_id`),
+		dal.Where("_tool_jira_board_issues.connection_id = ? AND _tool_jira_board_issues.board_id = ?", connectionId, boardId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		logger.Error(err, "convert issue comments error")
+		return err
+	}
+	defer cursor.Close()
+
+	commentIdGen := didgen.NewDomainIdGenerator(&models.JiraIssueComment{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.JiraAccount{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      boardId,
+			},
+			Table: RAW_ISSUE_COMMENTS_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraIssueComment{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraComment := inputRow.(*models.JiraIssueComment)
+			comment := &ticket.IssueComment{
+				DomainEntity: domainlayer.DomainEntity{Id: commentIdGen.Generate(jiraComment.ConnectionId, jiraComment.IssueId, jiraComment.CommentId)},
+				IssueId:      issueIdGen.Generate(jiraComment.ConnectionId, jiraComment.IssueId),
+				Body:         jiraComment.Body,
+				CreatedDate:  jiraComment.Created,
+			}
+			if jiraComment.CreatorAccountId != "" {
+				comment.AccountId = accountIdGen.Generate(connectionId, jiraComment.CreatorAccountId)
+			}
+			return []interface{}{comment}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
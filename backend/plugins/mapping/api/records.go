@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/mapping/models"
+)
+
+var invalidSourceChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// recordsBatchSize limits the rows of an insert statement to stay within the placeholder limit of the database
+const recordsBatchSize = 500
+
+type pushRecordsRequest struct {
+	// Records are the raw json objects of the tool
+	Records []json.RawMessage `json:"records"`
+	// Replace deletes the records pushed previously for the source
+	Replace bool `json:"replace"`
+}
+
+type pushRecordsResponse struct {
+	RawDataTable  string `json:"rawDataTable"`
+	RawDataParams string `json:"rawDataParams"`
+	Count         int    `json:"count"`
+}
+
+// GetRawTableName returns the raw table holding the records pushed for the source
+func GetRawTableName(source string) string {
+	return "_raw_mapping_" + strings.Trim(invalidSourceChars.ReplaceAllString(strings.ToLower(source), "_"), "_")
+}
+
+// @Summary push raw records
+// @Description Push raw json records of a tool into _raw_mapping_<source>, so they can be mapped into domain tables
+// @Tags plugins/mapping
+// @Accept application/json
+// @Param source path string true "the tool the records came from"
+// @Param body body pushRecordsRequest true "json body"
+// @Success 200  {object} pushRecordsResponse
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/mapping/sources/{source}/records [POST]
+func PushRecords(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	source := input.Params["source"]
+	table := GetRawTableName(source)
+	if table == GetRawTableName("") {
+		return nil, errors.BadInput.New("invalid source")
+	}
+	body, err := errors.Convert01(json.Marshal(input.Body))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	request := &pushRecordsRequest{}
+	if err = errors.Convert(json.Unmarshal(body, request)); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid body")
+	}
+	params, err := errors.Convert01(json.Marshal(models.MappingParams{Source: source}))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*helper.RawData, 0, len(request.Records))
+	for i, record := range request.Records {
+		if !json.Valid(record) {
+			return nil, errors.BadInput.New(fmt.Sprintf("record #%d is not a valid json", i))
+		}
+		rows = append(rows, &helper.RawData{
+			Params: string(params),
+			Data:   record,
+			Input:  []byte("null"),
+		})
+	}
+
+	db := basicRes.GetDal()
+	err = db.AutoMigrate(&helper.RawData{}, dal.From(table))
+	if err != nil {
+		return nil, err
+	}
+	// the previous records are replaced all or nothing
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+	err = saveRecords(tx, table, string(params), rows, request.Replace)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			basicRes.GetLogger().Error(e, "failed to rollback the transaction of pushing records")
+		}
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: &pushRecordsResponse{
+			RawDataTable:  table,
+			RawDataParams: string(params),
+			Count:         len(rows),
+		},
+		Status: http.StatusOK,
+	}, nil
+}
+
+// saveRecords inserts the rows in batches, deleting the records of the same params first when replace is set
+func saveRecords(tx dal.Transaction, table string, params string, rows []*helper.RawData, replace bool) errors.Error {
+	if replace {
+		err := tx.Delete(&helper.RawData{}, dal.From(table), dal.Where("params = ?", params))
+		if err != nil {
+			return err
+		}
+	}
+	for start := 0; start < len(rows); start += recordsBatchSize {
+		end := start + recordsBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		err := tx.Create(rows[start:end], dal.From(table))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
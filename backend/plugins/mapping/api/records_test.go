@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func pushRecordsInput(count int, replace bool) *plugin.ApiResourceInput {
+	records := make([]interface{}, count)
+	for i := range records {
+		records[i] = map[string]interface{}{"id": i}
+	}
+	return &plugin.ApiResourceInput{
+		Params: map[string]string{"source": "OpsGenie"},
+		Body:   map[string]interface{}{"records": records, "replace": replace},
+	}
+}
+
+func TestPushRecordsReplace(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	var batches []int
+	tx.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+	tx.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		batches = append(batches, len(args.Get(0).([]*helper.RawData)))
+	}).Return(nil)
+	tx.On("Commit").Return(nil).Once()
+	Init(unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil)
+		mockDal.On("Begin").Return(tx).Once()
+	}))
+
+	output, err := PushRecords(pushRecordsInput(1001, true))
+	assert.Nil(t, err)
+	response := output.Body.(*pushRecordsResponse)
+	assert.Equal(t, "_raw_mapping_opsgenie", response.RawDataTable)
+	assert.Equal(t, 1001, response.Count)
+	assert.Equal(t, []int{500, 500, 1}, batches)
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Rollback")
+}
+
+func TestPushRecordsRollback(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	tx.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+	tx.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	tx.On("Create", mock.Anything, mock.Anything).Return(errors.Default.New("too many placeholders")).Once()
+	tx.On("Rollback").Return(nil).Once()
+	Init(unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil)
+		mockDal.On("Begin").Return(tx).Once()
+	}))
+
+	_, err := PushRecords(pushRecordsInput(600, true))
+	assert.NotNil(t, err)
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Commit")
+}
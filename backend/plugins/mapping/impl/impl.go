@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/mapping/api"
+	"github.com/apache/incubator-devlake/plugins/mapping/tasks"
+)
+
+// make sure interface is implemented
+var _ plugin.PluginMeta = (*Mapping)(nil)
+var _ plugin.PluginInit = (*Mapping)(nil)
+var _ plugin.PluginTask = (*Mapping)(nil)
+var _ plugin.PluginApi = (*Mapping)(nil)
+var _ plugin.PluginModel = (*Mapping)(nil)
+var _ plugin.PluginBlueprintV100 = (*Mapping)(nil)
+
+// Mapping builds domain records from arbitrary raw tables by declarative mappings
+type Mapping struct{}
+
+func (p Mapping) Description() string {
+	return "To build domain records from raw tables by declarative mappings"
+}
+
+func (p Mapping) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Mapping) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (p Mapping) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.ConvertMappedEntitiesMeta,
+	}
+}
+
+func (p Mapping) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.MappingTaskData{
+		Options: op,
+	}, nil
+}
+
+// PkgPath information lost when compiled as plugin(.so)
+func (p Mapping) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/mapping"
+}
+
+func (p Mapping) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"sources/:source/records": {
+			"POST": api.PushRecords,
+		},
+	}
+}
+
+func (p Mapping) MakePipelinePlan(connectionId uint64, scope []*plugin.BlueprintScopeV100) (plugin.PipelinePlan, errors.Error) {
+	return api.MakePipelinePlan(p.SubTaskMetas(), connectionId, scope)
+}
@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main // must be main for plugin entry point
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/plugins/mapping/impl"
+	"github.com/spf13/cobra"
+)
+
+var PluginEntry impl.Mapping //nolint
+
+// standalone mode for debugging
+func main() {
+	cmd := &cobra.Command{Use: "mapping"}
+	mappings := cmd.Flags().StringP("mappings", "m", "", "mappings in json")
+	_ = cmd.MarkFlagRequired("mappings")
+
+	cmd.Run = func(c *cobra.Command, args []string) {
+		var options []interface{}
+		if err := json.Unmarshal([]byte(*mappings), &options); err != nil {
+			panic(err)
+		}
+		runner.DirectRun(c, args, PluginEntry, map[string]interface{}{
+			"mappings": options,
+		})
+	}
+	runner.RunCmd(cmd)
+}
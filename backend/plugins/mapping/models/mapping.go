@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+// EntityMapping describes how to build domain records of an Entity from the rows of a raw table
+type EntityMapping struct {
+	// Source names the tool the raw data came from, it is part of the generated domain ids, i.e. opsgenie
+	Source string `json:"source" mapstructure:"source" example:"opsgenie"`
+	// RawDataTable is the _raw_mapping_* or _raw_singer_* table holding the records, i.e. _raw_singer_alerts
+	RawDataTable string `json:"rawDataTable" mapstructure:"rawDataTable" example:"_raw_singer_alerts"`
+	// RawDataParams limits the rows to be mapped, all rows of the table are mapped if it is empty
+	RawDataParams string `json:"rawDataParams" mapstructure:"rawDataParams" example:"{\"ConnectionId\":1,\"Stream\":\"alerts\"}"`
+	// Entity is the domain table to be populated: issues, pull_requests, cicd_tasks or accounts
+	Entity string `json:"entity" mapstructure:"entity" example:"issues"`
+	// Id extracts the id of the record in the tool, the domain id is generated from it
+	Id FieldMapping `json:"id" mapstructure:"id"`
+	// Fields maps the columns of the domain table, i.e. title, status or created_date, to their values
+	Fields map[string]FieldMapping `json:"fields" mapstructure:"fields"`
+}
+
+// FieldMapping describes how to get the value of a field from a raw record, the steps are applied in the order
+// of the fields
+type FieldMapping struct {
+	// Path is a JSONPath expression like $.fields.status.name, the leading $. is optional
+	Path string `json:"path,omitempty" mapstructure:"path,omitempty"`
+	// Value is a constant to be used instead of Path
+	Value interface{} `json:"value,omitempty" mapstructure:"value,omitempty"`
+	// Regex extracts the first capturing group, or the whole match if there is no group, from the value
+	Regex string `json:"regex,omitempty" mapstructure:"regex,omitempty"`
+	// Values translates the value, i.e. {"open": "TODO", "closed": "DONE"}, the "*" key matches any other value
+	Values map[string]string `json:"values,omitempty" mapstructure:"values,omitempty"`
+	// Format is the Go time layout to parse the value, i.e. 2006-01-02, common formats and unix timestamps are
+	// detected automatically for datetime columns when it is empty
+	Format string `json:"format,omitempty" mapstructure:"format,omitempty"`
+	// IdOf turns the value into the domain id of the specified entity from the same source, i.e. accounts
+	IdOf string `json:"idOf,omitempty" mapstructure:"idOf,omitempty"`
+	// Default is used when the value is missing or empty
+	Default interface{} `json:"default,omitempty" mapstructure:"default,omitempty"`
+}
+
+// MappingParams identifies the raw records pushed through the mapping api
+type MappingParams struct {
+	Source string
+}
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/mapping/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertMappedEntities
+
+var ConvertMappedEntitiesMeta = plugin.SubTaskMeta{
+	Name:             "convertMappedEntities",
+	EntryPoint:       ConvertMappedEntities,
+	EnabledByDefault: true,
+	Description:      "Build domain records from raw tables by the declarative mappings",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CODE_REVIEW, plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CROSS},
+}
+
+// rawRow is a row of the raw table, RawDataOrigin is selected from it so the converter copies it to the domain records
+type rawRow struct {
+	common.RawDataOrigin
+	Data []byte
+}
+
+// ConvertMappedEntities converts the raw tables by the mappings one by one
+func ConvertMappedEntities(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*MappingTaskData)
+	for i := range data.Options.Mappings {
+		mapping := &data.Options.Mappings[i]
+		taskCtx.GetLogger().Info("mapping %s of %s into %s", mapping.RawDataTable, mapping.Source, mapping.Entity)
+		if err := convertMappedEntities(taskCtx, mapping); err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to map %s into %s", mapping.RawDataTable, mapping.Entity))
+		}
+	}
+	return nil
+}
+
+func convertMappedEntities(taskCtx plugin.SubTaskContext, mapping *models.EntityMapping) errors.Error {
+	db := taskCtx.GetDal()
+	mapper, err := NewEntityMapper(mapping)
+	if err != nil {
+		return err
+	}
+	tables, err := db.AllTables()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, table := range tables {
+		if table == mapping.RawDataTable {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.NotFound.New(fmt.Sprintf("raw table %s does not exist", mapping.RawDataTable))
+	}
+	// records are converted by their params, so stale records of the same params get deleted by the converter
+	paramsList := []string{mapping.RawDataParams}
+	if mapping.RawDataParams == "" {
+		paramsList = nil
+		err = db.Pluck("DISTINCT params", &paramsList, dal.From(mapping.RawDataTable))
+		if err != nil {
+			return err
+		}
+	}
+	for _, params := range paramsList {
+		cursor, err := db.Cursor(
+			dal.Select("id AS _raw_data_id, params AS _raw_data_params, ? AS _raw_data_table, data", mapping.RawDataTable),
+			dal.From(mapping.RawDataTable),
+			dal.Where("params = ?", params),
+		)
+		if err != nil {
+			return err
+		}
+		var rawParams interface{}
+		if params != "" {
+			rawParams = json.RawMessage(params)
+		}
+		converter, err := api.NewDataConverter(api.DataConverterArgs{
+			RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+				Ctx:    taskCtx,
+				Table:  strings.TrimPrefix(mapping.RawDataTable, "_raw_"),
+				Params: rawParams,
+			},
+			InputRowType: reflect.TypeOf(rawRow{}),
+			Input:        cursor,
+			Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+				row := inputRow.(*rawRow)
+				record, err := mapper.Map(row.Data)
+				if err != nil {
+					return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to map raw record #%d", row.RawDataId))
+				}
+				if record == nil {
+					return nil, nil
+				}
+				return []interface{}{record}, nil
+			},
+		})
+		if err != nil {
+			cursor.Close()
+			return err
+		}
+		if err = converter.Execute(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
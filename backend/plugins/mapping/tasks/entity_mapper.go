@@ -0,0 +1,265 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/mapping/models"
+	"github.com/tidwall/gjson"
+	"gorm.io/gorm/schema"
+)
+
+type entityType struct {
+	domainType reflect.Type
+	mappedType interface{}
+}
+
+// entityTypes are the domain tables supported by the mapping plugin
+var entityTypes = map[string]entityType{
+	"issues":        {reflect.TypeOf(ticket.Issue{}), &models.MappedIssue{}},
+	"pull_requests": {reflect.TypeOf(code.PullRequest{}), &models.MappedPullRequest{}},
+	"cicd_tasks":    {reflect.TypeOf(devops.CICDTask{}), &models.MappedCicdTask{}},
+	"accounts":      {reflect.TypeOf(crossdomain.Account{}), &models.MappedAccount{}},
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// rawTablePattern only allows the raw tables of the mapping and singer plugins, the raw tables of other plugins are
+// converted by the plugins themselves, and their converters would wipe the domain records of each other
+var rawTablePattern = regexp.MustCompile(`^_raw_(mapping|singer)_[a-z0-9_]+$`)
+var schemaCache = &sync.Map{}
+
+// EntityMapper builds domain records from raw json by an EntityMapping
+type EntityMapper struct {
+	mapping    *models.EntityMapping
+	domainType reflect.Type
+	schema     *schema.Schema
+	idGens     map[string]*didgen.DomainIdGenerator
+	regexps    map[string]*regexp.Regexp
+}
+
+// ValidateEntityMapping checks the mapping without building the mapper
+func ValidateEntityMapping(mapping *models.EntityMapping) errors.Error {
+	if mapping.Source == "" {
+		return errors.BadInput.New("source is required")
+	}
+	if !rawTablePattern.MatchString(mapping.RawDataTable) {
+		return errors.BadInput.New(fmt.Sprintf("rawDataTable %s is not a _raw_mapping_* or _raw_singer_* table", mapping.RawDataTable))
+	}
+	if _, ok := entityTypes[mapping.Entity]; !ok {
+		return errors.BadInput.New(fmt.Sprintf("unsupported entity %s", mapping.Entity))
+	}
+	if mapping.Id.Path == "" && mapping.Id.Value == nil {
+		return errors.BadInput.New("path of id is required")
+	}
+	fields := map[string]models.FieldMapping{"id": mapping.Id}
+	for column, field := range mapping.Fields {
+		fields[column] = field
+	}
+	for column, field := range fields {
+		if field.Regex != "" {
+			if _, err := regexp.Compile(field.Regex); err != nil {
+				return errors.BadInput.Wrap(err, fmt.Sprintf("invalid regex of %s", column))
+			}
+		}
+		if _, ok := entityTypes[field.IdOf]; field.IdOf != "" && !ok {
+			return errors.BadInput.New(fmt.Sprintf("unsupported idOf %s of %s", field.IdOf, column))
+		}
+	}
+	return nil
+}
+
+// NewEntityMapper creates an EntityMapper, the mapping plugin must be registered to generate domain ids
+func NewEntityMapper(mapping *models.EntityMapping) (*EntityMapper, errors.Error) {
+	if err := ValidateEntityMapping(mapping); err != nil {
+		return nil, err
+	}
+	entity := entityTypes[mapping.Entity]
+	s, err := schema.Parse(reflect.New(entity.domainType).Interface(), schemaCache, schema.NamingStrategy{})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to parse schema of %s", mapping.Entity))
+	}
+	mapper := &EntityMapper{
+		mapping:    mapping,
+		domainType: entity.domainType,
+		schema:     s,
+		idGens:     make(map[string]*didgen.DomainIdGenerator),
+		regexps:    make(map[string]*regexp.Regexp),
+	}
+	for column := range mapping.Fields {
+		if s.LookUpField(column) == nil || column == "id" {
+			return nil, errors.BadInput.New(fmt.Sprintf("%s is not a mappable column of %s", column, mapping.Entity))
+		}
+	}
+	for name, entity := range entityTypes {
+		mapper.idGens[name] = didgen.NewDomainIdGenerator(entity.mappedType)
+	}
+	return mapper, nil
+}
+
+// Map builds a domain record from the raw json, nil is returned if the id of the record is missing
+func (m *EntityMapper) Map(data []byte) (interface{}, errors.Error) {
+	id, err := m.evaluate("id", &m.mapping.Id, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil || fmt.Sprintf("%v", id) == "" {
+		return nil, nil
+	}
+	record := reflect.New(m.domainType)
+	err = m.set(record, "id", m.idGens[m.mapping.Entity].Generate(m.mapping.Source, fmt.Sprintf("%v", id)))
+	if err != nil {
+		return nil, err
+	}
+	for column, fieldMapping := range m.mapping.Fields {
+		fieldMapping := fieldMapping
+		field := m.schema.LookUpField(column)
+		value, err := m.evaluate(column, &fieldMapping, data, field)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		if err = m.set(record, column, value); err != nil {
+			return nil, err
+		}
+	}
+	return record.Interface(), nil
+}
+
+func (m *EntityMapper) set(record reflect.Value, column string, value interface{}) errors.Error {
+	field := m.schema.LookUpField(column)
+	if field.FieldType.Kind() == reflect.Ptr && field.FieldType.Elem() == timeType {
+		t, ok := value.(time.Time)
+		if !ok {
+			return errors.BadInput.New(fmt.Sprintf("%s of %s is a time but got %v", column, m.mapping.Entity, value))
+		}
+		value = &t
+	}
+	if err := field.Set(context.Background(), record, value); err != nil {
+		return errors.BadInput.Wrap(err, fmt.Sprintf("failed to set %s of %s to %v", column, m.mapping.Entity, value))
+	}
+	return nil
+}
+
+// evaluate runs the steps of the FieldMapping against the raw json, nil is returned if the value is missing
+func (m *EntityMapper) evaluate(column string, fm *models.FieldMapping, data []byte, field *schema.Field) (interface{}, errors.Error) {
+	var value interface{}
+	if fm.Value != nil {
+		value = fm.Value
+	} else if fm.Path != "" {
+		result := gjson.GetBytes(data, jsonPathToGjson(fm.Path))
+		if result.Exists() && result.Type != gjson.Null {
+			if result.Type == gjson.String {
+				value = result.String()
+			} else {
+				value = result.Value()
+			}
+		}
+	}
+	if value != nil && fm.Regex != "" {
+		re, ok := m.regexps[fm.Regex]
+		if !ok {
+			re = regexp.MustCompile(fm.Regex)
+			m.regexps[fm.Regex] = re
+		}
+		matches := re.FindStringSubmatch(toString(value))
+		switch {
+		case len(matches) == 0:
+			value = nil
+		case len(matches) > 1:
+			value = matches[1]
+		default:
+			value = matches[0]
+		}
+	}
+	if value != nil && len(fm.Values) > 0 {
+		if translated, ok := fm.Values[toString(value)]; ok {
+			value = translated
+		} else if translated, ok := fm.Values["*"]; ok {
+			value = translated
+		}
+	}
+	if value == nil || toString(value) == "" {
+		value = fm.Default
+	}
+	if value == nil {
+		return nil, nil
+	}
+	if fm.IdOf != "" {
+		return m.idGens[fm.IdOf].Generate(m.mapping.Source, toString(value)), nil
+	}
+	isTime := field != nil && (field.FieldType == timeType || (field.FieldType.Kind() == reflect.Ptr && field.FieldType.Elem() == timeType))
+	if fm.Format != "" || isTime {
+		t, err := parseTime(value, fm.Format)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("failed to parse %s from %v", column, value))
+		}
+		return t, nil
+	}
+	if f, ok := value.(float64); ok && f == float64(int64(f)) {
+		// json numbers are float64, keep integers as integers so they can be set to both numeric and string columns
+		return int64(f), nil
+	}
+	return value, nil
+}
+
+// parseTime parses the value by the layout, or detects the format when the layout is empty, numbers are treated as
+// unix timestamps in either seconds or milliseconds
+func parseTime(value interface{}, layout string) (time.Time, error) {
+	if ts, ok := value.(float64); ok && layout == "" {
+		if ts > 1e11 {
+			return time.UnixMilli(int64(ts)).UTC(), nil
+		}
+		return time.Unix(int64(ts), 0).UTC(), nil
+	}
+	s := strings.TrimSpace(toString(value))
+	if layout != "" {
+		return time.Parse(layout, s)
+	}
+	return api.ConvertStringToTime(s)
+}
+
+// jsonPathToGjson converts the simple JSONPath expressions into gjson paths, i.e. $.items[0].name into items.0.name
+func jsonPathToGjson(path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	path = strings.ReplaceAll(path, "[", ".")
+	return strings.ReplaceAll(path, "]", "")
+}
+
+func toString(value interface{}) string {
+	if f, ok := value.(float64); ok && f == float64(int64(f)) {
+		return fmt.Sprintf("%d", int64(f))
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,166 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/mapping/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type mappingPlugin struct{}
+
+func (mappingPlugin) Description() string { return "mapping" }
+func (mappingPlugin) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/mapping"
+}
+
+func init() {
+	_ = plugin.RegisterPlugin("mapping", mappingPlugin{})
+}
+
+func TestEntityMapperMapIssue(t *testing.T) {
+	mapper, err := NewEntityMapper(&models.EntityMapping{
+		Source:       "opsgenie",
+		RawDataTable: "_raw_singer_alerts",
+		Entity:       "issues",
+		Id:           models.FieldMapping{Path: "$.id"},
+		Fields: map[string]models.FieldMapping{
+			"title":             {Path: "$.message"},
+			"type":              {Value: ticket.INCIDENT},
+			"issue_key":         {Path: "tinyId", Regex: `^(\d+)`},
+			"status":            {Path: "status", Values: map[string]string{"open": ticket.TODO, "closed": ticket.DONE, "*": ticket.IN_PROGRESS}},
+			"original_status":   {Path: "status"},
+			"priority":          {Path: "priority", Default: "P3"},
+			"created_date":      {Path: "createdAt"},
+			"resolution_date":   {Path: "closedAt", Format: "2006-01-02 15:04"},
+			"lead_time_minutes": {Path: "leadTime"},
+			"assignee_id":       {Path: "owners[0].id", IdOf: "accounts"},
+		},
+	})
+	assert.Nil(t, err)
+
+	record, err := mapper.Map([]byte(`{
+		"id": 123,
+		"message": "db is down",
+		"tinyId": "42-a",
+		"status": "closed",
+		"createdAt": 1678406400,
+		"closedAt": "2023-03-10 02:30",
+		"leadTime": 150,
+		"owners": [{"id": "u1"}]
+	}`))
+	assert.Nil(t, err)
+	issue := record.(*ticket.Issue)
+	assert.Equal(t, "mapping:MappedIssue:opsgenie:123", issue.Id)
+	assert.Equal(t, "db is down", issue.Title)
+	assert.Equal(t, ticket.INCIDENT, issue.Type)
+	assert.Equal(t, "42", issue.IssueKey)
+	assert.Equal(t, ticket.DONE, issue.Status)
+	assert.Equal(t, "closed", issue.OriginalStatus)
+	assert.Equal(t, "P3", issue.Priority)
+	assert.Equal(t, time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC), *issue.CreatedDate)
+	assert.Equal(t, time.Date(2023, 3, 10, 2, 30, 0, 0, time.UTC), *issue.ResolutionDate)
+	assert.Equal(t, int64(150), issue.LeadTimeMinutes)
+	assert.Equal(t, "mapping:MappedAccount:opsgenie:u1", issue.AssigneeId)
+
+	record, err = mapper.Map([]byte(`{"id": 124, "status": "acked"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, ticket.IN_PROGRESS, record.(*ticket.Issue).Status)
+
+	// records without id are skipped
+	record, err = mapper.Map([]byte(`{"message": "no id"}`))
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+
+	_, err = mapper.Map([]byte(`{"id": 125, "closedAt": "yesterday"}`))
+	assert.NotNil(t, err)
+}
+
+func TestEntityMapperMapAccount(t *testing.T) {
+	mapper, err := NewEntityMapper(&models.EntityMapping{
+		Source:       "opsgenie",
+		RawDataTable: "_raw_mapping_opsgenie",
+		Entity:       "accounts",
+		Id:           models.FieldMapping{Path: "id"},
+		Fields: map[string]models.FieldMapping{
+			"email":     {Path: "username"},
+			"full_name": {Path: "fullName"},
+		},
+	})
+	assert.Nil(t, err)
+	record, err := mapper.Map([]byte(`{"id": "u1", "username": "a@b.c", "fullName": "A B"}`))
+	assert.Nil(t, err)
+	account := record.(*crossdomain.Account)
+	assert.Equal(t, "mapping:MappedAccount:opsgenie:u1", account.Id)
+	assert.Equal(t, "a@b.c", account.Email)
+	assert.Equal(t, "A B", account.FullName)
+}
+
+func TestEntityMapperSetTimeColumn(t *testing.T) {
+	// an id can not be set into a time column
+	mapper, err := NewEntityMapper(&models.EntityMapping{
+		Source:       "s",
+		RawDataTable: "_raw_mapping_t",
+		Entity:       "cicd_tasks",
+		Id:           models.FieldMapping{Path: "id"},
+		Fields: map[string]models.FieldMapping{
+			"finished_date": {Path: "user", IdOf: "accounts"},
+		},
+	})
+	assert.Nil(t, err)
+	_, err = mapper.Map([]byte(`{"id": "t1", "user": "u1"}`))
+	assert.NotNil(t, err)
+}
+
+func TestValidateEntityMapping(t *testing.T) {
+	valid := models.EntityMapping{Source: "s", RawDataTable: "_raw_mapping_t", Entity: "cicd_tasks", Id: models.FieldMapping{Path: "id"}}
+	assert.Nil(t, ValidateEntityMapping(&valid))
+
+	invalid := valid
+	invalid.Entity = "boards"
+	assert.NotNil(t, ValidateEntityMapping(&invalid))
+
+	invalid = valid
+	invalid.RawDataTable = "issues"
+	assert.NotNil(t, ValidateEntityMapping(&invalid))
+	invalid.RawDataTable = "_raw_mapping_t where 1=1"
+	assert.NotNil(t, ValidateEntityMapping(&invalid))
+	invalid.RawDataTable = "_raw_mapping_T"
+	assert.NotNil(t, ValidateEntityMapping(&invalid))
+	invalid.RawDataTable = "_raw_jira_api_issues"
+	assert.NotNil(t, ValidateEntityMapping(&invalid))
+
+	invalid = valid
+	invalid.Fields = map[string]models.FieldMapping{"name": {Path: "name", Regex: "("}}
+	assert.NotNil(t, ValidateEntityMapping(&invalid))
+
+	_, err := NewEntityMapper(&models.EntityMapping{Source: "s", RawDataTable: "_raw_mapping_t", Entity: "cicd_tasks", Id: models.FieldMapping{Path: "id"},
+		Fields: map[string]models.FieldMapping{"no_such_column": {Path: "x"}}})
+	assert.NotNil(t, err)
+}
+
+func TestJsonPathToGjson(t *testing.T) {
+	assert.Equal(t, "items.0.name", jsonPathToGjson("$.items[0].name"))
+	assert.Equal(t, "fields.status.name", jsonPathToGjson("fields.status.name"))
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/mapping/models"
+)
+
+type MappingOptions struct {
+	Mappings []models.EntityMapping `json:"mappings" mapstructure:"mappings"`
+}
+
+type MappingTaskData struct {
+	Options *MappingOptions
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*MappingOptions, errors.Error) {
+	var op MappingOptions
+	if err := api.Decode(options, &op, nil); err != nil {
+		return nil, err
+	}
+	if len(op.Mappings) == 0 {
+		return nil, errors.BadInput.New("mappings are required")
+	}
+	for i := range op.Mappings {
+		if err := ValidateEntityMapping(&op.Mappings[i]); err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid mapping #%d", i))
+		}
+	}
+	return &op, nil
+}
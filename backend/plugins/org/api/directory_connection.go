@@ -0,0 +1,174 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	gocontext "context"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/apache/incubator-devlake/plugins/org/tasks"
+)
+
+// credentialMask replaces the password and token of directory connections in responses
+const credentialMask = "********"
+
+// maskDirectoryConnection returns a copy of the connection with the bind password and the SCIM token masked
+func maskDirectoryConnection(connection *models.DirectoryConnection) *models.DirectoryConnection {
+	masked := *connection
+	if masked.Password != "" {
+		masked.Password = credentialMask
+	}
+	if masked.Token != "" {
+		masked.Token = credentialMask
+	}
+	return &masked
+}
+
+type directoryTestResult struct {
+	Users  int `json:"users"`
+	Groups int `json:"groups"`
+}
+
+// TestDirectoryConnection reads the whole directory and reports how many users and groups were found
+// @Summary test directory connection
+// @Description Test LDAP or SCIM directory connection, returning the number of users and groups found
+// @Tags plugins/org
+// @Param body body models.DirectoryConnection true "json body"
+// @Success 200  {object} directoryTestResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/test [POST]
+func (h *Handlers) TestDirectoryConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	err := helper.Decode(input.Body, connection, nil)
+	if err != nil {
+		return nil, err
+	}
+	connection.Name = "test"
+	err = connection.ValidateConnection(connection, h.vld)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := tasks.NewDirectoryReader(gocontext.TODO(), h.basicRes, connection)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	users, groups, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: directoryTestResult{Users: len(users), Groups: len(groups)}, Status: http.StatusOK}, nil
+}
+
+// @Summary create directory connection
+// @Description Create LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Param body body models.DirectoryConnection true "json body"
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections [POST]
+func (h *Handlers) PostDirectoryConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	err := h.connectionHelper.Create(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: maskDirectoryConnection(connection), Status: http.StatusOK}, nil
+}
+
+// @Summary patch directory connection
+// @Description Patch LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Param body body models.DirectoryConnection true "json body"
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections/{connectionId} [PATCH]
+func (h *Handlers) PatchDirectoryConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	// the masked credentials sent back by clients are not meant to change the stored ones
+	for _, key := range []string{"password", "token"} {
+		if value, ok := input.Body[key].(string); ok && value == credentialMask {
+			delete(input.Body, key)
+		}
+	}
+	err := h.connectionHelper.Patch(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: maskDirectoryConnection(connection), Status: http.StatusOK}, nil
+}
+
+// @Summary delete directory connection
+// @Description Delete LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections/{connectionId} [DELETE]
+func (h *Handlers) DeleteDirectoryConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	err := h.connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	err = h.connectionHelper.Delete(connection)
+	return &plugin.ApiResourceOutput{Body: maskDirectoryConnection(connection)}, err
+}
+
+// @Summary list directory connections
+// @Description List LDAP and SCIM directory connections
+// @Tags plugins/org
+// @Success 200  {object} []models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections [GET]
+func (h *Handlers) ListDirectoryConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var connections []models.DirectoryConnection
+	err := h.connectionHelper.List(&connections)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*models.DirectoryConnection, len(connections))
+	for i := range connections {
+		responses[i] = maskDirectoryConnection(&connections[i])
+	}
+	return &plugin.ApiResourceOutput{Body: responses}, nil
+}
+
+// @Summary get directory connection
+// @Description Get LDAP or SCIM directory connection
+// @Tags plugins/org
+// @Success 200  {object} models.DirectoryConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/org/connections/{connectionId} [GET]
+func (h *Handlers) GetDirectoryConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.DirectoryConnection{}
+	err := h.connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: maskDirectoryConnection(connection)}, nil
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func storedDirectoryConnection() *models.DirectoryConnection {
+	connection := &models.DirectoryConnection{
+		Type:     models.DirectoryTypeLdap,
+		Endpoint: "ldaps://ldap.example.com:636",
+		BindDn:   "cn=admin,dc=example,dc=com",
+		Password: "ldap-bind-password",
+		Token:    "scim-bearer-token",
+		BaseDn:   "dc=example,dc=com",
+	}
+	connection.ID = 1
+	connection.Name = "directory"
+	return connection
+}
+
+func newDirectoryConnectionHandlers(callback func(mockDal *mockdal.Dal)) *Handlers {
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*models.DirectoryConnection) = *storedDirectoryConnection()
+		}).Return(nil)
+		mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*[]models.DirectoryConnection) = []models.DirectoryConnection{*storedDirectoryConnection()}
+		}).Return(nil)
+		callback(mockDal)
+	})
+	return &Handlers{connectionHelper: helper.NewConnectionHelper(basicRes, nil)}
+}
+
+func assertDirectoryCredentialsMasked(t *testing.T, output *plugin.ApiResourceOutput) {
+	body, err := json.Marshal(output.Body)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(body), "ldap-bind-password")
+	assert.NotContains(t, string(body), "scim-bearer-token")
+	assert.Contains(t, string(body), `"password":"********"`)
+	assert.Contains(t, string(body), `"token":"********"`)
+}
+
+func TestDirectoryConnectionCredentialsMasked(t *testing.T) {
+	h := newDirectoryConnectionHandlers(func(mockDal *mockdal.Dal) {
+		mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil)
+		mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	})
+	params := map[string]string{"connectionId": "1"}
+
+	output, err := h.GetDirectoryConnection(&plugin.ApiResourceInput{Params: params})
+	assert.Nil(t, err)
+	assertDirectoryCredentialsMasked(t, output)
+
+	output, err = h.ListDirectoryConnections(&plugin.ApiResourceInput{})
+	assert.Nil(t, err)
+	assertDirectoryCredentialsMasked(t, output)
+
+	output, err = h.PostDirectoryConnections(&plugin.ApiResourceInput{Body: map[string]interface{}{
+		"name":     "directory",
+		"type":     models.DirectoryTypeScim,
+		"endpoint": "https://example.com/scim/v2",
+		"password": "ldap-bind-password",
+		"token":    "scim-bearer-token",
+	}})
+	assert.Nil(t, err)
+	assertDirectoryCredentialsMasked(t, output)
+
+	output, err = h.PatchDirectoryConnection(&plugin.ApiResourceInput{Params: params, Body: map[string]interface{}{"bindDn": "cn=reader"}})
+	assert.Nil(t, err)
+	assertDirectoryCredentialsMasked(t, output)
+
+	output, err = h.DeleteDirectoryConnection(&plugin.ApiResourceInput{Params: params})
+	assert.Nil(t, err)
+	assertDirectoryCredentialsMasked(t, output)
+}
+
+func TestPatchDirectoryConnectionKeepsMaskedCredentials(t *testing.T) {
+	var saved *models.DirectoryConnection
+	h := newDirectoryConnectionHandlers(func(mockDal *mockdal.Dal) {
+		mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(*models.DirectoryConnection)
+		}).Return(nil)
+	})
+
+	_, err := h.PatchDirectoryConnection(&plugin.ApiResourceInput{
+		Params: map[string]string{"connectionId": "1"},
+		Body:   map[string]interface{}{"password": credentialMask, "token": "new-scim-token"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "ldap-bind-password", saved.Password)
+	assert.Equal(t, "new-scim-token", saved.Token)
+}
@@ -21,6 +21,8 @@ import (
 	"encoding/csv"
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/go-playground/validator/v10"
 	"github.com/gocarina/gocsv"
 	"net/http"
 )
@@ -28,11 +30,20 @@ import (
 const maxMemory = 32 << 20 // 32 MB
 
 type Handlers struct {
-	store store
+	store            store
+	basicRes         context.BasicRes
+	vld              *validator.Validate
+	connectionHelper *helper.ConnectionApiHelper
 }
 
 func NewHandlers(basicRes context.BasicRes) *Handlers {
-	return &Handlers{store: NewDbStore(basicRes.GetDal(), basicRes)}
+	vld := validator.New()
+	return &Handlers{
+		store:            NewDbStore(basicRes.GetDal(), basicRes),
+		basicRes:         basicRes,
+		vld:              vld,
+		connectionHelper: helper.NewConnectionHelper(basicRes, vld),
+	}
 }
 
 func (h *Handlers) unmarshal(r *http.Request, items interface{}) errors.Error {
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore keeps users and teams in memory, the store methods not needed by the tests are left unimplemented
+type memoryStore struct {
+	store
+	users map[string]*user
+	teams map[string]*team
+	saved []interface{}
+}
+
+func (m *memoryStore) findUserById(id string) (*user, errors.Error) {
+	if u, ok := m.users[id]; ok {
+		copied := *u
+		return &copied, nil
+	}
+	return nil, errors.NotFound.New(fmt.Sprintf("user %s not found", id))
+}
+
+func (m *memoryStore) findTeamById(id string) (*team, errors.Error) {
+	if t, ok := m.teams[id]; ok {
+		copied := *t
+		return &copied, nil
+	}
+	return nil, errors.NotFound.New(fmt.Sprintf("team %s not found", id))
+}
+
+func (m *memoryStore) saveOne(item interface{}) errors.Error {
+	m.saved = append(m.saved, item)
+	return nil
+}
+
+func (m *memoryStore) replaceUserTeams(userId string, teamIds []string) errors.Error {
+	for _, teamId := range teamIds {
+		m.saved = append(m.saved, &crossdomain.TeamUser{TeamId: teamId, UserId: userId})
+	}
+	return nil
+}
+
+func newMemoryHandlers() (*Handlers, *memoryStore) {
+	m := &memoryStore{
+		users: map[string]*user{"u1": {Id: "u1", Name: "John Doe"}},
+		teams: map[string]*team{
+			"t1": {Id: "t1", Name: "Engineering"},
+			"t2": {Id: "t2", Name: "Backend", ParentId: "t1"},
+		},
+	}
+	return &Handlers{store: m}, m
+}
+
+func TestPostUser(t *testing.T) {
+	h, m := newMemoryHandlers()
+	_, err := h.PostUser(&plugin.ApiResourceInput{Body: map[string]interface{}{"id": "u1", "name": "Again"}})
+	assert.Equal(t, errors.BadInput, err.GetType())
+
+	_, err = h.PostUser(&plugin.ApiResourceInput{Body: map[string]interface{}{"id": "u2", "name": "Jane", "teamIds": "t3"}})
+	assert.Equal(t, errors.BadInput, err.GetType())
+	assert.Empty(t, m.saved)
+
+	output, err := h.PostUser(&plugin.ApiResourceInput{Body: map[string]interface{}{"id": "u2", "name": "Jane", "teamIds": "t1;t2"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "u2", output.Body.(*user).Id)
+	assert.Len(t, m.saved, 3)
+	assert.Equal(t, "Jane", m.saved[0].(*crossdomain.User).Name)
+	assert.Equal(t, "t2", m.saved[2].(*crossdomain.TeamUser).TeamId)
+}
+
+func TestPatchTeam(t *testing.T) {
+	h, m := newMemoryHandlers()
+	// t1 can not become a sub team of its own sub team
+	_, err := h.PatchTeam(&plugin.ApiResourceInput{
+		Params: map[string]string{"teamId": "t1"},
+		Body:   map[string]interface{}{"parentId": "t2"},
+	})
+	assert.Equal(t, errors.BadInput, err.GetType())
+	assert.Empty(t, m.saved)
+
+	output, err := h.PatchTeam(&plugin.ApiResourceInput{
+		Params: map[string]string{"teamId": "t2"},
+		Body:   map[string]interface{}{"alias": "BE", "sortingIndex": float64(3)},
+	})
+	assert.Nil(t, err)
+	patched := output.Body.(*team)
+	assert.Equal(t, "Backend", patched.Name)
+	assert.Equal(t, "t1", patched.ParentId)
+	assert.Equal(t, 3, patched.SortingIndex)
+	assert.Equal(t, "BE", m.saved[0].(*crossdomain.Team).Alias)
+}
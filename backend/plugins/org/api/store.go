@@ -18,11 +18,14 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
+
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
 	"reflect"
 )
 
@@ -32,18 +35,49 @@ type store interface {
 	findAllAccounts() ([]account, errors.Error)
 	findAllUserAccounts() ([]userAccount, errors.Error)
 	findAllProjectMapping() ([]projectMapping, errors.Error)
+	findUserAccountSuggestions(status string) ([]userAccountSuggestion, errors.Error)
+	reviewUserAccountSuggestion(accountId, userId string, accept bool) errors.Error
+	findUserById(id string) (*user, errors.Error)
+	findTeamById(id string) (*team, errors.Error)
+	findTeamUsers(teamId string) ([]user, errors.Error)
+	saveOne(item interface{}) errors.Error
+	replaceUserTeams(userId string, teamIds []string) errors.Error
+	deleteUser(id string) errors.Error
+	deleteTeam(id string) errors.Error
+	deleteTeamUser(teamId, userId string) errors.Error
 	deleteAll(i interface{}) errors.Error
 	save(items []interface{}) errors.Error
+	upsert(items []interface{}) errors.Error
 }
 
 type dbStore struct {
-	db     dal.Dal
-	driver *helper.BatchSaveDivider
+	db       dal.Dal
+	driver   *helper.BatchSaveDivider
+	basicRes context.BasicRes
 }
 
 func NewDbStore(db dal.Dal, basicRes context.BasicRes) *dbStore {
 	driver := helper.NewBatchSaveDivider(basicRes, 1000, "", "")
-	return &dbStore{db: db, driver: driver}
+	return &dbStore{db: db, driver: driver, basicRes: basicRes}
+}
+
+// transaction runs f in a transaction, which is committed only if f succeeds
+func (d *dbStore) transaction(f func(tx dal.Dal) errors.Error) errors.Error {
+	tx := d.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+	err := f(tx)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			d.basicRes.GetLogger().Error(e, "failed to rollback the transaction")
+		}
+		return err
+	}
+	return tx.Commit()
 }
 
 func (d *dbStore) findAllUsers() ([]user, errors.Error) {
@@ -104,6 +138,168 @@ func (d *dbStore) findAllProjectMapping() ([]projectMapping, errors.Error) {
 	var pm *projectMapping
 	return pm.fromDomainLayer(mapping), nil
 }
+func (d *dbStore) findUserAccountSuggestions(status string) ([]userAccountSuggestion, errors.Error) {
+	var suggestions []userAccountSuggestion
+	err := d.db.All(
+		&suggestions,
+		dal.Select("s.account_id, s.user_id, s.confidence, s.reasons, s.status, "+
+			"a.user_name AS account_user_name, a.full_name AS account_full_name, a.email AS account_email, "+
+			"u.name AS user_name, u.email AS user_email"),
+		dal.From("_tool_org_user_account_suggestions s"),
+		dal.Join("LEFT JOIN accounts a ON a.id = s.account_id"),
+		dal.Join("LEFT JOIN users u ON u.id = s.user_id"),
+		dal.Where("s.status = ?", status),
+		dal.Orderby("s.confidence DESC, s.account_id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+func (d *dbStore) reviewUserAccountSuggestion(accountId, userId string, accept bool) errors.Error {
+	return d.transaction(func(tx dal.Dal) errors.Error {
+		var suggestion models.UserAccountSuggestion
+		err := tx.First(&suggestion, dal.Where("account_id = ? AND user_id = ?", accountId, userId), dal.Lock(true, false))
+		if tx.IsErrorNotFound(err) {
+			return errors.NotFound.New(fmt.Sprintf("no suggestion linking account %s to user %s", accountId, userId))
+		}
+		if err != nil {
+			return err
+		}
+		suggestion.Status = models.SuggestionRejected
+		if accept {
+			suggestion.Status = models.SuggestionAccepted
+			// an account belongs to one user only, the accepted link replaces the existing one
+			err = tx.Delete(&crossdomain.UserAccount{}, dal.Where("account_id = ? AND user_id != ?", accountId, userId))
+			if err != nil {
+				return err
+			}
+			err = tx.CreateOrUpdate(&crossdomain.UserAccount{UserId: userId, AccountId: accountId})
+			if err != nil {
+				return err
+			}
+		}
+		return tx.CreateOrUpdate(&suggestion)
+	})
+}
+
+func (d *dbStore) findUserById(id string) (*user, errors.Error) {
+	var u crossdomain.User
+	err := d.db.First(&u, dal.Where("id = ?", id))
+	if d.db.IsErrorNotFound(err) {
+		return nil, errors.NotFound.New(fmt.Sprintf("user %s not found", id))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tus []crossdomain.TeamUser
+	err = d.db.All(&tus, dal.Where("user_id = ?", id))
+	if err != nil {
+		return nil, err
+	}
+	var uu *user
+	return &uu.fromDomainLayer([]crossdomain.User{u}, tus)[0], nil
+}
+
+func (d *dbStore) findTeamById(id string) (*team, errors.Error) {
+	var t crossdomain.Team
+	err := d.db.First(&t, dal.Where("id = ?", id))
+	if d.db.IsErrorNotFound(err) {
+		return nil, errors.NotFound.New(fmt.Sprintf("team %s not found", id))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tt *team
+	return &tt.fromDomainLayer([]crossdomain.Team{t})[0], nil
+}
+
+func (d *dbStore) findTeamUsers(teamId string) ([]user, errors.Error) {
+	var uu []crossdomain.User
+	err := d.db.All(
+		&uu,
+		dal.Select("users.*"),
+		dal.From("users"),
+		dal.Join("JOIN team_users ON team_users.user_id = users.id"),
+		dal.Where("team_users.team_id = ?", teamId),
+		dal.Orderby("users.id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	userIds := make([]string, 0, len(uu))
+	for _, u := range uu {
+		userIds = append(userIds, u.Id)
+	}
+	var tus []crossdomain.TeamUser
+	if len(userIds) > 0 {
+		err = d.db.All(&tus, dal.Where("user_id IN ?", userIds))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var u *user
+	return u.fromDomainLayer(uu, tus), nil
+}
+
+func (d *dbStore) saveOne(item interface{}) errors.Error {
+	return d.db.CreateOrUpdate(item)
+}
+
+func (d *dbStore) replaceUserTeams(userId string, teamIds []string) errors.Error {
+	return d.transaction(func(tx dal.Dal) errors.Error {
+		err := tx.Delete(&crossdomain.TeamUser{}, dal.Where("user_id = ?", userId))
+		if err != nil {
+			return err
+		}
+		for _, teamId := range teamIds {
+			err = tx.CreateOrUpdate(&crossdomain.TeamUser{TeamId: teamId, UserId: userId})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// deleteUser removes the user along with its team memberships and account links
+func (d *dbStore) deleteUser(id string) errors.Error {
+	return d.transaction(func(tx dal.Dal) errors.Error {
+		err := tx.Delete(&crossdomain.TeamUser{}, dal.Where("user_id = ?", id))
+		if err != nil {
+			return err
+		}
+		err = tx.Delete(&crossdomain.UserAccount{}, dal.Where("user_id = ?", id))
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&crossdomain.User{}, dal.Where("id = ?", id))
+	})
+}
+
+// deleteTeam removes the team and its memberships, teams having sub teams can not be deleted
+func (d *dbStore) deleteTeam(id string) errors.Error {
+	return d.transaction(func(tx dal.Dal) errors.Error {
+		count, err := tx.Count(dal.From(&crossdomain.Team{}), dal.Where("parent_id = ?", id))
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.BadInput.New(fmt.Sprintf("team %s has %d sub teams, delete or move them first", id, count))
+		}
+		err = tx.Delete(&crossdomain.TeamUser{}, dal.Where("team_id = ?", id))
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&crossdomain.Team{}, dal.Where("id = ?", id))
+	})
+}
+
+func (d *dbStore) deleteTeamUser(teamId, userId string) errors.Error {
+	return d.db.Delete(&crossdomain.TeamUser{}, dal.Where("team_id = ? AND user_id = ?", teamId, userId))
+}
+
 func (d *dbStore) deleteAll(i interface{}) errors.Error {
 	return d.db.Delete(i, dal.Where("1=1"))
 }
@@ -122,3 +318,31 @@ func (d *dbStore) save(items []interface{}) errors.Error {
 	d.driver.Close()
 	return nil
 }
+
+// upsert saves the items without deleting the existing records, unlike save which goes through the BatchSaveDivider
+func (d *dbStore) upsert(items []interface{}) errors.Error {
+	batches := make(map[reflect.Type]*helper.BatchSave)
+	for _, item := range items {
+		rowType := reflect.TypeOf(item)
+		batch, ok := batches[rowType]
+		if !ok {
+			var err errors.Error
+			batch, err = helper.NewBatchSave(d.basicRes, rowType, 1000)
+			if err != nil {
+				return err
+			}
+			batches[rowType] = batch
+		}
+		err := batch.Add(item)
+		if err != nil {
+			return err
+		}
+	}
+	for _, batch := range batches {
+		err := batch.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReviewUserAccountSuggestion(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	tx.On("First", mock.AnythingOfType("*models.UserAccountSuggestion"), mock.Anything).Return(nil).Once()
+	tx.On("IsErrorNotFound", mock.Anything).Return(false)
+	// the existing link of the account is replaced along with the status update
+	tx.On("Delete", mock.AnythingOfType("*crossdomain.UserAccount"), mock.Anything).Return(nil).Once()
+	tx.On("CreateOrUpdate", &crossdomain.UserAccount{UserId: "user:2", AccountId: "github:1"}, mock.Anything).Return(nil).Once()
+	tx.On("CreateOrUpdate", mock.MatchedBy(func(s *models.UserAccountSuggestion) bool {
+		return s.Status == models.SuggestionAccepted
+	}), mock.Anything).Return(nil).Once()
+	tx.On("Commit").Return(nil).Once()
+	db := new(mockdal.Dal)
+	db.On("Begin").Return(tx)
+
+	d := &dbStore{db: db}
+	assert.Nil(t, d.reviewUserAccountSuggestion("github:1", "user:2", true))
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Rollback")
+}
+
+func TestReviewUserAccountSuggestionRollback(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	tx.On("First", mock.AnythingOfType("*models.UserAccountSuggestion"), mock.Anything).Return(nil).Once()
+	tx.On("IsErrorNotFound", mock.Anything).Return(false)
+	tx.On("Delete", mock.AnythingOfType("*crossdomain.UserAccount"), mock.Anything).Return(nil).Once()
+	tx.On("CreateOrUpdate", mock.AnythingOfType("*crossdomain.UserAccount"), mock.Anything).Return(errors.Default.New("duplicated")).Once()
+	tx.On("Rollback").Return(nil).Once()
+	db := new(mockdal.Dal)
+	db.On("Begin").Return(tx)
+
+	d := &dbStore{db: db}
+	assert.NotNil(t, d.reviewUserAccountSuggestion("github:1", "user:2", true))
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Commit")
+}
+
+func TestDeleteUser(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	tx.On("Delete", mock.AnythingOfType("*crossdomain.TeamUser"), mock.Anything).Return(nil).Once()
+	tx.On("Delete", mock.AnythingOfType("*crossdomain.UserAccount"), mock.Anything).Return(nil).Once()
+	tx.On("Delete", mock.AnythingOfType("*crossdomain.User"), mock.Anything).Return(nil).Once()
+	tx.On("Commit").Return(nil).Once()
+	db := new(mockdal.Dal)
+	db.On("Begin").Return(tx)
+
+	d := &dbStore{db: db}
+	assert.Nil(t, d.deleteUser("user:1"))
+	tx.AssertExpectations(t)
+}
+
+func TestDeleteTeamHavingSubTeams(t *testing.T) {
+	tx := new(mockdal.Transaction)
+	tx.On("Count", mock.Anything).Return(int64(2), nil).Once()
+	tx.On("Rollback").Return(nil).Once()
+	db := new(mockdal.Dal)
+	db.On("Begin").Return(tx)
+
+	d := &dbStore{db: db}
+	err := d.deleteTeam("team:1")
+	assert.Equal(t, errors.BadInput, err.GetType())
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -18,9 +18,11 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"net/http"
 
 	"github.com/gocarina/gocsv"
@@ -69,6 +71,7 @@ func (h *Handlers) GetTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceO
 // @Tags 		 plugins/org
 // @Accept       multipart/form-data
 // @Param        file formData file true "select file to upload"
+// @Param        merge query bool false "upsert the records in the file instead of replacing all existing ones"
 // @Produce      json
 // @Success      200
 // @Failure 400  {object} shared.ApiBody "Bad Request"
@@ -85,6 +88,13 @@ func (h *Handlers) CreateTeam(input *plugin.ApiResourceInput) (*plugin.ApiResour
 	for _, tm := range t.toDomainLayer(tt) {
 		items = append(items, tm)
 	}
+	if input.Query.Get("merge") == "true" {
+		err = h.store.upsert(items)
+		if err != nil {
+			return nil, err
+		}
+		return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+	}
 	err = h.store.deleteAll(&crossdomain.Team{})
 	if err != nil {
 		return nil, err
@@ -95,3 +105,129 @@ func (h *Handlers) CreateTeam(input *plugin.ApiResourceInput) (*plugin.ApiResour
 	}
 	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
 }
+
+// ListTeams returns all teams
+// @Summary      List teams
+// @Description  list teams
+// @Tags 		 plugins/org
+// @Produce      json
+// @Success      200  {object} []team
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams [get]
+func (h *Handlers) ListTeams(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	teams, err := h.store.findAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: teams, Status: http.StatusOK}, nil
+}
+
+// GetOneTeam returns a single team
+// @Summary      Get team
+// @Description  get a team
+// @Tags 		 plugins/org
+// @Produce      json
+// @Success      200  {object} team
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [get]
+func (h *Handlers) GetOneTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	t, err := h.store.findTeamById(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: t, Status: http.StatusOK}, nil
+}
+
+// PostTeam creates a single team
+// @Summary      Create team
+// @Description  create a team, e.g. {"id": "t1", "name": "Platform", "alias": "PF", "parentId": "t0", "sortingIndex": 1}
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Produce      json
+// @Success      200  {object} team
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams [post]
+func (h *Handlers) PostTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var t team
+	err := helper.Decode(input.Body, &t, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode team")
+	}
+	if t.Id == "" || t.Name == "" {
+		return nil, errors.BadInput.New("id and name are required")
+	}
+	_, err = h.store.findTeamById(t.Id)
+	if err == nil {
+		return nil, errors.BadInput.New(fmt.Sprintf("team %s already exists", t.Id))
+	}
+	if err.GetType() != errors.NotFound {
+		return nil, err
+	}
+	return h.saveTeam(&t)
+}
+
+// PatchTeam updates the fields of a team given in the body
+// @Summary      Patch team
+// @Description  update a team, e.g. {"parentId": "t2"}
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Produce      json
+// @Success      200  {object} team
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [patch]
+func (h *Handlers) PatchTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	t, err := h.store.findTeamById(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	err = helper.Decode(input.Body, t, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode team")
+	}
+	t.Id = input.Params["teamId"]
+	return h.saveTeam(t)
+}
+
+// DeleteTeam deletes a team along with its memberships, teams having sub teams can not be deleted
+// @Summary      Delete team
+// @Description  delete a team along with its memberships
+// @Tags 		 plugins/org
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [delete]
+func (h *Handlers) DeleteTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	t, err := h.store.findTeamById(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.deleteTeam(t.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: t, Status: http.StatusOK}, nil
+}
+
+func (h *Handlers) saveTeam(t *team) (*plugin.ApiResourceOutput, errors.Error) {
+	// walk up the parents to make sure they exist and the team is not its own ancestor
+	for parentId := t.ParentId; parentId != ""; {
+		if parentId == t.Id {
+			return nil, errors.BadInput.New(fmt.Sprintf("team %s can not be a sub team of itself", t.Id))
+		}
+		parent, err := h.store.findTeamById(parentId)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid parent of team %s", t.Id))
+		}
+		parentId = parent.ParentId
+	}
+	err := h.store.saveOne(t.toDomainLayer([]team{*t})[0])
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: t, Status: http.StatusOK}, nil
+}
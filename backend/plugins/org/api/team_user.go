@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// ListTeamUsers returns the members of a team
+// @Summary      List team members
+// @Description  list the users of a team
+// @Tags 		 plugins/org
+// @Produce      json
+// @Success      200  {object} []user
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/users [get]
+func (h *Handlers) ListTeamUsers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	t, err := h.store.findTeamById(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	users, err := h.store.findTeamUsers(t.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: users, Status: http.StatusOK}, nil
+}
+
+// PostTeamUser adds a user to a team
+// @Summary      Add team member
+// @Description  add a user to a team, e.g. {"userId": "u1"}
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Produce      json
+// @Success      200  {object} teamUser
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/users [post]
+func (h *Handlers) PostTeamUser(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	userId, _ := input.Body["userId"].(string)
+	if userId == "" {
+		return nil, errors.BadInput.New("userId is required")
+	}
+	t, err := h.store.findTeamById(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	_, err = h.store.findUserById(userId)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid member of team %s", t.Id))
+	}
+	err = h.store.saveOne(&crossdomain.TeamUser{TeamId: t.Id, UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: teamUser{TeamId: t.Id, UserId: userId}, Status: http.StatusOK}, nil
+}
+
+// DeleteTeamUser removes a user from a team
+// @Summary      Remove team member
+// @Description  remove a user from a team
+// @Tags 		 plugins/org
+// @Success      200
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/users/{userId} [delete]
+func (h *Handlers) DeleteTeamUser(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	t, err := h.store.findTeamById(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.deleteTeamUser(t.Id, input.Params["userId"])
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
@@ -76,10 +76,10 @@ var fakeProjectMapping = []projectMapping{
 }
 
 type user struct {
-	Id      string
-	Name    string
-	Email   string
-	TeamIds string
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	TeamIds string `json:"teamIds"`
 }
 
 func (*user) fromDomainLayer(users []crossdomain.User, teamUsers []crossdomain.TeamUser) []user {
@@ -119,6 +119,16 @@ func (*user) toDomainLayer(uu []user) (users []*crossdomain.User, teamUsers []*c
 	return
 }
 
+func (u *user) teamIds() []string {
+	var teamIds []string
+	for _, teamId := range strings.Split(u.TeamIds, ";") {
+		if teamId != "" {
+			teamIds = append(teamIds, teamId)
+		}
+	}
+	return teamIds
+}
+
 func (*user) fakeData() []user {
 	return fakeUsers
 }
@@ -202,12 +212,25 @@ func (au *userAccount) fromDomainLayer(accountUsers []crossdomain.UserAccount) [
 	return result
 }
 
+type userAccountSuggestion struct {
+	AccountId       string  `json:"accountId"`
+	AccountUserName string  `json:"accountUserName"`
+	AccountFullName string  `json:"accountFullName"`
+	AccountEmail    string  `json:"accountEmail"`
+	UserId          string  `json:"userId"`
+	UserName        string  `json:"userName"`
+	UserEmail       string  `json:"userEmail"`
+	Confidence      float64 `json:"confidence"`
+	Reasons         string  `json:"reasons"`
+	Status          string  `json:"status"`
+}
+
 type team struct {
-	Id           string
-	Name         string
-	Alias        string
-	ParentId     string
-	SortingIndex int
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	Alias        string `json:"alias"`
+	ParentId     string `json:"parentId"`
+	SortingIndex int    `json:"sortingIndex"`
 }
 
 func (*team) fromDomainLayer(tt []crossdomain.Team) []team {
@@ -242,6 +265,11 @@ func (*team) fakeData() []team {
 	return fakeTeams
 }
 
+type teamUser struct {
+	TeamId string `json:"teamId"`
+	UserId string `json:"userId"`
+}
+
 type projectMapping struct {
 	ProjectName string
 	Table       string
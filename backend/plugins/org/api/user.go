@@ -18,9 +18,11 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"net/http"
 
 	"github.com/gocarina/gocsv"
@@ -68,6 +70,7 @@ func (h *Handlers) GetUser(input *plugin.ApiResourceInput) (*plugin.ApiResourceO
 // @Tags 		 plugins/org
 // @Accept       multipart/form-data
 // @Param        file formData file true "select file to upload"
+// @Param        merge query bool false "upsert the records in the file instead of replacing all existing ones"
 // @Produce      json
 // @Success      200
 // @Failure 400  {object} shared.ApiBody "Bad Request"
@@ -88,6 +91,13 @@ func (h *Handlers) CreateUser(input *plugin.ApiResourceInput) (*plugin.ApiResour
 	for _, teamUser := range teamUsers {
 		items = append(items, teamUser)
 	}
+	if input.Query.Get("merge") == "true" {
+		err = h.store.upsert(items)
+		if err != nil {
+			return nil, err
+		}
+		return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+	}
 	err = h.store.deleteAll(&crossdomain.User{})
 	if err != nil {
 		return nil, err
@@ -102,3 +112,128 @@ func (h *Handlers) CreateUser(input *plugin.ApiResourceInput) (*plugin.ApiResour
 	}
 	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
 }
+
+// ListUsers returns all users
+// @Summary      List users
+// @Description  list users along with the ids of their teams
+// @Tags 		 plugins/org
+// @Produce      json
+// @Success      200  {object} []user
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/users [get]
+func (h *Handlers) ListUsers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	users, err := h.store.findAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: users, Status: http.StatusOK}, nil
+}
+
+// GetOneUser returns a single user
+// @Summary      Get user
+// @Description  get a user along with the ids of its teams
+// @Tags 		 plugins/org
+// @Produce      json
+// @Success      200  {object} user
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/users/{userId} [get]
+func (h *Handlers) GetOneUser(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	u, err := h.store.findUserById(input.Params["userId"])
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: u, Status: http.StatusOK}, nil
+}
+
+// PostUser creates a single user, teamIds is a ";" separated list like in users.csv
+// @Summary      Create user
+// @Description  create a user, e.g. {"id": "u1", "name": "John Doe", "email": "john@example.com", "teamIds": "t1;t2"}
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Produce      json
+// @Success      200  {object} user
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/users [post]
+func (h *Handlers) PostUser(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var u user
+	err := helper.Decode(input.Body, &u, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode user")
+	}
+	if u.Id == "" || u.Name == "" {
+		return nil, errors.BadInput.New("id and name are required")
+	}
+	_, err = h.store.findUserById(u.Id)
+	if err == nil {
+		return nil, errors.BadInput.New(fmt.Sprintf("user %s already exists", u.Id))
+	}
+	if err.GetType() != errors.NotFound {
+		return nil, err
+	}
+	return h.saveUser(&u)
+}
+
+// PatchUser updates the fields of a user given in the body, memberships are replaced when teamIds is given
+// @Summary      Patch user
+// @Description  update a user, e.g. {"email": "john@example.com"}
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Produce      json
+// @Success      200  {object} user
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/users/{userId} [patch]
+func (h *Handlers) PatchUser(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	u, err := h.store.findUserById(input.Params["userId"])
+	if err != nil {
+		return nil, err
+	}
+	err = helper.Decode(input.Body, u, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode user")
+	}
+	u.Id = input.Params["userId"]
+	return h.saveUser(u)
+}
+
+// DeleteUser deletes a user along with its team memberships and account links
+// @Summary      Delete user
+// @Description  delete a user along with its team memberships and account links
+// @Tags 		 plugins/org
+// @Success      200
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/users/{userId} [delete]
+func (h *Handlers) DeleteUser(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	u, err := h.store.findUserById(input.Params["userId"])
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.deleteUser(u.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: u, Status: http.StatusOK}, nil
+}
+
+func (h *Handlers) saveUser(u *user) (*plugin.ApiResourceOutput, errors.Error) {
+	teamIds := u.teamIds()
+	for _, teamId := range teamIds {
+		if _, err := h.store.findTeamById(teamId); err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid team of user %s", u.Id))
+		}
+	}
+	users, _ := u.toDomainLayer([]user{*u})
+	err := h.store.saveOne(users[0])
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.replaceUserTeams(u.Id, teamIds)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: u, Status: http.StatusOK}, nil
+}
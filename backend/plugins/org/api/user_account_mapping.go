@@ -60,6 +60,7 @@ func (h *Handlers) GetUserAccountMapping(input *plugin.ApiResourceInput) (*plugi
 // @Tags 		 plugins/org
 // @Accept       multipart/form-data
 // @Param        file formData file true "select file to upload"
+// @Param        merge query bool false "upsert the records in the file instead of replacing all existing ones"
 // @Produce      json
 // @Success      200
 // @Failure 400  {object} shared.ApiBody "Bad Request"
@@ -77,6 +78,13 @@ func (h *Handlers) CreateUserAccountMapping(input *plugin.ApiResourceInput) (*pl
 	for _, userAccount := range userAccounts {
 		items = append(items, userAccount)
 	}
+	if input.Query.Get("merge") == "true" {
+		err = h.store.upsert(items)
+		if err != nil {
+			return nil, err
+		}
+		return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+	}
 	err = h.store.deleteAll(&crossdomain.UserAccount{})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+type suggestionReview struct {
+	AccountId string `json:"accountId" mapstructure:"accountId"`
+	UserId    string `json:"userId" mapstructure:"userId"`
+	Accept    bool   `json:"accept" mapstructure:"accept"`
+}
+
+type suggestionReviewRequest struct {
+	Reviews []suggestionReview `json:"reviews" mapstructure:"reviews"`
+}
+
+// GetUserAccountSuggestions returns the user/account links suggested by the fuzzy identity resolution
+// @Summary      Get user account suggestions
+// @Description  get user/account links suggested by the fuzzy identity resolution, ordered by confidence
+// @Tags 		 plugins/org
+// @Param        status query string false "PENDING (default), ACCEPTED or REJECTED"
+// @Produce      json
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/user_account_suggestions [get]
+func (h *Handlers) GetUserAccountSuggestions(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	status := strings.ToUpper(input.Query.Get("status"))
+	switch status {
+	case "":
+		status = models.SuggestionPending
+	case models.SuggestionPending, models.SuggestionAccepted, models.SuggestionRejected:
+	default:
+		return nil, errors.BadInput.New("status must be one of PENDING, ACCEPTED or REJECTED")
+	}
+	suggestions, err := h.store.findUserAccountSuggestions(status)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: suggestions, Status: http.StatusOK}, nil
+}
+
+// ReviewUserAccountSuggestions accepts or rejects suggested user/account links, accepted ones are saved into user_accounts
+// @Summary      Review user account suggestions
+// @Description  accept or reject suggested user/account links, e.g. {"reviews": [{"accountId": "github:GithubAccount:1:1", "userId": "u1", "accept": true}]}
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Produce      json
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/user_account_suggestions [post]
+func (h *Handlers) ReviewUserAccountSuggestions(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var request suggestionReviewRequest
+	err := helper.Decode(input.Body, &request, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode reviews")
+	}
+	for _, review := range request.Reviews {
+		if review.AccountId == "" || review.UserId == "" {
+			return nil, errors.BadInput.New("accountId and userId are required")
+		}
+		err = h.store.reviewUserAccountSuggestion(review.AccountId, review.UserId, review.Accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
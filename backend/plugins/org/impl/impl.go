@@ -24,6 +24,8 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/org/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/apache/incubator-devlake/plugins/org/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/org/tasks"
 )
 
@@ -31,6 +33,7 @@ var _ plugin.PluginMeta = (*Org)(nil)
 var _ plugin.PluginInit = (*Org)(nil)
 var _ plugin.PluginTask = (*Org)(nil)
 var _ plugin.PluginModel = (*Org)(nil)
+var _ plugin.PluginMigration = (*Org)(nil)
 
 type Org struct {
 	handlers *api.Handlers
@@ -42,7 +45,10 @@ func (p *Org) Init(basicRes context.BasicRes) errors.Error {
 }
 
 func (p Org) GetTablesInfo() []dal.Tabler {
-	return []dal.Tabler{}
+	return []dal.Tabler{
+		&models.UserAccountSuggestion{},
+		&models.DirectoryConnection{},
+	}
 }
 
 func (p Org) Description() string {
@@ -51,7 +57,9 @@ func (p Org) Description() string {
 
 func (p Org) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
+		tasks.SyncDirectoryMeta,
 		tasks.ConnectUserAccountsExactMeta,
+		tasks.ConnectUserAccountsFuzzyMeta,
 	}
 }
 
@@ -61,9 +69,24 @@ func (p Org) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]inte
 	if err != nil {
 		return nil, errors.BadInput.Wrap(err, "could not decode options")
 	}
+	if op.MinConfidence == 0 {
+		op.MinConfidence = tasks.DefaultMinConfidence
+	}
 	taskData := &tasks.TaskData{
 		Options: &op,
 	}
+	if op.ConnectionId != 0 {
+		connectionHelper := helper.NewConnectionHelper(
+			taskCtx,
+			nil,
+		)
+		connection := &models.DirectoryConnection{}
+		err = connectionHelper.FirstById(connection, op.ConnectionId)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "unable to get directory connection by the given connection ID")
+		}
+		taskData.Connection = connection
+	}
 	return taskData, nil
 }
 
@@ -71,8 +94,49 @@ func (p Org) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/org"
 }
 
+func (p Org) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
 func (p Org) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": p.handlers.TestDirectoryConnection,
+		},
+		"connections": {
+			"POST": p.handlers.PostDirectoryConnections,
+			"GET":  p.handlers.ListDirectoryConnections,
+		},
+		"connections/:connectionId": {
+			"GET":    p.handlers.GetDirectoryConnection,
+			"PATCH":  p.handlers.PatchDirectoryConnection,
+			"DELETE": p.handlers.DeleteDirectoryConnection,
+		},
+		"users": {
+			"GET":  p.handlers.ListUsers,
+			"POST": p.handlers.PostUser,
+		},
+		"users/:userId": {
+			"GET":    p.handlers.GetOneUser,
+			"PATCH":  p.handlers.PatchUser,
+			"DELETE": p.handlers.DeleteUser,
+		},
+		"teams": {
+			"GET":  p.handlers.ListTeams,
+			"POST": p.handlers.PostTeam,
+		},
+		"teams/:teamId": {
+			"GET":    p.handlers.GetOneTeam,
+			"PATCH":  p.handlers.PatchTeam,
+			"DELETE": p.handlers.DeleteTeam,
+		},
+		"teams/:teamId/users": {
+			"GET":  p.handlers.ListTeamUsers,
+			"POST": p.handlers.PostTeamUser,
+		},
+		"teams/:teamId/users/:userId": {
+			"DELETE": p.handlers.DeleteTeamUser,
+		},
 		"teams.csv": {
 			"GET": p.handlers.GetTeam,
 			"PUT": p.handlers.CreateTeam,
@@ -86,6 +150,10 @@ func (p Org) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": p.handlers.GetUserAccountMapping,
 			"PUT": p.handlers.CreateUserAccountMapping,
 		},
+		"user_account_suggestions": {
+			"GET":  p.handlers.GetUserAccountSuggestions,
+			"POST": p.handlers.ReviewUserAccountSuggestions,
+		},
 		"project_mapping.csv": {
 			"GET": p.handlers.GetProjectMapping,
 			"PUT": p.handlers.CreateProjectMapping,
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	DirectoryTypeLdap = "ldap"
+	DirectoryTypeScim = "scim"
+)
+
+// DirectoryConnection points to an LDAP server or a SCIM 2.0 endpoint which users and teams are synchronized from
+type DirectoryConnection struct {
+	helper.BaseConnection `mapstructure:",squash"`
+	Type                  string `mapstructure:"type" json:"type" validate:"required,oneof=ldap scim" gorm:"type:varchar(20)"`
+	// Endpoint is either an ldap url, i.e. ldaps://ldap.example.com:636, or the SCIM base url, i.e. https://example.com/scim/v2
+	Endpoint string `mapstructure:"endpoint" json:"endpoint" validate:"required" gorm:"type:varchar(255)"`
+	// BindDn and Password are used to bind to the ldap server, an anonymous bind is made when BindDn is empty
+	BindDn   string `mapstructure:"bindDn" json:"bindDn" gorm:"type:varchar(255)"`
+	Password string `mapstructure:"password" json:"password" gorm:"serializer:encdec"`
+	// Token is the bearer token of the SCIM endpoint
+	Token string `mapstructure:"token" json:"token" gorm:"serializer:encdec"`
+	// BaseDn and the fields below only apply to ldap, empty ones fall back to the defaults of the ldap reader
+	BaseDn             string `mapstructure:"baseDn" json:"baseDn" gorm:"type:varchar(255)"`
+	UserFilter         string `mapstructure:"userFilter" json:"userFilter" gorm:"type:varchar(255)"`
+	GroupFilter        string `mapstructure:"groupFilter" json:"groupFilter" gorm:"type:varchar(255)"`
+	UserIdAttribute    string `mapstructure:"userIdAttribute" json:"userIdAttribute" gorm:"type:varchar(100)"`
+	UserNameAttribute  string `mapstructure:"userNameAttribute" json:"userNameAttribute" gorm:"type:varchar(100)"`
+	EmailAttribute     string `mapstructure:"emailAttribute" json:"emailAttribute" gorm:"type:varchar(100)"`
+	GroupNameAttribute string `mapstructure:"groupNameAttribute" json:"groupNameAttribute" gorm:"type:varchar(100)"`
+	MemberAttribute    string `mapstructure:"memberAttribute" json:"memberAttribute" gorm:"type:varchar(100)"`
+}
+
+var _ apihelperabstract.ConnectionValidator = (*DirectoryConnection)(nil)
+
+func (DirectoryConnection) TableName() string {
+	return "_tool_org_directory_connections"
+}
+
+// ValidateConnection makes sure the settings required by the directory type are present
+func (c *DirectoryConnection) ValidateConnection(connection interface{}, vld *validator.Validate) errors.Error {
+	if vld != nil {
+		if err := vld.Struct(connection); err != nil {
+			return errors.BadInput.Wrap(err, "error validating target")
+		}
+	}
+	if c.Type == DirectoryTypeLdap && c.BaseDn == "" {
+		return errors.BadInput.New("baseDn is required for ldap directories")
+	}
+	if c.Type == DirectoryTypeScim && c.Token == "" {
+		return errors.BadInput.New("token is required for scim directories")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	gocontext "context"
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+// DirectoryUser is a user read from a directory, Id is unique within the directory
+type DirectoryUser struct {
+	Id    string
+	Name  string
+	Email string
+}
+
+// DirectoryGroup is a group read from a directory, members are referred by their Ids
+type DirectoryGroup struct {
+	Id       string
+	Name     string
+	UserIds  []string
+	GroupIds []string
+}
+
+// DirectoryReader reads all users and groups from a directory
+type DirectoryReader interface {
+	Read() ([]DirectoryUser, []DirectoryGroup, errors.Error)
+	Close()
+}
+
+// NewDirectoryReader creates the reader matching the type of the connection
+func NewDirectoryReader(ctx gocontext.Context, basicRes context.BasicRes, connection *models.DirectoryConnection) (DirectoryReader, errors.Error) {
+	switch connection.Type {
+	case models.DirectoryTypeLdap:
+		return newLdapReader(basicRes, connection)
+	case models.DirectoryTypeScim:
+		return newScimReader(ctx, basicRes, connection)
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unsupported directory type %s", connection.Type))
+}
@@ -0,0 +1,180 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	defaultLdapUserFilter         = "(|(objectClass=person)(objectClass=inetOrgPerson))"
+	defaultLdapGroupFilter        = "(|(objectClass=groupOfNames)(objectClass=groupOfUniqueNames)(objectClass=posixGroup))"
+	defaultLdapUserIdAttribute    = "uid"
+	defaultLdapUserNameAttribute  = "cn"
+	defaultLdapEmailAttribute     = "mail"
+	defaultLdapGroupNameAttribute = "cn"
+	defaultLdapMemberAttribute    = "member"
+	ldapPageSize                  = 500
+)
+
+// ldapSearcher is the part of *ldap.Conn used by the reader, so it can be replaced by a stand-in in tests
+type ldapSearcher interface {
+	SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error)
+	Close()
+}
+
+type ldapReader struct {
+	connection *models.DirectoryConnection
+	searcher   ldapSearcher
+}
+
+func newLdapReader(basicRes context.BasicRes, connection *models.DirectoryConnection) (*ldapReader, errors.Error) {
+	insecureSkipVerify, err := utils.StrToBoolOr(basicRes.GetConfig("IN_SECURE_SKIP_VERIFY"), false)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to parse IN_SECURE_SKIP_VERIFY")
+	}
+	conn, e := ldap.DialURL(connection.Endpoint, ldap.DialWithTLSConfig(&tls.Config{InsecureSkipVerify: insecureSkipVerify}))
+	if e != nil {
+		return nil, errors.Default.Wrap(e, fmt.Sprintf("failed to connect to %s", connection.Endpoint))
+	}
+	if connection.BindDn != "" {
+		e = conn.Bind(connection.BindDn, connection.Password)
+	} else {
+		e = conn.UnauthenticatedBind("")
+	}
+	if e != nil {
+		conn.Close()
+		return nil, errors.Unauthorized.Wrap(e, "failed to bind to the ldap server")
+	}
+	return &ldapReader{connection: connection, searcher: conn}, nil
+}
+
+func (r *ldapReader) Read() ([]DirectoryUser, []DirectoryGroup, errors.Error) {
+	c := r.connection
+	idAttribute := orDefault(c.UserIdAttribute, defaultLdapUserIdAttribute)
+	nameAttribute := orDefault(c.UserNameAttribute, defaultLdapUserNameAttribute)
+	emailAttribute := orDefault(c.EmailAttribute, defaultLdapEmailAttribute)
+	groupNameAttribute := orDefault(c.GroupNameAttribute, defaultLdapGroupNameAttribute)
+	memberAttribute := orDefault(c.MemberAttribute, defaultLdapMemberAttribute)
+
+	userEntries, err := r.search(orDefault(c.UserFilter, defaultLdapUserFilter), idAttribute, nameAttribute, emailAttribute)
+	if err != nil {
+		return nil, nil, err
+	}
+	var users []DirectoryUser
+	userIds := make(map[string]string, len(userEntries))
+	for _, entry := range userEntries {
+		user := DirectoryUser{
+			Id:    entry.GetEqualFoldAttributeValue(idAttribute),
+			Name:  entry.GetEqualFoldAttributeValue(nameAttribute),
+			Email: entry.GetEqualFoldAttributeValue(emailAttribute),
+		}
+		if user.Id == "" {
+			user.Id = entry.DN
+		}
+		userIds[normalizeDn(entry.DN)] = user.Id
+		userIds[strings.ToLower(user.Id)] = user.Id
+		users = append(users, user)
+	}
+
+	groupEntries, err := r.search(orDefault(c.GroupFilter, defaultLdapGroupFilter), groupNameAttribute, memberAttribute, "uniqueMember", "memberUid")
+	if err != nil {
+		return nil, nil, err
+	}
+	groupIds := make(map[string]bool, len(groupEntries))
+	for _, entry := range groupEntries {
+		groupIds[normalizeDn(entry.DN)] = true
+	}
+	var groups []DirectoryGroup
+	for _, entry := range groupEntries {
+		group := DirectoryGroup{
+			Id:   normalizeDn(entry.DN),
+			Name: entry.GetEqualFoldAttributeValue(groupNameAttribute),
+		}
+		var members []string
+		for _, attribute := range []string{memberAttribute, "uniqueMember", "memberUid"} {
+			members = append(members, entry.GetEqualFoldAttributeValues(attribute)...)
+		}
+		seen := make(map[string]bool, len(members))
+		for _, member := range members {
+			// members are DNs, except for posixGroup whose memberUid holds the user ids
+			key := normalizeDn(member)
+			if !strings.Contains(member, "=") {
+				key = strings.ToLower(strings.TrimSpace(member))
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if groupIds[key] {
+				group.GroupIds = append(group.GroupIds, key)
+			} else if userId, ok := userIds[key]; ok {
+				group.UserIds = append(group.UserIds, userId)
+			}
+		}
+		groups = append(groups, group)
+	}
+	return users, groups, nil
+}
+
+func (r *ldapReader) Close() {
+	r.searcher.Close()
+}
+
+func (r *ldapReader) search(filter string, attributes ...string) ([]*ldap.Entry, errors.Error) {
+	request := ldap.NewSearchRequest(
+		r.connection.BaseDn,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		attributes,
+		nil,
+	)
+	result, err := r.searcher.SearchWithPaging(request, ldapPageSize)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to search %s in %s", filter, r.connection.BaseDn))
+	}
+	return result.Entries, nil
+}
+
+// normalizeDn makes DNs comparable, i.e. "CN=Dev Team, OU=Groups" and "cn=dev team,ou=groups"
+func normalizeDn(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.ToLower(strings.Join(parts, ","))
+}
+
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
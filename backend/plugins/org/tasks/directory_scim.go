@@ -0,0 +1,166 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	gocontext "context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+const scimPageSize = 100
+
+type scimListResponse[T any] struct {
+	TotalResults int `json:"totalResults"`
+	ItemsPerPage int `json:"itemsPerPage"`
+	StartIndex   int `json:"startIndex"`
+	Resources    []T `json:"Resources"`
+}
+
+type scimUser struct {
+	Id          string `json:"id"`
+	UserName    string `json:"userName"`
+	DisplayName string `json:"displayName"`
+	Name        struct {
+		Formatted  string `json:"formatted"`
+		GivenName  string `json:"givenName"`
+		FamilyName string `json:"familyName"`
+	} `json:"name"`
+	Emails []struct {
+		Value   string `json:"value"`
+		Primary bool   `json:"primary"`
+	} `json:"emails"`
+	Active *bool `json:"active"`
+}
+
+type scimGroup struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Members     []struct {
+		Value string `json:"value"`
+		Type  string `json:"type"`
+	} `json:"members"`
+}
+
+type scimReader struct {
+	client *helper.ApiClient
+}
+
+func newScimReader(ctx gocontext.Context, basicRes context.BasicRes, connection *models.DirectoryConnection) (*scimReader, errors.Error) {
+	client, err := helper.NewApiClient(
+		ctx,
+		connection.Endpoint,
+		map[string]string{
+			"Authorization": fmt.Sprintf("Bearer %s", connection.Token),
+			"Accept":        "application/scim+json, application/json",
+		},
+		0,
+		"",
+		basicRes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &scimReader{client: client}, nil
+}
+
+func (r *scimReader) Read() ([]DirectoryUser, []DirectoryGroup, errors.Error) {
+	scimUsers, err := scimList[scimUser](r.client, "Users")
+	if err != nil {
+		return nil, nil, err
+	}
+	var users []DirectoryUser
+	userIds := make(map[string]bool, len(scimUsers))
+	for _, u := range scimUsers {
+		if u.Active != nil && !*u.Active {
+			continue
+		}
+		user := DirectoryUser{Id: u.Id, Name: u.DisplayName}
+		if user.Name == "" {
+			user.Name = u.Name.Formatted
+		}
+		if user.Name == "" {
+			user.Name = strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+		}
+		if user.Name == "" {
+			user.Name = u.UserName
+		}
+		for _, email := range u.Emails {
+			if user.Email == "" || email.Primary {
+				user.Email = email.Value
+			}
+		}
+		userIds[user.Id] = true
+		users = append(users, user)
+	}
+
+	scimGroups, err := scimList[scimGroup](r.client, "Groups")
+	if err != nil {
+		return nil, nil, err
+	}
+	var groups []DirectoryGroup
+	for _, g := range scimGroups {
+		group := DirectoryGroup{Id: g.Id, Name: g.DisplayName}
+		for _, member := range g.Members {
+			if strings.EqualFold(member.Type, "Group") {
+				group.GroupIds = append(group.GroupIds, member.Value)
+			} else if userIds[member.Value] {
+				group.UserIds = append(group.UserIds, member.Value)
+			}
+		}
+		groups = append(groups, group)
+	}
+	return users, groups, nil
+}
+
+func (r *scimReader) Close() {}
+
+// scimList walks through the pages of a SCIM list endpoint, pages are addressed by the 1-based startIndex
+func scimList[T any](client *helper.ApiClient, path string) ([]T, errors.Error) {
+	var resources []T
+	for startIndex := 1; ; {
+		query := url.Values{}
+		query.Set("startIndex", fmt.Sprintf("%d", startIndex))
+		query.Set("count", fmt.Sprintf("%d", scimPageSize))
+		res, err := client.Get(path, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("unexpected status code %d when listing scim %s", res.StatusCode, path))
+		}
+		var page scimListResponse[T]
+		err = helper.UnmarshalResponse(res, &page)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, page.Resources...)
+		startIndex += len(page.Resources)
+		if len(page.Resources) == 0 || startIndex > page.TotalResults {
+			return resources, nil
+		}
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+var SyncDirectoryMeta = plugin.SubTaskMeta{
+	Name:             "syncDirectory",
+	EntryPoint:       SyncDirectory,
+	EnabledByDefault: true,
+	Description:      "import users and teams from the ldap or scim directory of the connection",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// SyncDirectory replaces the users, teams and team_users previously imported from the directory with its
+// current content, records created by the csv files or the api are left untouched
+func SyncDirectory(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TaskData)
+	if data.Connection == nil {
+		taskCtx.GetLogger().Info("no directory connection, skip")
+		return nil
+	}
+	reader, err := NewDirectoryReader(taskCtx.GetContext(), taskCtx, data.Connection)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	directoryUsers, directoryGroups, err := reader.Read()
+	if err != nil {
+		return err
+	}
+	users, teams, teamUsers := directoryToDomainLayer(data.Options.ConnectionId, directoryUsers, directoryGroups)
+
+	params, e := json.Marshal(Params{ConnectionId: data.Options.ConnectionId})
+	if e != nil {
+		return errors.Convert(e)
+	}
+	origin := common.RawDataOrigin{
+		RawDataTable:  models.DirectoryConnection{}.TableName(),
+		RawDataParams: string(params),
+	}
+	divider := api.NewBatchSaveDivider(taskCtx, 500, origin.RawDataTable, origin.RawDataParams)
+	// acquiring the batches deletes the records of the previous sync, even if the directory turns out empty
+	var items []interface{}
+	for _, user := range users {
+		user.RawDataOrigin = origin
+		items = append(items, user)
+	}
+	for _, team := range teams {
+		team.RawDataOrigin = origin
+		items = append(items, team)
+	}
+	for _, teamUser := range teamUsers {
+		teamUser.RawDataOrigin = origin
+		items = append(items, teamUser)
+	}
+	for _, rowType := range []interface{}{&crossdomain.User{}, &crossdomain.Team{}, &crossdomain.TeamUser{}} {
+		_, err = divider.ForType(reflect.TypeOf(rowType))
+		if err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		batch, err := divider.ForType(reflect.TypeOf(item))
+		if err != nil {
+			return err
+		}
+		err = batch.Add(item)
+		if err != nil {
+			return err
+		}
+	}
+	// the remaining records are flushed on closing
+	err = divider.Close()
+	if err != nil {
+		return err
+	}
+	taskCtx.GetLogger().Info("synced %d users and %d teams from %s", len(users), len(teams), data.Connection.Name)
+	return nil
+}
+
+// directoryToDomainLayer turns directory groups into teams, a group nested in another one gets it as parent team
+func directoryToDomainLayer(
+	connectionId uint64,
+	directoryUsers []DirectoryUser,
+	directoryGroups []DirectoryGroup,
+) (users []*crossdomain.User, teams []*crossdomain.Team, teamUsers []*crossdomain.TeamUser) {
+	for _, u := range directoryUsers {
+		users = append(users, &crossdomain.User{
+			DomainEntity: domainlayer.DomainEntity{Id: directoryDomainId("DirectoryUser", connectionId, u.Id)},
+			Name:         u.Name,
+			Email:        u.Email,
+		})
+	}
+	parents := make(map[string]string)
+	for _, g := range directoryGroups {
+		for _, child := range g.GroupIds {
+			if _, ok := parents[child]; !ok && child != g.Id {
+				parents[child] = g.Id
+			}
+		}
+	}
+	for i, g := range directoryGroups {
+		team := &crossdomain.Team{
+			DomainEntity: domainlayer.DomainEntity{Id: directoryDomainId("DirectoryGroup", connectionId, g.Id)},
+			Name:         g.Name,
+			SortingIndex: i,
+		}
+		if parent, ok := parents[g.Id]; ok {
+			team.ParentId = directoryDomainId("DirectoryGroup", connectionId, parent)
+		}
+		teams = append(teams, team)
+		for _, userId := range g.UserIds {
+			teamUsers = append(teamUsers, &crossdomain.TeamUser{
+				TeamId: team.Id,
+				UserId: directoryDomainId("DirectoryUser", connectionId, userId),
+			})
+		}
+	}
+	return
+}
+
+func directoryDomainId(entity string, connectionId uint64, id string) string {
+	return fmt.Sprintf("org:%s:%d:%s", entity, connectionId, id)
+}
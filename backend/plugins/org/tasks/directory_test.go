@@ -0,0 +1,158 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	gocontext "context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLdap is a stand-in of the ldap server answering searches by their filter
+type fakeLdap map[string][]*ldap.Entry
+
+func (f fakeLdap) SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error) {
+	return &ldap.SearchResult{Entries: f[searchRequest.Filter]}, nil
+}
+
+func (f fakeLdap) Close() {}
+
+func TestLdapReader(t *testing.T) {
+	reader := &ldapReader{
+		connection: &models.DirectoryConnection{Type: models.DirectoryTypeLdap, BaseDn: "dc=example,dc=com"},
+		searcher: fakeLdap{
+			defaultLdapUserFilter: {
+				ldap.NewEntry("uid=jdoe,ou=people,dc=example,dc=com", map[string][]string{
+					"uid": {"jdoe"}, "cn": {"John Doe"}, "mail": {"john@example.com"},
+				}),
+				ldap.NewEntry("uid=jroe,ou=people,dc=example,dc=com", map[string][]string{
+					"uid": {"jroe"}, "cn": {"Jane Roe"}, "mail": {"jane@example.com"},
+				}),
+			},
+			defaultLdapGroupFilter: {
+				ldap.NewEntry("cn=engineering,ou=groups,dc=example,dc=com", map[string][]string{
+					"cn":     {"Engineering"},
+					"member": {"UID=jroe, OU=people, DC=example, DC=com", "cn=backend,ou=groups,dc=example,dc=com"},
+				}),
+				ldap.NewEntry("cn=backend,ou=groups,dc=example,dc=com", map[string][]string{
+					"cn":        {"Backend"},
+					"memberUid": {"jdoe", "ghost"},
+				}),
+			},
+		},
+	}
+	users, groups, err := reader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, []DirectoryUser{
+		{Id: "jdoe", Name: "John Doe", Email: "john@example.com"},
+		{Id: "jroe", Name: "Jane Roe", Email: "jane@example.com"},
+	}, users)
+	assert.Equal(t, []DirectoryGroup{
+		{
+			Id:       "cn=engineering,ou=groups,dc=example,dc=com",
+			Name:     "Engineering",
+			UserIds:  []string{"jroe"},
+			GroupIds: []string{"cn=backend,ou=groups,dc=example,dc=com"},
+		},
+		{
+			Id:      "cn=backend,ou=groups,dc=example,dc=com",
+			Name:    "Backend",
+			UserIds: []string{"jdoe"},
+		},
+	}, groups)
+}
+
+func TestScimReader(t *testing.T) {
+	// a stand-in of the scim endpoint serving one resource per page
+	users := []string{
+		`{"id": "1", "userName": "jdoe", "name": {"givenName": "John", "familyName": "Doe"}, "emails": [{"value": "jd@example.com"}, {"value": "john@example.com", "primary": true}]}`,
+		`{"id": "2", "userName": "jroe", "displayName": "Jane Roe", "active": false}`,
+		`{"id": "3", "userName": "bob"}`,
+	}
+	groups := []string{
+		`{"id": "g1", "displayName": "Engineering", "members": [{"value": "1", "type": "User"}, {"value": "2", "type": "User"}, {"value": "g2", "type": "Group"}]}`,
+		`{"id": "g2", "displayName": "Backend", "members": [{"value": "3"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		resources := users
+		if strings.HasSuffix(r.URL.Path, "/Groups") {
+			resources = groups
+		}
+		var startIndex int
+		_, _ = fmt.Sscanf(r.URL.Query().Get("startIndex"), "%d", &startIndex)
+		page := ""
+		if startIndex <= len(resources) {
+			page = resources[startIndex-1]
+		}
+		_, _ = fmt.Fprintf(w, `{"totalResults": %d, "startIndex": %d, "itemsPerPage": 1, "Resources": [%s]}`, len(resources), startIndex, page)
+	}))
+	defer server.Close()
+
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetConfig", "IN_SECURE_SKIP_VERIFY").Return("")
+	mockRes.On("GetConfig", "API_CASSETTE_MODE").Return("")
+	mockRes.On("GetConfig", "API_CASSETTE_DIR").Return("")
+	reader, err := NewDirectoryReader(gocontext.Background(), mockRes, &models.DirectoryConnection{
+		Type:     models.DirectoryTypeScim,
+		Endpoint: server.URL + "/scim/v2/",
+		Token:    "secret",
+	})
+	assert.Nil(t, err)
+	defer reader.Close()
+	directoryUsers, directoryGroups, err := reader.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, []DirectoryUser{
+		{Id: "1", Name: "John Doe", Email: "john@example.com"},
+		{Id: "3", Name: "bob"},
+	}, directoryUsers)
+	assert.Equal(t, []DirectoryGroup{
+		{Id: "g1", Name: "Engineering", UserIds: []string{"1"}, GroupIds: []string{"g2"}},
+		{Id: "g2", Name: "Backend", UserIds: []string{"3"}},
+	}, directoryGroups)
+}
+
+func TestDirectoryToDomainLayer(t *testing.T) {
+	users, teams, teamUsers := directoryToDomainLayer(
+		1,
+		[]DirectoryUser{{Id: "1", Name: "John Doe", Email: "john@example.com"}},
+		[]DirectoryGroup{
+			{Id: "g1", Name: "Engineering", GroupIds: []string{"g2"}},
+			{Id: "g2", Name: "Backend", UserIds: []string{"1"}},
+		},
+	)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "org:DirectoryUser:1:1", users[0].Id)
+	assert.Equal(t, "john@example.com", users[0].Email)
+	assert.Len(t, teams, 2)
+	assert.Equal(t, "org:DirectoryGroup:1:g1", teams[0].Id)
+	assert.Equal(t, "", teams[0].ParentId)
+	assert.Equal(t, "org:DirectoryGroup:1:g1", teams[1].ParentId)
+	assert.Equal(t, 1, teams[1].SortingIndex)
+	assert.Len(t, teamUsers, 1)
+	assert.Equal(t, "org:DirectoryGroup:1:g2", teamUsers[0].TeamId)
+	assert.Equal(t, "org:DirectoryUser:1:1", teamUsers[0].UserId)
+}
@@ -17,12 +17,18 @@ limitations under the License.
 
 package tasks
 
+import (
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
 type Options struct {
-	ConnectionId uint64 `json:"connectionId"`
+	ConnectionId  uint64  `json:"connectionId"`
+	MinConfidence float64 `json:"minConfidence"`
 }
 
 type TaskData struct {
-	Options *Options
+	Options    *Options
+	Connection *models.DirectoryConnection
 }
 type Params struct {
 	ConnectionId uint64
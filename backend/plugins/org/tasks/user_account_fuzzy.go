@@ -0,0 +1,471 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+)
+
+var ConnectUserAccountsFuzzyMeta = plugin.SubTaskMeta{
+	Name:             "connectUserAccountsFuzzy",
+	EntryPoint:       ConnectUserAccountsFuzzy,
+	EnabledByDefault: true,
+	Description:      "suggest links between users and accounts by scoring emails, logins, names and co-occurring commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+const (
+	// DefaultMinConfidence is the lowest confidence a suggestion needs to be recorded
+	DefaultMinConfidence = 0.7
+	// minEdgeScore drops weak pairwise evidence before it is propagated
+	minEdgeScore = 0.5
+	// maxBlockSize skips candidate blocks shared by too many identities (e.g. a very common surname)
+	maxBlockSize = 100
+	// minCoCommits is the least number of shared commits for the co-occurrence evidence to count
+	minCoCommits = 3
+	// suggestionBatchSize is the number of suggestions saved by a statement
+	suggestionBatchSize = 500
+)
+
+// coCommit counts the commits authored by a git identity inside the pull requests opened by an account
+type coCommit struct {
+	AccountId string
+	AuthorId  string
+	Commits   int
+}
+
+// identity is a user or an account reduced to the features used for matching
+type identity struct {
+	emails  []string
+	logins  []string
+	noreply []string
+	locals  []string
+	name    string
+}
+
+type identityEdge struct {
+	to      int
+	score   float64
+	reasons []string
+}
+
+func ConnectUserAccountsFuzzy(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*TaskData)
+	var users []crossdomain.User
+	err := db.All(&users)
+	if err != nil {
+		return err
+	}
+	var accounts []crossdomain.Account
+	err = db.All(&accounts)
+	if err != nil {
+		return err
+	}
+	var links []crossdomain.UserAccount
+	err = db.All(&links)
+	if err != nil {
+		return err
+	}
+	var coCommits []coCommit
+	err = db.All(
+		&coCommits,
+		dal.Select("pr.author_id AS account_id, c.author_id AS author_id, COUNT(DISTINCT c.sha) AS commits"),
+		dal.From("pull_requests pr"),
+		dal.Join("JOIN pull_request_commits prc ON prc.pull_request_id = pr.id"),
+		dal.Join("JOIN commits c ON c.sha = prc.commit_sha"),
+		dal.Where("pr.author_id != '' AND c.author_id != ''"),
+		dal.Groupby("pr.author_id, c.author_id"),
+	)
+	if err != nil {
+		return err
+	}
+	var rejected []models.UserAccountSuggestion
+	err = db.All(&rejected, dal.Where("status = ?", models.SuggestionRejected))
+	if err != nil {
+		return err
+	}
+	rejectedPairs := make(map[[2]string]bool, len(rejected))
+	for _, r := range rejected {
+		rejectedPairs[[2]string{r.AccountId, r.UserId}] = true
+	}
+
+	suggestions := suggestUserAccounts(users, accounts, links, coCommits, rejectedPairs, data.Options.MinConfidence)
+	// pending suggestions are recomputed on every run and replaced all or nothing, reviewed ones are kept
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+	err = replacePendingSuggestions(tx, suggestions)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			taskCtx.GetLogger().Error(e, "failed to rollback the transaction of user account suggestions")
+		}
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	taskCtx.GetLogger().Info("suggested %d user account links", len(suggestions))
+	return nil
+}
+
+// replacePendingSuggestions deletes the pending suggestions and saves the new ones in batches
+func replacePendingSuggestions(tx dal.Transaction, suggestions []*models.UserAccountSuggestion) errors.Error {
+	err := tx.Delete(&models.UserAccountSuggestion{}, dal.Where("status = ?", models.SuggestionPending))
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(suggestions); start += suggestionBatchSize {
+		end := start + suggestionBatchSize
+		if end > len(suggestions) {
+			end = len(suggestions)
+		}
+		err = tx.CreateOrUpdate(suggestions[start:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// suggestUserAccounts scores every candidate pair among users and accounts, then propagates the
+// confidence from users and already linked accounts to the unlinked accounts through the scored pairs
+func suggestUserAccounts(
+	users []crossdomain.User,
+	accounts []crossdomain.Account,
+	links []crossdomain.UserAccount,
+	coCommits []coCommit,
+	rejected map[[2]string]bool,
+	minConfidence float64,
+) []*models.UserAccountSuggestion {
+	if minConfidence <= 0 {
+		minConfidence = DefaultMinConfidence
+	}
+	nodes := make([]*identity, 0, len(users)+len(accounts))
+	for _, user := range users {
+		nodes = append(nodes, newIdentity(user.Email, "", user.Name))
+	}
+	accountIndex := make(map[string]int, len(accounts))
+	for _, account := range accounts {
+		accountIndex[account.Id] = len(nodes)
+		nodes = append(nodes, newIdentity(account.Email, account.UserName, account.FullName))
+	}
+
+	// seed the propagation with users and linked accounts
+	confidence := make([]float64, len(nodes))
+	owner := make([]string, len(nodes))
+	reasons := make([][]string, len(nodes))
+	for i, user := range users {
+		confidence[i] = 1
+		owner[i] = user.Id
+	}
+	for _, link := range links {
+		if i, ok := accountIndex[link.AccountId]; ok && link.UserId != "" {
+			confidence[i] = 1
+			owner[i] = link.UserId
+		}
+	}
+
+	edges := buildIdentityEdges(nodes, accountIndex, coCommits)
+	for changed, round := true, 0; changed && round < len(nodes); round++ {
+		changed = false
+		for from, out := range edges {
+			if owner[from] == "" {
+				continue
+			}
+			for _, e := range out {
+				c := confidence[from] * e.score
+				if c <= confidence[e.to] || e.to < len(users) {
+					continue
+				}
+				if rejected[[2]string{accounts[e.to-len(users)].Id, owner[from]}] {
+					continue
+				}
+				confidence[e.to] = c
+				owner[e.to] = owner[from]
+				reasons[e.to] = e.reasons
+				if confidence[from] < 1 {
+					reasons[e.to] = append(append([]string{}, e.reasons...), "transitive")
+				}
+				changed = true
+			}
+		}
+	}
+
+	var suggestions []*models.UserAccountSuggestion
+	for _, account := range accounts {
+		i := accountIndex[account.Id]
+		if confidence[i] >= 1 || owner[i] == "" || confidence[i] < minConfidence {
+			continue
+		}
+		suggestions = append(suggestions, &models.UserAccountSuggestion{
+			AccountId:  account.Id,
+			UserId:     owner[i],
+			Confidence: confidence[i],
+			Reasons:    strings.Join(reasons[i], ","),
+			Status:     models.SuggestionPending,
+		})
+	}
+	return suggestions
+}
+
+// buildIdentityEdges only compares identities sharing a blocking key (email, login or surname)
+// or a co-occurring commit, which keeps the comparison far below the quadratic number of pairs
+func buildIdentityEdges(nodes []*identity, accountIndex map[string]int, coCommits []coCommit) [][]identityEdge {
+	blocks := make(map[string][]int)
+	for i, node := range nodes {
+		for _, key := range node.blockingKeys() {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+	pairs := make(map[[2]int]float64)
+	for _, members := range blocks {
+		if len(members) > maxBlockSize {
+			continue
+		}
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				pair := orderedPair(members[x], members[y])
+				if _, ok := pairs[pair]; !ok {
+					pairs[pair] = 0
+				}
+			}
+		}
+	}
+	totals := make(map[string]int)
+	for _, c := range coCommits {
+		totals[c.AccountId] += c.Commits
+	}
+	for _, c := range coCommits {
+		a, okA := accountIndex[c.AccountId]
+		b, okB := accountIndex[c.AuthorId]
+		if !okA || !okB || a == b || c.Commits < minCoCommits {
+			continue
+		}
+		pairs[orderedPair(a, b)] = float64(c.Commits) / float64(totals[c.AccountId])
+	}
+
+	edges := make([][]identityEdge, len(nodes))
+	for pair, ratio := range pairs {
+		score, why := scoreIdentities(nodes[pair[0]], nodes[pair[1]], ratio)
+		if score < minEdgeScore {
+			continue
+		}
+		edges[pair[0]] = append(edges[pair[0]], identityEdge{to: pair[1], score: score, reasons: why})
+		edges[pair[1]] = append(edges[pair[1]], identityEdge{to: pair[0], score: score, reasons: why})
+	}
+	return edges
+}
+
+// scoreIdentities combines independent evidences with a noisy-or, coCommitRatio is the share of the
+// commits in one account's pull requests authored by the other one
+func scoreIdentities(a, b *identity, coCommitRatio float64) (float64, []string) {
+	var scores []float64
+	var reasons []string
+	add := func(score float64, reason string) {
+		scores = append(scores, score)
+		reasons = append(reasons, reason)
+	}
+	if intersects(a.emails, b.emails) {
+		add(0.95, "email")
+	}
+	if intersects(a.noreply, b.logins) || intersects(b.noreply, a.logins) || intersects(a.noreply, b.noreply) {
+		add(0.9, "noreply")
+	}
+	if intersects(a.logins, b.logins) {
+		add(0.75, "login")
+	}
+	if intersects(a.locals, b.logins) || intersects(b.locals, a.logins) {
+		add(0.5, "email_login")
+	}
+	if a.name != "" && b.name != "" {
+		if similarity := nameSimilarity(a.name, b.name); similarity >= 0.85 {
+			add(0.7*similarity, "name")
+		}
+	}
+	if coCommitRatio >= 0.5 {
+		add(0.4+0.5*coCommitRatio, "commits")
+	}
+	missing := 1.0
+	for _, score := range scores {
+		missing *= 1 - score
+	}
+	return 1 - missing, reasons
+}
+
+func newIdentity(email, login, name string) *identity {
+	node := &identity{name: normalizeName(name)}
+	if normalized, noreplyLogin := normalizeEmail(email); noreplyLogin != "" {
+		node.noreply = append(node.noreply, noreplyLogin)
+		node.emails = append(node.emails, normalized)
+	} else if normalized != "" {
+		node.emails = append(node.emails, normalized)
+		node.locals = append(node.locals, normalized[:strings.LastIndex(normalized, "@")])
+	}
+	if login = normalizeLogin(login); login != "" {
+		node.logins = append(node.logins, login)
+	}
+	return node
+}
+
+func (node *identity) blockingKeys() []string {
+	var keys []string
+	for _, email := range node.emails {
+		keys = append(keys, "email:"+email)
+	}
+	for _, login := range append(append(append([]string{}, node.logins...), node.noreply...), node.locals...) {
+		keys = append(keys, "login:"+login)
+	}
+	if tokens := strings.Fields(node.name); len(tokens) > 0 {
+		keys = append(keys, "name:"+tokens[len(tokens)-1])
+	}
+	return keys
+}
+
+// normalizeEmail lowercases the email and strips "+tag" suffixes, noreply addresses of GitHub and
+// GitLab also yield the login they embed
+func normalizeEmail(email string) (string, string) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", ""
+	}
+	local, domain := email[:at], email[at+1:]
+	switch domain {
+	case "users.noreply.github.com":
+		// 12345+login@users.noreply.github.com or login@users.noreply.github.com
+		if plus := strings.Index(local, "+"); plus >= 0 {
+			local = local[plus+1:]
+		}
+		return local + "@" + domain, local
+	case "users.noreply.gitlab.com":
+		// 12345-login@users.noreply.gitlab.com
+		if dash := strings.Index(local, "-"); dash >= 0 && isDigits(local[:dash]) {
+			local = local[dash+1:]
+		}
+		return local + "@" + domain, local
+	}
+	if local == "noreply" || local == "no-reply" {
+		return "", ""
+	}
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	return local + "@" + domain, ""
+}
+
+func normalizeLogin(login string) string {
+	login = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(login), "@"))
+	if strings.IndexFunc(login, unicode.IsSpace) >= 0 {
+		// git authors carry their display name as user name
+		return ""
+	}
+	return login
+}
+
+// normalizeName lowercases the name, drops punctuation and sorts the tokens so that
+// "Doe, John" and "john doe" are the same
+func normalizeName(name string) string {
+	tokens := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// nameSimilarity is the levenshtein distance normalized into [0, 1]
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0
+	}
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return 1 - float64(previous[len(rb)])/float64(longest)
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+func intersects(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func orderedPair(a, b int) [2]int {
+	if a > b {
+		return [2]int{b, a}
+	}
+	return [2]int{a, b}
+}
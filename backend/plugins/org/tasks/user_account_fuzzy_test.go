@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/org/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	cases := []struct {
+		email      string
+		normalized string
+		login      string
+	}{
+		{" John.Doe+work@Corp.com ", "john.doe@corp.com", ""},
+		{"12345+JDoe@users.noreply.github.com", "jdoe@users.noreply.github.com", "jdoe"},
+		{"jdoe@users.noreply.github.com", "jdoe@users.noreply.github.com", "jdoe"},
+		{"42-jdoe@users.noreply.gitlab.com", "jdoe@users.noreply.gitlab.com", "jdoe"},
+		{"noreply@github.com", "", ""},
+		{"not an email", "", ""},
+	}
+	for _, c := range cases {
+		normalized, login := normalizeEmail(c.email)
+		assert.Equal(t, c.normalized, normalized, c.email)
+		assert.Equal(t, c.login, login, c.email)
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	assert.Equal(t, "doe john", normalizeName("Doe, John"))
+	assert.Equal(t, 1.0, nameSimilarity(normalizeName("John Doe"), normalizeName("doe, john")))
+	assert.InDelta(t, 0.875, nameSimilarity("doe john", "doe jon"), 0.01)
+	assert.Less(t, nameSimilarity("doe john", "roe jane"), 0.85)
+	assert.Equal(t, 0.0, nameSimilarity("", ""))
+}
+
+func TestScoreIdentities(t *testing.T) {
+	score, reasons := scoreIdentities(
+		newIdentity("john.doe@corp.com", "", "John Doe"),
+		newIdentity("John.Doe+ci@corp.com", "John Doe", "Doe, John"),
+		0,
+	)
+	assert.InDelta(t, 0.985, score, 0.0001)
+	assert.Equal(t, []string{"email", "name"}, reasons)
+
+	score, reasons = scoreIdentities(
+		newIdentity("", "JDoe", ""),
+		newIdentity("1+jdoe@users.noreply.github.com", "", ""),
+		0,
+	)
+	assert.InDelta(t, 0.9, score, 0.0001)
+	assert.Equal(t, []string{"noreply"}, reasons)
+
+	score, _ = scoreIdentities(newIdentity("", "jdoe", ""), newIdentity("", "jroe", ""), 0)
+	assert.Equal(t, 0.0, score)
+}
+
+func TestSuggestUserAccounts(t *testing.T) {
+	account := func(id, email, login, name string) crossdomain.Account {
+		return crossdomain.Account{
+			DomainEntity: domainlayer.DomainEntity{Id: id},
+			Email:        email,
+			UserName:     login,
+			FullName:     name,
+		}
+	}
+	users := []crossdomain.User{
+		{DomainEntity: domainlayer.DomainEntity{Id: "U1"}, Email: "john.doe@corp.com", Name: "John Doe"},
+	}
+	accounts := []crossdomain.Account{
+		account("gitlab:GitlabAccount:1:7", "john.doe@corp.com", "johnd", "John Doe"),
+		account("john.doe+work@corp.com", "john.doe+work@corp.com", "John Doe", "John Doe"),
+		account("github:GithubAccount:1:123", "", "jdoe", ""),
+		account("123+jdoe@users.noreply.github.com", "123+jdoe@users.noreply.github.com", "J Doe", "J Doe"),
+		account("jira:JiraAccount:1:abc", "jane@corp.com", "", "Jane Roe"),
+	}
+	links := []crossdomain.UserAccount{
+		{UserId: "U1", AccountId: "gitlab:GitlabAccount:1:7"},
+	}
+	coCommits := []coCommit{
+		{AccountId: "github:GithubAccount:1:123", AuthorId: "john.doe+work@corp.com", Commits: 5},
+	}
+
+	suggestions := suggestUserAccounts(users, accounts, links, coCommits, nil, DefaultMinConfidence)
+	assert.Len(t, suggestions, 3)
+	byAccount := make(map[string]*models.UserAccountSuggestion)
+	for _, s := range suggestions {
+		assert.Equal(t, "U1", s.UserId)
+		assert.Equal(t, models.SuggestionPending, s.Status)
+		byAccount[s.AccountId] = s
+	}
+	assert.InDelta(t, 0.985, byAccount["john.doe+work@corp.com"].Confidence, 0.0001)
+	assert.Equal(t, "email,name", byAccount["john.doe+work@corp.com"].Reasons)
+	assert.InDelta(t, 0.985*0.9, byAccount["github:GithubAccount:1:123"].Confidence, 0.0001)
+	assert.Equal(t, "commits,transitive", byAccount["github:GithubAccount:1:123"].Reasons)
+	assert.InDelta(t, 0.985*0.9*0.9, byAccount["123+jdoe@users.noreply.github.com"].Confidence, 0.0001)
+
+	// rejected links are never suggested again
+	rejected := map[[2]string]bool{{"123+jdoe@users.noreply.github.com", "U1"}: true}
+	suggestions = suggestUserAccounts(users, accounts, links, coCommits, rejected, DefaultMinConfidence)
+	assert.Len(t, suggestions, 2)
+	for _, s := range suggestions {
+		assert.NotEqual(t, "123+jdoe@users.noreply.github.com", s.AccountId)
+	}
+}
+
+func TestReplacePendingSuggestions(t *testing.T) {
+	suggestions := make([]*models.UserAccountSuggestion, 501)
+	for i := range suggestions {
+		suggestions[i] = &models.UserAccountSuggestion{AccountId: fmt.Sprintf("a%d", i), UserId: "u1", Status: models.SuggestionPending}
+	}
+	tx := new(mockdal.Transaction)
+	var batches []int
+	tx.On("Delete", mock.AnythingOfType("*models.UserAccountSuggestion"), mock.Anything).Return(nil).Once()
+	tx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		batches = append(batches, len(args.Get(0).([]*models.UserAccountSuggestion)))
+	}).Return(nil)
+	assert.Nil(t, replacePendingSuggestions(tx, suggestions))
+	assert.Equal(t, []int{500, 1}, batches)
+	tx.AssertExpectations(t)
+
+	// the deletion is not followed by any insert once it fails
+	tx = new(mockdal.Transaction)
+	tx.On("Delete", mock.Anything, mock.Anything).Return(errors.Default.New("deadlock")).Once()
+	assert.NotNil(t, replacePendingSuggestions(tx, suggestions))
+	tx.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/refdiff/impl"
+	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
+)
+
+func TestReleaseDataFlow(t *testing.T) {
+	var plugin impl.RefDiff
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "refdiff", plugin)
+
+	taskData := &tasks.RefdiffTaskData{
+		Options: &tasks.RefdiffOptions{
+			RepoId: "github:GithubRepo:1:1",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/releases.csv", &code.Release{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_refs.csv", &code.Ref{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_repo_commits.csv", &code.RepoCommit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_commit_parents.csv", &code.CommitParent{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_pull_request_issues.csv", &crossdomain.PullRequestIssue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_issue_commits.csv", &crossdomain.IssueCommit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_issues.csv", &ticket.Issue{})
+	dataflowTester.FlushTabler(&code.Commit{})
+
+	// verify calculation, the release created from tags before is deleted along with its links
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_commits_of_tags.csv", &code.ReleaseCommit{})
+	dataflowTester.FlushTabler(&code.ReleasePullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_issues_of_tags.csv", &crossdomain.ReleaseIssue{})
+	dataflowTester.Subtask(tasks.CalculateReleasesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.Release{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/releases.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&code.ReleaseCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/release_commits.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&code.ReleasePullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/release_pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ReleaseIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/release_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
+
+func TestReleaseFromTagsDataFlow(t *testing.T) {
+	var plugin impl.RefDiff
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "refdiff", plugin)
+
+	taskData := &tasks.RefdiffTaskData{
+		Options: &tasks.RefdiffOptions{
+			RepoId:      "github:GithubRepo:1:2",
+			TagsPattern: `v\d+`,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_refs_from_tags.csv", &code.Ref{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_commits_from_tags.csv", &code.Commit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_repo_commits_from_tags.csv", &code.RepoCommit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_commit_parents.csv", &code.CommitParent{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_pull_requests.csv", &code.PullRequest{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_pull_request_issues.csv", &crossdomain.PullRequestIssue{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_issue_commits.csv", &crossdomain.IssueCommit{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/release_issues.csv", &ticket.Issue{})
+
+	// verify calculation
+	dataflowTester.FlushTabler(&code.Release{})
+	dataflowTester.FlushTabler(&code.ReleaseCommit{})
+	dataflowTester.FlushTabler(&code.ReleasePullRequest{})
+	dataflowTester.FlushTabler(&crossdomain.ReleaseIssue{})
+	dataflowTester.Subtask(tasks.CalculateReleasesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.Release{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/releases_from_tags.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&code.ReleaseCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/release_commits_from_tags.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ReleaseIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/release_issues_from_tags.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// the release of the tag no longer matching the pattern is deleted along with its links
+	taskData.Options.TagsPattern = `v2\.1`
+	dataflowTester.Subtask(tasks.CalculateReleasesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.Release{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/releases_from_tags_after_pattern_changed.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&code.ReleaseCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/release_commits_from_tags_after_pattern_changed.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&crossdomain.ReleaseIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/release_issues_from_tags.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
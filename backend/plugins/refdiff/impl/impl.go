@@ -64,6 +64,7 @@ func (p RefDiff) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CalculateIssuesDiffMeta,
 		tasks.CalculatePrCherryPickMeta,
 		tasks.CalculateProjectDeploymentCommitsDiffMeta,
+		tasks.CalculateReleasesMeta,
 	}
 }
 
@@ -0,0 +1,389 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/refdiff/utils"
+)
+
+// rows to be inserted in one statement, keep it far below the placeholders limit
+const releaseBatchSize = 1000
+
+var CalculateReleasesMeta = plugin.SubTaskMeta{
+	Name:             "calculateReleases",
+	EntryPoint:       CalculateReleases,
+	EnabledByDefault: true,
+	Description:      "Calculate commits, pull requests and resolved issues shipped in each release",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_CROSS},
+}
+
+type tagRef struct {
+	Id            string
+	Name          string
+	CommitSha     string
+	CommittedDate *time.Time
+}
+
+// CalculateReleases links every release to the commits it shipped for the first time, i.e. the commits reachable
+// from the release but not from the previous one, then to the pull requests merged by and issues resolved by them.
+// Repos without GitHub/GitLab releases get their releases from tags.
+func CalculateReleases(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*RefdiffTaskData)
+	db := taskCtx.GetDal()
+
+	var repoIds []string
+	if data.Options.RepoId != "" {
+		repoIds = append(repoIds, data.Options.RepoId)
+	}
+	if data.Options.ProjectName != "" {
+		var scopeIds []string
+		err := db.Pluck("row_id", &scopeIds,
+			dal.From("project_mapping pm"),
+			dal.Where("pm.project_name = ? and pm.table = ?", data.Options.ProjectName, "repos"),
+		)
+		if err != nil {
+			return err
+		}
+		repoIds = append(repoIds, scopeIds...)
+	}
+
+	taskCtx.SetProgress(0, len(repoIds))
+	for _, repoId := range repoIds {
+		err := replaceRepoReleases(taskCtx, repoId, data.Options.TagsPattern)
+		if err != nil {
+			return err
+		}
+		taskCtx.IncProgress(1)
+	}
+	return nil
+}
+
+// replaceRepoReleases recalculates the releases of the repo in one transaction, so the releases and their links
+// are replaced all or nothing
+func replaceRepoReleases(taskCtx plugin.SubTaskContext, repoId string, tagsPattern string) errors.Error {
+	tx := taskCtx.GetDal().Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+	err := calculateRepoReleases(taskCtx, tx, repoId, tagsPattern)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			taskCtx.GetLogger().Error(e, "failed to rollback the transaction of releases of repo %s", repoId)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func calculateRepoReleases(taskCtx plugin.SubTaskContext, db dal.Dal, repoId string, tagsPattern string) errors.Error {
+	ctx := taskCtx.GetContext()
+	logger := taskCtx.GetLogger()
+
+	var tags []tagRef
+	err := db.All(&tags,
+		dal.Select("r.id, r.name, r.commit_sha, c.committed_date"),
+		dal.From("refs r"),
+		dal.Join("LEFT JOIN commits c ON (c.sha = r.commit_sha)"),
+		dal.Where("r.repo_id = ? and r.ref_type = ?", repoId, "TAG"),
+	)
+	if err != nil {
+		return err
+	}
+	tagCommits := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagCommits[strings.TrimPrefix(tag.Name, "refs/tags/")] = tag.CommitSha
+	}
+
+	var releases []code.Release
+	err = db.All(&releases, dal.Where("repo_id = ? and is_draft = ?", repoId, false))
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 || allReleasesFromTags(repoId, releases) {
+		releases, err = createReleasesFromTags(db, repoId, tags, tagsPattern)
+		if err != nil {
+			return err
+		}
+	} else if len(releasesFromTags(repoId, releases)) > 0 {
+		// the repo got GitHub/GitLab releases, which replace the ones created from tags before
+		err = deleteReleasesFromTags(db, repoId)
+		if err != nil {
+			return err
+		}
+		releases = releasesNotFromTags(repoId, releases)
+	}
+	if len(releases) == 0 {
+		logger.Info("no release or tag found for repo %s", repoId)
+		return nil
+	}
+	sortReleases(releases)
+
+	releaseIds := make([]string, 0, len(releases))
+	for _, release := range releases {
+		releaseIds = append(releaseIds, release.Id)
+	}
+	for _, table := range []dal.Tabler{&code.ReleaseCommit{}, &code.ReleasePullRequest{}, &crossdomain.ReleaseIssue{}} {
+		err = db.Delete(table, dal.Where("release_id in ?", releaseIds))
+		if err != nil {
+			return err
+		}
+	}
+
+	var commitParents []code.CommitParent
+	err = db.All(&commitParents,
+		dal.Select("cp.*"),
+		dal.Join("LEFT JOIN repo_commits rc ON (rc.commit_sha = cp.commit_sha)"),
+		dal.From("commit_parents cp"),
+		dal.Where("rc.repo_id = ?", repoId),
+	)
+	if err != nil {
+		return err
+	}
+	commitNodeGraph := utils.NewCommitNodeGraph()
+	for _, commitParent := range commitParents {
+		commitNodeGraph.AddParent(commitParent.CommitSha, commitParent.ParentCommitSha)
+	}
+	logger.Info("Create a commit node graph with node count[%d]", commitNodeGraph.Size())
+
+	var previous *code.Release
+	for i := range releases {
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		default:
+		}
+		release := &releases[i]
+		if release.CommitSha == "" {
+			release.CommitSha = tagCommits[release.TagName]
+		}
+		if release.CommitSha == "" {
+			logger.Warn(nil, "skipping release %s, the commit of tag %s is unknown", release.Id, release.TagName)
+			continue
+		}
+		oldCommitSha := ""
+		release.PreviousReleaseId = ""
+		if previous != nil {
+			oldCommitSha = previous.CommitSha
+			release.PreviousReleaseId = previous.Id
+		}
+		err = db.Update(release)
+		if err != nil {
+			return err
+		}
+
+		lostSha, _, newCount := commitNodeGraph.CalculateLostSha(oldCommitSha, release.CommitSha)
+		releaseCommits := make([]code.ReleaseCommit, 0, len(lostSha))
+		for _, sha := range lostSha {
+			releaseCommits = append(releaseCommits, code.ReleaseCommit{ReleaseId: release.Id, CommitSha: sha})
+		}
+		for start := 0; start < len(releaseCommits); start += releaseBatchSize {
+			end := start + releaseBatchSize
+			if end > len(releaseCommits) {
+				end = len(releaseCommits)
+			}
+			err = db.CreateIfNotExist(releaseCommits[start:end])
+			if err != nil {
+				return err
+			}
+		}
+		logger.Info("total %d commits shipped in release %s", newCount, release.Id)
+		previous = release
+	}
+
+	return linkReleasePullRequestsAndIssues(db, repoId, releaseIds)
+}
+
+// allReleasesFromTags tells if the releases were created by us from tags, so they should be refreshed with the tags
+func allReleasesFromTags(repoId string, releases []code.Release) bool {
+	return len(releasesFromTags(repoId, releases)) == len(releases)
+}
+
+func releasesFromTags(repoId string, releases []code.Release) []code.Release {
+	var fromTags []code.Release
+	for _, release := range releases {
+		if isReleaseFromTag(repoId, release) {
+			fromTags = append(fromTags, release)
+		}
+	}
+	return fromTags
+}
+
+func releasesNotFromTags(repoId string, releases []code.Release) []code.Release {
+	var notFromTags []code.Release
+	for _, release := range releases {
+		if !isReleaseFromTag(repoId, release) {
+			notFromTags = append(notFromTags, release)
+		}
+	}
+	return notFromTags
+}
+
+func isReleaseFromTag(repoId string, release code.Release) bool {
+	return strings.HasPrefix(release.Id, fmt.Sprintf("%s:refs/tags/", repoId))
+}
+
+// deleteReleasesFromTags deletes the releases created from tags of the repo, along with their commits, pull requests
+// and issues, so no link would be left behind by the releases no longer existing
+func deleteReleasesFromTags(db dal.Dal, repoId string) errors.Error {
+	idPattern := fmt.Sprintf("%s:refs/tags/%%", repoId)
+	for _, table := range []dal.Tabler{&code.ReleaseCommit{}, &code.ReleasePullRequest{}, &crossdomain.ReleaseIssue{}} {
+		err := db.Delete(table, dal.Where("release_id like ?", idPattern))
+		if err != nil {
+			return err
+		}
+	}
+	return db.Delete(&code.Release{}, dal.Where("repo_id = ? and id like ?", repoId, idPattern))
+}
+
+func createReleasesFromTags(db dal.Dal, repoId string, tags []tagRef, tagsPattern string) ([]code.Release, errors.Error) {
+	var pattern *regexp.Regexp
+	if tagsPattern != "" {
+		var err errors.Error
+		pattern, err = errors.Convert01(regexp.Compile(tagsPattern))
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("unable to parse: %s", tagsPattern))
+		}
+	}
+	// releases of the tags no longer matching the pattern are dropped as well
+	err := deleteReleasesFromTags(db, repoId)
+	if err != nil {
+		return nil, err
+	}
+	releases := make([]code.Release, 0, len(tags))
+	for _, tag := range tags {
+		if pattern != nil && !pattern.MatchString(tag.Name) {
+			continue
+		}
+		tagName := strings.TrimPrefix(tag.Name, "refs/tags/")
+		release := code.Release{
+			DomainEntity: domainlayer.DomainEntity{Id: tag.Id},
+			RepoId:       repoId,
+			Name:         tagName,
+			TagName:      tagName,
+			CommitSha:    tag.CommitSha,
+		}
+		if tag.CommittedDate != nil {
+			release.CreatedDate = *tag.CommittedDate
+		}
+		err = db.CreateOrUpdate(&release)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+// sortReleases sorts releases by the time they were published
+func sortReleases(releases []code.Release) {
+	releaseDate := func(release *code.Release) time.Time {
+		if release.PublishedDate != nil {
+			return *release.PublishedDate
+		}
+		return release.CreatedDate
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		di, dj := releaseDate(&releases[i]), releaseDate(&releases[j])
+		if di.Equal(dj) {
+			return releases[i].Id < releases[j].Id
+		}
+		return di.Before(dj)
+	})
+}
+
+func linkReleasePullRequestsAndIssues(db dal.Dal, repoId string, releaseIds []string) errors.Error {
+	var releasePullRequests []code.ReleasePullRequest
+	err := db.All(&releasePullRequests,
+		dal.Select("DISTINCT rc.release_id, pr.id AS pull_request_id"),
+		dal.From("release_commits rc"),
+		dal.Join("JOIN pull_requests pr ON (pr.merge_commit_sha = rc.commit_sha)"),
+		dal.Where("rc.release_id in ? and pr.base_repo_id = ?", releaseIds, repoId),
+	)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(releasePullRequests); start += releaseBatchSize {
+		end := start + releaseBatchSize
+		if end > len(releasePullRequests) {
+			end = len(releasePullRequests)
+		}
+		err = db.CreateIfNotExist(releasePullRequests[start:end])
+		if err != nil {
+			return err
+		}
+	}
+
+	// issues are resolved by either the pull requests or the commits of the release
+	var issuesByPr, issuesByCommit []crossdomain.ReleaseIssue
+	err = db.All(&issuesByPr,
+		dal.Select("DISTINCT rp.release_id, pri.issue_id"),
+		dal.From("release_pull_requests rp"),
+		dal.Join("JOIN pull_request_issues pri ON (pri.pull_request_id = rp.pull_request_id)"),
+		dal.Join("JOIN issues i ON (i.id = pri.issue_id)"),
+		dal.Where("rp.release_id in ? and i.status = ?", releaseIds, ticket.DONE),
+	)
+	if err != nil {
+		return err
+	}
+	err = db.All(&issuesByCommit,
+		dal.Select("DISTINCT rc.release_id, ic.issue_id"),
+		dal.From("release_commits rc"),
+		dal.Join("JOIN issue_commits ic ON (ic.commit_sha = rc.commit_sha)"),
+		dal.Join("JOIN issues i ON (i.id = ic.issue_id)"),
+		dal.Where("rc.release_id in ? and i.status = ?", releaseIds, ticket.DONE),
+	)
+	if err != nil {
+		return err
+	}
+	seen := make(map[[2]string]bool)
+	var releaseIssues []crossdomain.ReleaseIssue
+	for _, releaseIssue := range append(issuesByPr, issuesByCommit...) {
+		key := [2]string{releaseIssue.ReleaseId, releaseIssue.IssueId}
+		if !seen[key] {
+			seen[key] = true
+			releaseIssues = append(releaseIssues, releaseIssue)
+		}
+	}
+	for start := 0; start < len(releaseIssues); start += releaseBatchSize {
+		end := start + releaseBatchSize
+		if end > len(releaseIssues) {
+			end = len(releaseIssues)
+		}
+		err = db.CreateIfNotExist(releaseIssues[start:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/singer/models"
+)
+
+// @Summary test singer connection
+// @Description Test Singer Connection, make sure the tap is installed and the selected streams exist in the catalog
+// @Tags plugins/singer
+// @Param body body models.SingerConnection true "json body"
+// @Success 200  {object} shared.ApiBody "Success"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/singer/test [POST]
+func TestConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.SingerConnection{}
+	err := normalizeJsonFields(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = api.Decode(input.Body, connection, nil)
+	if err != nil {
+		return nil, err
+	}
+	connection.Name = "test"
+	err = connection.ValidateConnection(connection, vld)
+	if err != nil {
+		return nil, err
+	}
+	_, err = connection.GetTapExecutable()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// @Summary create singer connection
+// @Description Create Singer connection, registering the tap name, config, catalog and selected streams
+// @Tags plugins/singer
+// @Param body body models.SingerConnection true "json body"
+// @Success 200  {object} models.SingerConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/singer/connections [POST]
+func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.SingerConnection{}
+	err := normalizeJsonFields(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = connectionHelper.Create(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: maskConnection(connection), Status: http.StatusOK}, nil
+}
+
+// @Summary patch singer connection
+// @Description Patch Singer connection
+// @Tags plugins/singer
+// @Param body body models.SingerConnection true "json body"
+// @Success 200  {object} models.SingerConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/singer/connections/{connectionId} [PATCH]
+func PatchConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.SingerConnection{}
+	err := normalizeJsonFields(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	if config, ok := input.Body["config"].(json.RawMessage); ok {
+		// the masked secrets sent back by clients are not meant to change the stored ones
+		stored := &models.SingerConnection{}
+		err = connectionHelper.First(stored, input.Params)
+		if err != nil {
+			return nil, err
+		}
+		input.Body["config"], err = unmaskConfig(config, stored.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = connectionHelper.Patch(connection, input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: maskConnection(connection), Status: http.StatusOK}, nil
+}
+
+// @Summary delete singer connection
+// @Description Delete Singer connection
+// @Tags plugins/singer
+// @Success 200  {object} models.SingerConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/singer/connections/{connectionId} [DELETE]
+func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.SingerConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	err = connectionHelper.Delete(connection)
+	return &plugin.ApiResourceOutput{Body: maskConnection(connection)}, err
+}
+
+// @Summary list singer connections
+// @Description List Singer connections
+// @Tags plugins/singer
+// @Success 200  {object} []models.SingerConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/singer/connections [GET]
+func ListConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var connections []models.SingerConnection
+	err := connectionHelper.List(&connections)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*models.SingerConnection, len(connections))
+	for i := range connections {
+		responses[i] = maskConnection(&connections[i])
+	}
+	return &plugin.ApiResourceOutput{Body: responses}, nil
+}
+
+// @Summary get singer connection
+// @Description Get Singer connection
+// @Tags plugins/singer
+// @Success 200  {object} models.SingerConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/singer/connections/{connectionId} [GET]
+func GetConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.SingerConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: maskConnection(connection)}, nil
+}
+
+// normalizeJsonFields converts the config and catalog objects into raw json so they can be decoded into the connection
+func normalizeJsonFields(body map[string]interface{}) errors.Error {
+	for _, field := range []string{"config", "catalog"} {
+		value, ok := body[field]
+		if !ok || value == nil {
+			continue
+		}
+		if s, ok := value.(string); ok {
+			if !json.Valid([]byte(s)) {
+				return errors.BadInput.New(fmt.Sprintf("%s is not a valid json", field))
+			}
+			body[field] = json.RawMessage(s)
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return errors.BadInput.Wrap(err, fmt.Sprintf("invalid %s", field))
+		}
+		body[field] = json.RawMessage(raw)
+	}
+	return nil
+}
+
+// configSecretMask replaces the secrets of the tap config in responses
+const configSecretMask = "********"
+
+// configSecretKeyPattern matches the config keys holding credentials, i.e. api_token, client_secret or password
+var configSecretKeyPattern = regexp.MustCompile(`(?i)token|secret|password|passwd|credential|key|auth`)
+
+// maskConnection returns a copy of the connection with the secrets of its config masked
+func maskConnection(connection *models.SingerConnection) *models.SingerConnection {
+	masked := *connection
+	var config interface{}
+	if json.Unmarshal(connection.Config, &config) != nil {
+		// the config can not be inspected, leave it out rather than leaking it
+		masked.Config = nil
+		return &masked
+	}
+	masked.Config, _ = json.Marshal(maskConfigSecrets(config, false))
+	return &masked
+}
+
+// maskConfigSecrets masks the values of the secret keys, and all the values nested under them
+func maskConfigSecrets(value interface{}, secret bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			masked[key] = maskConfigSecrets(item, secret || configSecretKeyPattern.MatchString(key))
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = maskConfigSecrets(item, secret)
+		}
+		return masked
+	case nil:
+		return nil
+	}
+	if secret {
+		return configSecretMask
+	}
+	return value
+}
+
+// unmaskConfig puts the stored secrets back to where the patched config holds the mask
+func unmaskConfig(config json.RawMessage, stored json.RawMessage) (json.RawMessage, errors.Error) {
+	var patched, original interface{}
+	if err := json.Unmarshal(config, &patched); err != nil {
+		return nil, errors.BadInput.Wrap(err, "config is not a valid json")
+	}
+	if json.Unmarshal(stored, &original) != nil {
+		return config, nil
+	}
+	raw, err := json.Marshal(unmaskConfigSecrets(patched, original))
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	return raw, nil
+}
+
+func unmaskConfigSecrets(value interface{}, stored interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		storedMap, _ := stored.(map[string]interface{})
+		for key, item := range v {
+			if item == configSecretMask {
+				if storedItem, ok := storedMap[key]; ok {
+					v[key] = storedItem
+				}
+				continue
+			}
+			v[key] = unmaskConfigSecrets(item, storedMap[key])
+		}
+	case []interface{}:
+		storedSlice, _ := stored.([]interface{})
+		for i, item := range v {
+			var storedItem interface{}
+			if i < len(storedSlice) {
+				storedItem = storedSlice[i]
+			}
+			v[i] = unmaskConfigSecrets(item, storedItem)
+		}
+	}
+	return value
+}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/singer/models"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNormalizeJsonFields(t *testing.T) {
+	body := map[string]interface{}{
+		"name":    "pagerduty",
+		"tapName": "tap-pagerduty",
+		"config":  map[string]interface{}{"token": "secret"},
+		"catalog": `{"streams":[{"stream":"incidents","tap_stream_id":"incidents"}]}`,
+		"streams": []interface{}{"incidents"},
+	}
+	assert.Nil(t, normalizeJsonFields(body))
+	connection := &models.SingerConnection{}
+	assert.Nil(t, api.Decode(body, connection, nil))
+	assert.JSONEq(t, `{"token":"secret"}`, string(connection.Config))
+	assert.Nil(t, connection.ValidateConnection(connection, validator.New()))
+
+	props, err := connection.GetProperties()
+	assert.Nil(t, err)
+	assert.Equal(t, "incidents", props.Streams[0].TapStreamId)
+
+	connection.Streams = []string{"services"}
+	assert.NotNil(t, connection.ValidateConnection(connection, validator.New()))
+
+	assert.NotNil(t, normalizeJsonFields(map[string]interface{}{"catalog": "{not json"}))
+}
+
+const storedSingerConfig = `{"start_date":"2023-01-01","api_token":"pd-token","oauth":{"client_id":"id","client_secret":"cs"},"subdomains":["a"]}`
+
+func TestGetConnectionMasksConfigSecrets(t *testing.T) {
+	Init(unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			connection := args.Get(0).(*models.SingerConnection)
+			connection.TapName = "tap-pagerduty"
+			connection.Config = json.RawMessage(storedSingerConfig)
+		}).Return(nil)
+		mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*[]models.SingerConnection) = []models.SingerConnection{{Config: json.RawMessage(storedSingerConfig)}}
+		}).Return(nil)
+	}))
+
+	output, err := GetConnection(&plugin.ApiResourceInput{Params: map[string]string{"connectionId": "1"}})
+	assert.Nil(t, err)
+	assert.JSONEq(t,
+		`{"start_date":"2023-01-01","api_token":"********","oauth":{"client_id":"********","client_secret":"********"},"subdomains":["a"]}`,
+		string(output.Body.(*models.SingerConnection).Config),
+	)
+
+	output, err = ListConnections(&plugin.ApiResourceInput{})
+	assert.Nil(t, err)
+	body, e := json.Marshal(output.Body)
+	assert.Nil(t, e)
+	assert.NotContains(t, string(body), "pd-token")
+	assert.NotContains(t, string(body), `"cs"`)
+}
+
+func TestUnmaskConfig(t *testing.T) {
+	config, err := unmaskConfig(
+		json.RawMessage(`{"start_date":"2023-02-01","api_token":"********","oauth":{"client_id":"********","client_secret":"new"}}`),
+		json.RawMessage(storedSingerConfig),
+	)
+	assert.Nil(t, err)
+	assert.JSONEq(t,
+		`{"start_date":"2023-02-01","api_token":"pd-token","oauth":{"client_id":"id","client_secret":"new"}}`,
+		string(config),
+	)
+
+	_, err = unmaskConfig(json.RawMessage(`{not json`), json.RawMessage(storedSingerConfig))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/singer/models"
+	"github.com/apache/incubator-devlake/plugins/singer/tasks"
+)
+
+type StreamInfo struct {
+	Stream      string `json:"stream"`
+	TapStreamId string `json:"tapStreamId"`
+	RawTable    string `json:"rawTable"`
+	Selected    bool   `json:"selected"`
+}
+
+// @Summary list streams of singer connection
+// @Description List the streams in the catalog of the Singer connection, and whether they are selected to be collected
+// @Tags plugins/singer
+// @Success 200  {object} []StreamInfo
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/singer/connections/{connectionId}/streams [GET]
+func ListStreams(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.SingerConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	props, err := connection.GetProperties()
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool, len(connection.Streams))
+	for _, stream := range connection.Streams {
+		selected[stream] = true
+	}
+	streams := make([]StreamInfo, 0, len(props.Streams))
+	for _, s := range props.Streams {
+		streams = append(streams, StreamInfo{
+			Stream:      s.Stream,
+			TapStreamId: s.TapStreamId,
+			RawTable:    "_raw_" + tasks.GetRawTableName(s.Stream),
+			Selected:    selected[s.Stream],
+		})
+	}
+	return &plugin.ApiResourceOutput{Body: streams}, nil
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/singer/api"
+	"github.com/apache/incubator-devlake/plugins/singer/models"
+	"github.com/apache/incubator-devlake/plugins/singer/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/singer/tasks"
+)
+
+// make sure interface is implemented
+var _ plugin.PluginMeta = (*Singer)(nil)
+var _ plugin.PluginInit = (*Singer)(nil)
+var _ plugin.PluginTask = (*Singer)(nil)
+var _ plugin.PluginApi = (*Singer)(nil)
+var _ plugin.PluginModel = (*Singer)(nil)
+var _ plugin.PluginMigration = (*Singer)(nil)
+var _ plugin.PluginBlueprintV100 = (*Singer)(nil)
+
+// Singer runs any Singer tap registered through the connection api, and lands its records into _raw_singer_* tables
+type Singer struct{}
+
+func (p Singer) Description() string {
+	return "collect data from any Singer tap"
+}
+
+func (p Singer) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Singer) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.SingerConnection{},
+	}
+}
+
+func (p Singer) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectStreamsMeta,
+	}
+}
+
+func (p Singer) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+	)
+	connection := &models.SingerConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get singer connection by the given connection ID")
+	}
+	return &tasks.SingerTaskData{
+		Options:    op,
+		Connection: connection,
+	}, nil
+}
+
+// PkgPath information lost when compiled as plugin(.so)
+func (p Singer) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/singer"
+}
+
+func (p Singer) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Singer) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"GET":    api.GetConnection,
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+		},
+		"connections/:connectionId/streams": {
+			"GET": api.ListStreams,
+		},
+	}
+}
+
+func (p Singer) MakePipelinePlan(connectionId uint64, scope []*plugin.BlueprintScopeV100) (plugin.PipelinePlan, errors.Error) {
+	return api.MakePipelinePlan(p.SubTaskMetas(), connectionId, scope)
+}
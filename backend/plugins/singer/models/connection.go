@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/tap"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/datatypes"
+)
+
+// TapsDirEnv is the directory holding the tap executables allowed to run, it is configured by the operator so
+// api callers can only choose among the installed taps
+const TapsDirEnv = "SINGER_TAPS_DIR"
+
+var tapNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// SingerConnection registers a Singer tap along with its config, catalog and the streams to be collected
+type SingerConnection struct {
+	helper.BaseConnection `mapstructure:",squash"`
+	// TapName is the name of an executable in SINGER_TAPS_DIR, i.e. tap-pagerduty
+	TapName string `mapstructure:"tapName" json:"tapName" validate:"required" gorm:"type:varchar(255)"`
+	// Config is passed to the tap by --config, it is encrypted since it holds credentials most of the time
+	Config json.RawMessage `mapstructure:"config" json:"config" validate:"required" gorm:"serializer:encdec"`
+	// Catalog is passed to the tap by --catalog, or --properties for legacy taps
+	Catalog datatypes.JSON `mapstructure:"catalog" json:"catalog"`
+	// Streams are the streams selected from the Catalog to be collected
+	Streams  []string `mapstructure:"streams" json:"streams" gorm:"type:text;serializer:json"`
+	IsLegacy bool     `mapstructure:"isLegacy" json:"isLegacy"`
+}
+
+var _ apihelperabstract.ConnectionValidator = (*SingerConnection)(nil)
+
+func (SingerConnection) TableName() string {
+	return "_tool_singer_connections"
+}
+
+// GetProperties returns the deserialized Catalog
+func (c *SingerConnection) GetProperties() (*tap.SingerTapProperties, errors.Error) {
+	props := &tap.SingerTapProperties{}
+	if len(c.Catalog) == 0 {
+		return props, nil
+	}
+	if err := json.Unmarshal(c.Catalog, props); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid singer catalog")
+	}
+	return props, nil
+}
+
+// GetTapExecutable returns the path of the tap in the directory configured by SINGER_TAPS_DIR
+func (c *SingerConnection) GetTapExecutable() (string, errors.Error) {
+	return ResolveTapExecutable(config.GetConfig().GetString(TapsDirEnv), c.TapName)
+}
+
+// ResolveTapExecutable returns the path of the tap in the dir, the name must be a plain file name so it can not
+// escape from the dir
+func ResolveTapExecutable(dir string, name string) (string, errors.Error) {
+	if dir == "" {
+		return "", errors.BadInput.New(fmt.Sprintf("%s is not configured, no singer tap is allowed to run", TapsDirEnv))
+	}
+	if !tapNamePattern.MatchString(name) {
+		return "", errors.BadInput.New(fmt.Sprintf("invalid tap name %q", name))
+	}
+	path := filepath.Join(dir, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.BadInput.Wrap(err, fmt.Sprintf("tap %s is not installed in %s", name, TapsDirEnv))
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return "", errors.BadInput.New(fmt.Sprintf("tap %s is not an executable file", name))
+	}
+	return path, nil
+}
+
+// ValidateConnection makes sure the tap name is valid and the selected streams exist in the Catalog
+func (c *SingerConnection) ValidateConnection(connection interface{}, vld *validator.Validate) errors.Error {
+	if vld != nil {
+		if err := vld.Struct(connection); err != nil {
+			return errors.BadInput.Wrap(err, "error validating target")
+		}
+	}
+	if !tapNamePattern.MatchString(c.TapName) {
+		return errors.BadInput.New(fmt.Sprintf("invalid tap name %q", c.TapName))
+	}
+	props, err := c.GetProperties()
+	if err != nil {
+		return err
+	}
+	for _, stream := range c.Streams {
+		found := false
+		for _, s := range props.Streams {
+			found = found || s.Stream == stream
+		}
+		if !found {
+			return errors.BadInput.New(fmt.Sprintf("stream %s not found in the catalog", stream))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveTapExecutable(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "tap-pagerduty"), []byte("#!/bin/sh\n"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("not a tap"), 0644))
+
+	path, err := ResolveTapExecutable(dir, "tap-pagerduty")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "tap-pagerduty"), path)
+
+	for _, name := range []string{"", "   ", "tap-pagerduty; rm -rf /", "../tap-pagerduty", "/bin/sh", "tap-github", "readme.txt"} {
+		_, err = ResolveTapExecutable(dir, name)
+		assert.NotNil(t, err, name)
+	}
+
+	_, err = ResolveTapExecutable("", "tap-pagerduty")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/tap"
+	"github.com/apache/incubator-devlake/plugins/singer/models"
+)
+
+var _ plugin.SubTaskEntryPoint = CollectStreams
+
+var CollectStreamsMeta = plugin.SubTaskMeta{
+	Name:             "collectStreams",
+	EntryPoint:       CollectStreams,
+	EnabledByDefault: true,
+	Description:      "Run the singer tap and land records of the selected streams into _raw_singer_* tables",
+	DomainTypes:      plugin.DOMAIN_TYPES,
+}
+
+var invalidTableChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// GetRawTableName returns the raw table name (without the _raw_ prefix) holding the records of the stream
+func GetRawTableName(stream string) string {
+	return "singer_" + strings.Trim(invalidTableChars.ReplaceAllString(strings.ToLower(stream), "_"), "_")
+}
+
+// CollectStreams runs the tap once for each selected stream, state of each stream is persisted separately so
+// the tap can resume from where it stopped
+func CollectStreams(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SingerTaskData)
+	streams := data.GetStreams()
+	if len(streams) == 0 {
+		return errors.BadInput.New(fmt.Sprintf("no stream is selected for singer connection %d", data.Options.ConnectionId))
+	}
+	for _, stream := range streams {
+		taskCtx.GetLogger().Info("collecting singer stream %s", stream)
+		if err := collectStream(taskCtx, data, stream); err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to collect singer stream %s", stream))
+		}
+	}
+	return nil
+}
+
+func collectStream(taskCtx plugin.SubTaskContext, data *SingerTaskData, stream string) errors.Error {
+	// properties are loaded for each stream since the tap marks the stream as selected in them
+	props, err := data.Connection.GetProperties()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, s := range props.Streams {
+		found = found || s.Stream == stream
+	}
+	if !found {
+		return errors.BadInput.New(fmt.Sprintf("stream %s not found in the catalog", stream))
+	}
+	tapExecutable, err := data.Connection.GetTapExecutable()
+	if err != nil {
+		return err
+	}
+	tapClient, err := tap.NewSingerTap(&tap.SingerTapConfig{
+		TapExecutable:    tapExecutable,
+		StreamProperties: props,
+		IsLegacy:         data.Connection.IsLegacy,
+	})
+	if err != nil {
+		return err
+	}
+	collector, err := tap.NewTapCollector(
+		&tap.CollectorArgs[tap.SingerTapStream]{
+			RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
+				Ctx:   taskCtx,
+				Table: GetRawTableName(stream),
+				Params: models.SingerParams{
+					ConnectionId: data.Options.ConnectionId,
+					Stream:       stream,
+				},
+			},
+			TapClient:    tapClient,
+			TapConfig:    data.Connection.Config,
+			ConnectionId: data.Options.ConnectionId,
+			StreamName:   stream,
+			Incremental:  data.Options.Incremental,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/singer/models"
+)
+
+type SingerOptions struct {
+	ConnectionId uint64   `json:"connectionId" mapstructure:"connectionId"`
+	Tasks        []string `json:"tasks,omitempty" mapstructure:"tasks,omitempty"`
+	// Streams overrides the streams selected in the connection
+	Streams []string `json:"streams,omitempty" mapstructure:"streams,omitempty"`
+	// Incremental keeps the records collected previously, the tap would resume from the persisted state anyway
+	Incremental bool `json:"incremental" mapstructure:"incremental"`
+}
+
+type SingerTaskData struct {
+	Options    *SingerOptions
+	Connection *models.SingerConnection
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*SingerOptions, errors.Error) {
+	var op SingerOptions
+	if err := helper.Decode(options, &op, nil); err != nil {
+		return nil, err
+	}
+	if op.ConnectionId == 0 {
+		return nil, errors.BadInput.New("connectionId is invalid")
+	}
+	return &op, nil
+}
+
+// GetStreams returns the streams to be collected
+func (data *SingerTaskData) GetStreams() []string {
+	if len(data.Options.Streams) > 0 {
+		return data.Options.Streams
+	}
+	return data.Connection.Streams
+}
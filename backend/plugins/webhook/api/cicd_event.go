@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"regexp"
+	"strings"
+)
+
+// cicdEvent holds the domain layer entities converted from a native event payload of a CI/CD system
+type cicdEvent struct {
+	Pipeline *devops.CICDPipeline
+	Tasks    []*devops.CICDTask
+	Commits  []*devops.CiCDPipelineCommit
+}
+
+var (
+	productionEnvRegex = regexp.MustCompile(`(?i)prod`)
+	stagingEnvRegex    = regexp.MustCompile(`(?i)stag`)
+	testingEnvRegex    = regexp.MustCompile(`(?i)test|qa|dev|uat`)
+)
+
+// getEnvironment maps an environment name of the CI/CD system to PRODUCTION, STAGING or TESTING,
+// names matching none of them are kept in upper case
+func getEnvironment(name string) string {
+	switch {
+	case name == "":
+		return ""
+	case productionEnvRegex.MatchString(name):
+		return devops.PRODUCTION
+	case stagingEnvRegex.MatchString(name):
+		return devops.STAGING
+	case testingEnvRegex.MatchString(name):
+		return devops.TESTING
+	}
+	return strings.ToUpper(name)
+}
+
+// finishPipeline sets the result, type and duration of the pipeline once all of its tasks are done
+func finishPipeline(pipeline *devops.CICDPipeline, tasks []*devops.CICDTask) {
+	for _, task := range tasks {
+		if task.Status != devops.DONE {
+			pipeline.Status = devops.IN_PROGRESS
+			pipeline.Result = devops.IN_PROGRESS
+			return
+		}
+	}
+	pipelineType, result := getTypeAndResultFromTasks(derefTasks(tasks))
+	if pipeline.Type == `` {
+		pipeline.Type = pipelineType
+	}
+	pipeline.Result = result
+	pipeline.Status = devops.DONE
+	if pipeline.FinishedDate != nil {
+		pipeline.DurationSec = uint64(pipeline.FinishedDate.Sub(pipeline.CreatedDate).Seconds())
+	}
+}
+
+func derefTasks(tasks []*devops.CICDTask) []devops.CICDTask {
+	domainTasks := make([]devops.CICDTask, 0, len(tasks))
+	for _, task := range tasks {
+		domainTasks = append(domainTasks, *task)
+	}
+	return domainTasks
+}
+
+// saveCicdEvent upserts all the entities of the event in one transaction
+func saveCicdEvent(event *cicdEvent) errors.Error {
+	tx := basicRes.GetDal().Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+	err := saveCicdEventEntities(tx, event)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			basicRes.GetLogger().Error(e, "failed to rollback the transaction of cicd event")
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func saveCicdEventEntities(tx dal.Transaction, event *cicdEvent) errors.Error {
+	for _, task := range event.Tasks {
+		err := tx.CreateOrUpdate(task)
+		if err != nil {
+			return err
+		}
+	}
+	err := tx.CreateOrUpdate(event.Pipeline)
+	if err != nil {
+		return err
+	}
+	for _, commit := range event.Commits {
+		err = tx.CreateOrUpdate(commit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
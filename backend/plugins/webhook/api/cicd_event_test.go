@@ -0,0 +1,205 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestGetEnvironment(t *testing.T) {
+	assert.Equal(t, devops.PRODUCTION, getEnvironment("Production"))
+	assert.Equal(t, devops.PRODUCTION, getEnvironment("prod-us-east"))
+	assert.Equal(t, devops.STAGING, getEnvironment("staging"))
+	assert.Equal(t, devops.TESTING, getEnvironment("qa"))
+	assert.Equal(t, devops.TESTING, getEnvironment("development"))
+	assert.Equal(t, "SANDBOX", getEnvironment("sandbox"))
+	assert.Equal(t, "", getEnvironment(""))
+}
+
+func TestConvertGithubDeploymentStatus(t *testing.T) {
+	payload := &GithubDeploymentStatusEvent{}
+	err := json.Unmarshal([]byte(`{
+		"deployment_status": {"id": 2, "state": "success", "environment": "production", "created_at": "2023-03-01T10:05:00Z"},
+		"deployment": {"id": 1, "sha": "abc", "ref": "main", "environment": "production", "created_at": "2023-03-01T10:00:00Z"},
+		"repository": {"full_name": "apache/incubator-devlake", "html_url": "https://github.com/apache/incubator-devlake"}
+	}`), payload)
+	assert.Nil(t, err)
+
+	event := convertGithubDeploymentStatus(1, payload)
+	assert.Equal(t, "webhook:1:github:1", event.Pipeline.Id)
+	assert.Equal(t, devops.DONE, event.Pipeline.Status)
+	assert.Equal(t, devops.SUCCESS, event.Pipeline.Result)
+	assert.Equal(t, devops.DEPLOYMENT, event.Pipeline.Type)
+	assert.Equal(t, uint64(300), event.Pipeline.DurationSec)
+	assert.Equal(t, devops.PRODUCTION, event.Tasks[0].Environment)
+	assert.Equal(t, "webhook:1", event.Tasks[0].CicdScopeId)
+	assert.Equal(t, "abc", event.Commits[0].CommitSha)
+	assert.Equal(t, "https://github.com/apache/incubator-devlake", event.Commits[0].RepoId)
+
+	payload.DeploymentStatus.State = "in_progress"
+	event = convertGithubDeploymentStatus(1, payload)
+	assert.Equal(t, devops.IN_PROGRESS, event.Pipeline.Status)
+	assert.Equal(t, devops.IN_PROGRESS, event.Tasks[0].Result)
+	assert.Nil(t, event.Tasks[0].FinishedDate)
+
+	payload.DeploymentStatus.State = "error"
+	event = convertGithubDeploymentStatus(1, payload)
+	assert.Equal(t, devops.FAILURE, event.Pipeline.Result)
+
+	payload.DeploymentStatus.State = "inactive"
+	assert.Nil(t, convertGithubDeploymentStatus(1, payload))
+}
+
+func TestIsOutdatedDeploymentStatus(t *testing.T) {
+	finished := time.Date(2023, 3, 1, 10, 5, 0, 0, time.UTC)
+	earlier := finished.Add(-time.Minute)
+	later := finished.Add(time.Minute)
+	done := &devops.CICDTask{Status: devops.DONE, Result: devops.SUCCESS, FinishedDate: &finished}
+
+	assert.True(t, isOutdatedDeploymentStatus(done, &devops.CICDTask{Status: devops.IN_PROGRESS}))
+	assert.True(t, isOutdatedDeploymentStatus(done, &devops.CICDTask{Status: devops.DONE, FinishedDate: &earlier}))
+	assert.False(t, isOutdatedDeploymentStatus(done, &devops.CICDTask{Status: devops.DONE, FinishedDate: &later}))
+	assert.False(t, isOutdatedDeploymentStatus(&devops.CICDTask{Status: devops.IN_PROGRESS}, &devops.CICDTask{Status: devops.IN_PROGRESS}))
+}
+
+func TestConvertGitlabPipeline(t *testing.T) {
+	payload := &GitlabPipelineEvent{}
+	err := json.Unmarshal([]byte(`{
+		"object_kind": "pipeline",
+		"object_attributes": {"id": 31, "ref": "main", "sha": "abc", "status": "failed",
+			"created_at": "2016-08-12 15:23:28 UTC", "finished_at": "2016-08-12 15:26:29 UTC", "duration": 63},
+		"project": {"id": 1, "web_url": "http://192.168.64.1:3005/gitlab-org/gitlab-test", "path_with_namespace": "gitlab-org/gitlab-test"},
+		"builds": [
+			{"id": 380, "stage": "deploy", "name": "production", "status": "skipped", "started_at": null, "finished_at": null,
+				"environment": {"name": "production", "deployment_tier": "production"}},
+			{"id": 377, "stage": "test", "name": "test-image", "status": "success",
+				"started_at": "2016-08-12 15:23:28 UTC", "finished_at": "2016-08-12 15:26:29 UTC", "duration": 181},
+			{"id": 378, "stage": "test", "name": "test-build", "status": "failed",
+				"started_at": "2016-08-12 15:26:29 UTC", "finished_at": "2016-08-12 15:26:31 UTC", "duration": 2}
+		]
+	}`), payload)
+	assert.Nil(t, err)
+
+	event := convertGitlabPipeline(1, payload)
+	assert.Equal(t, "webhook:1:gitlab:1:31", event.Pipeline.Id)
+	assert.Equal(t, devops.DONE, event.Pipeline.Status)
+	assert.Equal(t, devops.FAILURE, event.Pipeline.Result)
+	assert.Equal(t, "", event.Pipeline.Type)
+	assert.Equal(t, devops.PRODUCTION, event.Pipeline.Environment)
+	assert.Equal(t, uint64(63), event.Pipeline.DurationSec)
+	assert.Equal(t, "2016-08-12T15:23:28Z", event.Pipeline.CreatedDate.UTC().Format("2006-01-02T15:04:05Z"))
+
+	assert.Len(t, event.Tasks, 3)
+	assert.Equal(t, devops.DEPLOYMENT, event.Tasks[0].Type)
+	assert.Equal(t, devops.ABORT, event.Tasks[0].Result)
+	assert.Equal(t, event.Pipeline.CreatedDate, event.Tasks[0].StartedDate)
+	assert.Equal(t, devops.TEST, event.Tasks[1].Type)
+	assert.Equal(t, devops.SUCCESS, event.Tasks[1].Result)
+	assert.Equal(t, devops.DONE, event.Tasks[1].Status)
+	assert.Equal(t, devops.FAILURE, event.Tasks[2].Result)
+	assert.Equal(t, "main", event.Commits[0].Branch)
+
+	payload.ObjectAttributes.Status = "running"
+	event = convertGitlabPipeline(1, payload)
+	assert.Equal(t, devops.IN_PROGRESS, event.Pipeline.Status)
+}
+
+func TestConvertJenkinsNotification(t *testing.T) {
+	payload := &JenkinsNotificationEvent{}
+	err := json.Unmarshal([]byte(`{
+		"name": "asgard",
+		"build": {"full_url": "http://localhost:8080/job/asgard/18/", "number": 18, "phase": "STARTED",
+			"timestamp": 1677664800000, "duration": 0,
+			"scm": {"url": "https://github.com/Netflix/asgard.git", "branch": "origin/master", "commit": "c6d86dc"}}
+	}`), payload)
+	assert.Nil(t, err)
+
+	event := convertJenkinsNotification(1, payload, devops.DEPLOYMENT, devops.STAGING)
+	assert.Equal(t, "webhook:1:jenkins:asgard:18", event.Pipeline.Id)
+	assert.Equal(t, devops.IN_PROGRESS, event.Pipeline.Status)
+	assert.Equal(t, devops.IN_PROGRESS, event.Tasks[0].Result)
+	assert.Equal(t, devops.STAGING, event.Tasks[0].Environment)
+	assert.Equal(t, "master", event.Commits[0].Branch)
+
+	payload.Build.Phase = "COMPLETED"
+	payload.Build.Status = "UNSTABLE"
+	payload.Build.Duration = 90500
+	event = convertJenkinsNotification(1, payload, devops.DEPLOYMENT, devops.STAGING)
+	assert.Equal(t, devops.DONE, event.Pipeline.Status)
+	assert.Equal(t, devops.FAILURE, event.Pipeline.Result)
+	assert.Equal(t, uint64(90), event.Tasks[0].DurationSec)
+	assert.Equal(t, uint64(90), event.Pipeline.DurationSec)
+
+	payload.Build.Scm.Commit = ""
+	event = convertJenkinsNotification(1, payload, devops.BUILD, devops.PRODUCTION)
+	assert.Empty(t, event.Commits)
+	assert.Equal(t, devops.BUILD, event.Pipeline.Type)
+}
+
+func TestSaveCicdEvent(t *testing.T) {
+	event := &cicdEvent{
+		Pipeline: &devops.CICDPipeline{},
+		Tasks:    []*devops.CICDTask{{}, {}},
+		Commits:  []*devops.CiCDPipelineCommit{{}},
+	}
+	tx := new(mockdal.Transaction)
+	tx.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil).Times(4)
+	tx.On("Commit").Return(nil).Once()
+	originalBasicRes := basicRes
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Begin").Return(tx).Once()
+	})
+	defer func() {
+		basicRes = originalBasicRes
+	}()
+
+	assert.Nil(t, saveCicdEvent(event))
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Rollback")
+}
+
+func TestSaveCicdEventRollback(t *testing.T) {
+	event := &cicdEvent{
+		Pipeline: &devops.CICDPipeline{},
+		Tasks:    []*devops.CICDTask{{}},
+		Commits:  []*devops.CiCDPipelineCommit{{}},
+	}
+	tx := new(mockdal.Transaction)
+	tx.On("CreateOrUpdate", event.Tasks[0], mock.Anything).Return(nil).Once()
+	tx.On("CreateOrUpdate", event.Pipeline, mock.Anything).Return(errors.Default.New("deadlock")).Once()
+	tx.On("Rollback").Return(nil).Once()
+	originalBasicRes := basicRes
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		mockDal.On("Begin").Return(tx).Once()
+	})
+	defer func() {
+		basicRes = originalBasicRes
+	}()
+
+	assert.NotNil(t, saveCicdEvent(event))
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Commit")
+}
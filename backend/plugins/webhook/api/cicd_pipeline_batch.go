@@ -0,0 +1,297 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type WebhookPipelineCommitRequest struct {
+	// RepoUrl should be unique string, fill url or other unique data
+	RepoUrl   string `mapstructure:"repo_url" validate:"required"`
+	CommitSha string `mapstructure:"commit_sha" validate:"required"`
+	Branch    string
+}
+
+type WebhookPipelineTaskRequest struct {
+	Name         string     `validate:"required"` // Name should be unique in one pipeline
+	Result       string     `validate:"omitempty,oneof=SUCCESS FAILURE ABORT IN_PROGRESS"`
+	Status       string     `validate:"omitempty,oneof=IN_PROGRESS DONE"`
+	Type         string     `validate:"omitempty,oneof=TEST LINT BUILD DEPLOYMENT"`
+	Environment  string     `validate:"omitempty,oneof=PRODUCTION STAGING TESTING"`
+	StartedDate  *time.Time `mapstructure:"start_time"`
+	FinishedDate *time.Time `mapstructure:"end_time"`
+}
+
+type WebhookPipelineRequest struct {
+	// PipelineName can be filled by any string unique in one connection, it is shared with cicd_tasks endpoint
+	PipelineName string     `mapstructure:"pipeline_name" validate:"required"`
+	Result       string     `validate:"omitempty,oneof=SUCCESS FAILURE ABORT IN_PROGRESS"`
+	Status       string     `validate:"omitempty,oneof=IN_PROGRESS DONE"`
+	Environment  string     `validate:"omitempty,oneof=PRODUCTION STAGING TESTING"`
+	StartedDate  *time.Time `mapstructure:"start_time"`
+	FinishedDate *time.Time `mapstructure:"end_time"`
+
+	Commits []WebhookPipelineCommitRequest `validate:"dive"`
+	// Tasks is optional, a DEPLOYMENT task is created with the result and status of pipeline if absent
+	Tasks []WebhookPipelineTaskRequest `validate:"dive"`
+}
+
+// PostCicdPipeline
+// @Summary create or update a deployment with multiple commits and tasks by webhook
+// @Description Create or update a pipeline with all of its commits and tasks in one request, e.g. a release of a monorepo or several services.<br/>
+// @Description example1: {"pipeline_name":"release-1.0","environment":"PRODUCTION","start_time":"2020-01-01T12:00:00+00:00","end_time":"2020-01-01T12:59:59+00:00","commits":[{"repo_url":"https://github.com/apache/incubator-devlake","commit_sha":"015e3d3b480e417aede5a1293bd61de9b0fd051d","branch":"main"},{"repo_url":"https://github.com/apache/incubator-devlake-website","commit_sha":"a2a4e7c4f1b9c5cc4b3eeb5a9f1e5e0d67e8d1d3"}]}<br/>
+// @Description example2: {"pipeline_name":"A123","commits":[{"repo_url":"devlake","commit_sha":"015e3d3b480e417aede5a1293bd61de9b0fd051d"}],"tasks":[{"name":"unit-test","type":"TEST","result":"SUCCESS","status":"DONE","start_time":"2020-01-01T12:00:00+00:00","end_time":"2020-01-01T12:10:00+00:00"},{"name":"deploy","type":"DEPLOYMENT","environment":"PRODUCTION","result":"IN_PROGRESS","status":"IN_PROGRESS","start_time":"2020-01-01T12:10:00+00:00"}]}<br/>
+// @Description Requests are idempotent: tasks and commits are upserted, and the pipeline is recalculated from all of its tasks,
+// @Description so a late task reopens or updates a pipeline which has already been done.
+// @Tags plugins/webhook
+// @Param body body WebhookPipelineRequest true "json body"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/:connectionId/cicd_pipelines [POST]
+func PostCicdPipeline(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	// get request
+	request := &WebhookPipelineRequest{}
+	err = api.DecodeMapStruct(input.Body, request)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+	}
+	// validate
+	vld = validator.New()
+	err = errors.Convert(vld.Struct(request))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(vld.Struct(request), `input json error`)
+	}
+
+	// the pipeline, tasks and commits are saved all or nothing
+	tx := basicRes.GetDal().Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+	err = savePipelineRequest(tx, request, connection.ID, time.Now())
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			basicRes.GetLogger().Error(e, "failed to rollback the transaction of cicd pipeline")
+		}
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// savePipelineRequest upserts the tasks and commits of the request, then recalculates the pipeline from all of its tasks
+func savePipelineRequest(tx dal.Transaction, request *WebhookPipelineRequest, connectionId uint64, now time.Time) errors.Error {
+	scopeId := fmt.Sprintf("%s:%d", "webhook", connectionId)
+	pipelineId := fmt.Sprintf("%s:%d:%s", "webhook", connectionId, request.PipelineName)
+
+	// lock the pipeline so the requests of the same pipeline are saved one by one
+	domainPipeline := &devops.CICDPipeline{}
+	err := tx.First(domainPipeline, dal.Where("id = ?", pipelineId), dal.Lock(true, false))
+	if err != nil {
+		if !tx.IsErrorNotFound(err) {
+			return err
+		}
+		domainPipeline = &devops.CICDPipeline{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: pipelineId,
+			},
+			Name: request.PipelineName,
+		}
+	}
+	existingTasks := []devops.CICDTask{}
+	err = tx.All(&existingTasks, dal.Where("pipeline_id = ?", pipelineId))
+	if err != nil {
+		return err
+	}
+	existingTaskMap := make(map[string]*devops.CICDTask, len(existingTasks))
+	for i := range existingTasks {
+		existingTaskMap[existingTasks[i].Id] = &existingTasks[i]
+	}
+
+	for _, task := range convertPipelineTasks(request, pipelineId, scopeId, existingTaskMap, now) {
+		err = tx.CreateOrUpdate(task)
+		if err != nil {
+			return err
+		}
+	}
+	for _, commit := range request.Commits {
+		err = tx.CreateOrUpdate(&devops.CiCDPipelineCommit{
+			PipelineId: pipelineId,
+			CommitSha:  commit.CommitSha,
+			Branch:     commit.Branch,
+			RepoId:     commit.RepoUrl,
+			Repo:       commit.RepoUrl,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// recalculate the pipeline from all of its tasks, including those received before
+	domainTasks := []devops.CICDTask{}
+	err = tx.All(&domainTasks, dal.Where("pipeline_id = ?", pipelineId))
+	if err != nil {
+		return err
+	}
+	domainPipeline.CicdScopeId = scopeId
+	if request.Environment != `` {
+		domainPipeline.Environment = request.Environment
+	}
+	refreshPipeline(domainPipeline, domainTasks, request.FinishedDate)
+	return tx.CreateOrUpdate(domainPipeline)
+}
+
+// convertPipelineTasks converts the tasks in request, or makes up a DEPLOYMENT task if there is none. Dates absent
+// from the request are taken from the existing tasks, and `now` is used only for the tasks never saved before
+func convertPipelineTasks(
+	request *WebhookPipelineRequest,
+	pipelineId, scopeId string,
+	existingTasks map[string]*devops.CICDTask,
+	now time.Time,
+) []*devops.CICDTask {
+	taskRequests := request.Tasks
+	if len(taskRequests) == 0 {
+		taskRequests = []WebhookPipelineTaskRequest{{
+			Name:         `deployment`,
+			Result:       request.Result,
+			Status:       request.Status,
+			Type:         devops.DEPLOYMENT,
+			Environment:  request.Environment,
+			StartedDate:  request.StartedDate,
+			FinishedDate: request.FinishedDate,
+		}}
+	}
+	tasks := make([]*devops.CICDTask, 0, len(taskRequests))
+	for _, taskRequest := range taskRequests {
+		task := &devops.CICDTask{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: fmt.Sprintf("%s:%s", pipelineId, taskRequest.Name),
+			},
+			PipelineId:   pipelineId,
+			Name:         taskRequest.Name,
+			Result:       taskRequest.Result,
+			Status:       taskRequest.Status,
+			Type:         taskRequest.Type,
+			Environment:  taskRequest.Environment,
+			FinishedDate: taskRequest.FinishedDate,
+			CicdScopeId:  scopeId,
+		}
+		if task.Environment == `` {
+			task.Environment = request.Environment
+		}
+		if task.Type == devops.DEPLOYMENT && task.Environment == `` {
+			task.Environment = devops.PRODUCTION
+		}
+		// a task with end_time is done unless told otherwise
+		if task.Status == `` {
+			if task.FinishedDate != nil || (task.Result != `` && task.Result != devops.IN_PROGRESS) {
+				task.Status = devops.DONE
+			} else {
+				task.Status = devops.IN_PROGRESS
+			}
+		}
+		if task.Result == `` {
+			if task.Status == devops.DONE {
+				task.Result = devops.SUCCESS
+			} else {
+				task.Result = devops.IN_PROGRESS
+			}
+		}
+		existing := existingTasks[task.Id]
+		switch {
+		case taskRequest.StartedDate != nil:
+			task.StartedDate = *taskRequest.StartedDate
+		case request.StartedDate != nil:
+			task.StartedDate = *request.StartedDate
+		case existing != nil && !existing.StartedDate.IsZero():
+			task.StartedDate = existing.StartedDate
+		default:
+			task.StartedDate = now
+		}
+		if task.Status == devops.DONE && task.FinishedDate == nil {
+			if existing != nil && existing.FinishedDate != nil {
+				task.FinishedDate = existing.FinishedDate
+			} else {
+				finishedDate := now
+				task.FinishedDate = &finishedDate
+			}
+		}
+		if task.FinishedDate != nil {
+			task.DurationSec = uint64(task.FinishedDate.Sub(task.StartedDate).Seconds())
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// refreshPipeline recalculates status, result, type and dates of the pipeline from all of its tasks,
+// a pipeline is reopened if any of its tasks is still in progress
+func refreshPipeline(pipeline *devops.CICDPipeline, tasks []devops.CICDTask, finishedDate *time.Time) {
+	pipeline.Type, pipeline.Result = getTypeAndResultFromTasks(tasks)
+	pipeline.Status = devops.DONE
+	var lastFinishedDate *time.Time
+	for _, task := range tasks {
+		if pipeline.CreatedDate.IsZero() || task.StartedDate.Before(pipeline.CreatedDate) {
+			pipeline.CreatedDate = task.StartedDate
+		}
+		if task.Status != devops.DONE {
+			pipeline.Status = devops.IN_PROGRESS
+		} else if task.FinishedDate != nil && (lastFinishedDate == nil || task.FinishedDate.After(*lastFinishedDate)) {
+			lastFinishedDate = task.FinishedDate
+		}
+		if pipeline.Environment == `` && task.Type == devops.DEPLOYMENT {
+			pipeline.Environment = task.Environment
+		}
+	}
+	if pipeline.Status != devops.DONE {
+		pipeline.Result = devops.IN_PROGRESS
+		pipeline.FinishedDate = nil
+		pipeline.DurationSec = 0
+		return
+	}
+	if finishedDate != nil {
+		lastFinishedDate = finishedDate
+	}
+	pipeline.FinishedDate = lastFinishedDate
+	if pipeline.FinishedDate != nil {
+		pipeline.DurationSec = uint64(pipeline.FinishedDate.Sub(pipeline.CreatedDate).Seconds())
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConvertPipelineTasks(t *testing.T) {
+	now := time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC)
+	startTime := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	request := &WebhookPipelineRequest{}
+	err := api.DecodeMapStruct(map[string]interface{}{
+		"pipeline_name": "release-1.0",
+		"environment":   "STAGING",
+		"start_time":    "2020-01-01T12:00:00+00:00",
+		"commits": []interface{}{
+			map[string]interface{}{"repo_url": "a", "commit_sha": "1"},
+			map[string]interface{}{"repo_url": "b", "commit_sha": "2"},
+		},
+	}, request)
+	assert.Nil(t, err)
+	assert.Len(t, request.Commits, 2)
+
+	// a deployment task is made up if there is none
+	tasks := convertPipelineTasks(request, "webhook:1:release-1.0", "webhook:1", nil, now)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, "webhook:1:release-1.0:deployment", tasks[0].Id)
+	assert.Equal(t, devops.DEPLOYMENT, tasks[0].Type)
+	assert.Equal(t, devops.STAGING, tasks[0].Environment)
+	assert.Equal(t, devops.IN_PROGRESS, tasks[0].Status)
+	assert.Equal(t, devops.IN_PROGRESS, tasks[0].Result)
+	assert.True(t, startTime.Equal(tasks[0].StartedDate))
+
+	request.Status = devops.DONE
+	tasks = convertPipelineTasks(request, "webhook:1:release-1.0", "webhook:1", nil, now)
+	assert.Equal(t, devops.SUCCESS, tasks[0].Result)
+	assert.Equal(t, now, *tasks[0].FinishedDate)
+	assert.Equal(t, uint64(3600), tasks[0].DurationSec)
+
+	request.Tasks = []WebhookPipelineTaskRequest{
+		{Name: "unit-test", Type: devops.TEST, Result: devops.FAILURE},
+		{Name: "deploy", Type: devops.DEPLOYMENT, Environment: devops.PRODUCTION},
+	}
+	tasks = convertPipelineTasks(request, "webhook:1:release-1.0", "webhook:1", nil, now)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, devops.DONE, tasks[0].Status)
+	assert.Equal(t, devops.FAILURE, tasks[0].Result)
+	assert.Equal(t, devops.STAGING, tasks[0].Environment)
+	assert.Equal(t, devops.IN_PROGRESS, tasks[1].Status)
+	assert.Equal(t, devops.PRODUCTION, tasks[1].Environment)
+
+	// dates absent from a later request are kept as they were saved
+	earlier := now.Add(-30 * time.Minute)
+	request.StartedDate = nil
+	request.Tasks = []WebhookPipelineTaskRequest{{Name: "deploy", Status: devops.DONE}}
+	tasks = convertPipelineTasks(request, "webhook:1:release-1.0", "webhook:1", map[string]*devops.CICDTask{
+		"webhook:1:release-1.0:deploy": {StartedDate: startTime, FinishedDate: &earlier},
+	}, now.Add(time.Hour))
+	assert.True(t, startTime.Equal(tasks[0].StartedDate))
+	assert.Equal(t, earlier, *tasks[0].FinishedDate)
+	assert.Equal(t, uint64(1800), tasks[0].DurationSec)
+}
+
+func TestRefreshPipeline(t *testing.T) {
+	t1 := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(10 * time.Minute)
+	t3 := t1.Add(30 * time.Minute)
+	test := devops.CICDTask{Type: devops.TEST, Result: devops.SUCCESS, Status: devops.DONE, StartedDate: t1, FinishedDate: &t2}
+	deploy := devops.CICDTask{Type: devops.DEPLOYMENT, Environment: devops.PRODUCTION, Result: devops.SUCCESS, Status: devops.DONE, StartedDate: t2, FinishedDate: &t3}
+
+	pipeline := &devops.CICDPipeline{}
+	refreshPipeline(pipeline, []devops.CICDTask{test, deploy}, nil)
+	assert.Equal(t, devops.DONE, pipeline.Status)
+	assert.Equal(t, devops.SUCCESS, pipeline.Result)
+	assert.Equal(t, ``, pipeline.Type)
+	assert.Equal(t, devops.PRODUCTION, pipeline.Environment)
+	assert.Equal(t, t1, pipeline.CreatedDate)
+	assert.Equal(t, t3, *pipeline.FinishedDate)
+	assert.Equal(t, uint64(1800), pipeline.DurationSec)
+
+	// a late task reopens the pipeline
+	rollback := devops.CICDTask{Type: devops.DEPLOYMENT, Result: devops.IN_PROGRESS, Status: devops.IN_PROGRESS, StartedDate: t3}
+	refreshPipeline(pipeline, []devops.CICDTask{test, deploy, rollback}, nil)
+	assert.Equal(t, devops.IN_PROGRESS, pipeline.Status)
+	assert.Equal(t, devops.IN_PROGRESS, pipeline.Result)
+	assert.Nil(t, pipeline.FinishedDate)
+
+	// and finishes it again
+	rollback.Result = devops.FAILURE
+	rollback.Status = devops.DONE
+	refreshPipeline(pipeline, []devops.CICDTask{test, deploy, rollback}, &t3)
+	assert.Equal(t, devops.DONE, pipeline.Status)
+	assert.Equal(t, devops.FAILURE, pipeline.Result)
+	assert.Equal(t, t3, *pipeline.FinishedDate)
+}
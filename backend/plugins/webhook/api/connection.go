@@ -30,7 +30,7 @@ import (
 // @Description Create webhook connection, example: {"name":"Webhook data connection name"}
 // @Tags plugins/webhook
 // @Param body body models.WebhookConnection true "json body"
-// @Success 200  {object} models.WebhookConnection
+// @Success 200  {object} WebhookConnectionResponse
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/connections [POST]
@@ -41,7 +41,7 @@ func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 	if err != nil {
 		return nil, err
 	}
-	return &plugin.ApiResourceOutput{Body: connection, Status: http.StatusOK}, nil
+	return &plugin.ApiResourceOutput{Body: formatConnection(connection), Status: http.StatusOK}, nil
 }
 
 // PatchConnection
@@ -49,24 +49,28 @@ func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 // @Description Patch webhook connection
 // @Tags plugins/webhook
 // @Param body body models.WebhookConnection true "json body"
-// @Success 200  {object} models.WebhookConnection
+// @Success 200  {object} WebhookConnectionResponse
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/connections/{connectionId} [PATCH]
 func PatchConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	connection := &models.WebhookConnection{}
+	// the masked secret sent back by clients is not meant to change the secret
+	if secret, ok := input.Body["secret"].(string); ok && secret == secretMask {
+		delete(input.Body, "secret")
+	}
 	err := connectionHelper.Patch(connection, input)
 	if err != nil {
 		return nil, err
 	}
-	return &plugin.ApiResourceOutput{Body: connection}, nil
+	return &plugin.ApiResourceOutput{Body: formatConnection(connection)}, nil
 }
 
 // DeleteConnection
 // @Summary delete a webhook connection
 // @Description Delete a webhook connection
 // @Tags plugins/webhook
-// @Success 200  {object} models.WebhookConnection
+// @Success 200  {object} WebhookConnectionResponse
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/webhook/connections/{connectionId} [DELETE]
@@ -77,9 +81,12 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 		return nil, err
 	}
 	err = connectionHelper.Delete(connection)
-	return &plugin.ApiResourceOutput{Body: connection}, err
+	return &plugin.ApiResourceOutput{Body: formatConnection(connection)}, err
 }
 
+// secretMask replaces the secret of connections in responses
+const secretMask = "********"
+
 type WebhookConnectionResponse struct {
 	models.WebhookConnection
 	PostIssuesEndpoint             string `json:"postIssuesEndpoint"`
@@ -87,6 +94,10 @@ type WebhookConnectionResponse struct {
 	PostPipelineTaskEndpoint       string `json:"postPipelineTaskEndpoint"`
 	PostPipelineDeployTaskEndpoint string `json:"postPipelineDeployTaskEndpoint"`
 	ClosePipelineEndpoint          string `json:"closePipelineEndpoint"`
+	PostPipelineEndpoint           string `json:"postPipelineEndpoint"`
+	GithubDeploymentStatusEndpoint string `json:"githubDeploymentStatusEndpoint"`
+	GitlabPipelineEndpoint         string `json:"gitlabPipelineEndpoint"`
+	JenkinsNotificationEndpoint    string `json:"jenkinsNotificationEndpoint"`
 }
 
 // ListConnections
@@ -127,10 +138,17 @@ func GetConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, e
 
 func formatConnection(connection *models.WebhookConnection) *WebhookConnectionResponse {
 	response := &WebhookConnectionResponse{WebhookConnection: *connection}
+	if response.Secret != "" {
+		response.Secret = secretMask
+	}
 	response.PostIssuesEndpoint = fmt.Sprintf(`/plugins/webhook/%d/issues`, connection.ID)
 	response.CloseIssuesEndpoint = fmt.Sprintf(`/plugins/webhook/%d/issue/:boardKey/:issueKey/close`, connection.ID)
 	response.PostPipelineTaskEndpoint = fmt.Sprintf(`/plugins/webhook/%d/cicd_tasks`, connection.ID)
 	response.PostPipelineDeployTaskEndpoint = fmt.Sprintf(`/plugins/webhook/%d/deployments`, connection.ID)
 	response.ClosePipelineEndpoint = fmt.Sprintf(`/plugins/webhook/%d/cicd_pipeline/:pipelineName/finish`, connection.ID)
+	response.PostPipelineEndpoint = fmt.Sprintf(`/plugins/webhook/%d/cicd_pipelines`, connection.ID)
+	response.GithubDeploymentStatusEndpoint = fmt.Sprintf(`/plugins/webhook/%d/github/deployment_status`, connection.ID)
+	response.GitlabPipelineEndpoint = fmt.Sprintf(`/plugins/webhook/%d/gitlab/pipeline`, connection.ID)
+	response.JenkinsNotificationEndpoint = fmt.Sprintf(`/plugins/webhook/%d/jenkins/notification`, connection.ID)
 	return response
 }
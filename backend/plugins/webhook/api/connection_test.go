@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFormatConnectionMasksSecret(t *testing.T) {
+	connection := &models.WebhookConnection{Secret: "top-secret-hmac-key"}
+	connection.ID = 1
+	connection.Name = "jenkins"
+
+	response := formatConnection(connection)
+	assert.Equal(t, secretMask, response.Secret)
+	assert.Equal(t, "top-secret-hmac-key", connection.Secret)
+	body, err := json.Marshal(response)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(body), "top-secret-hmac-key")
+	assert.Contains(t, string(body), "/plugins/webhook/1/deployments")
+
+	assert.Equal(t, "", formatConnection(&models.WebhookConnection{}).Secret)
+}
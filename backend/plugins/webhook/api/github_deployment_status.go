@@ -0,0 +1,198 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"net/http"
+	"time"
+)
+
+// GithubDeploymentStatusEvent is the part of GitHub `deployment_status` event payload we care about
+type GithubDeploymentStatusEvent struct {
+	DeploymentStatus struct {
+		Id          int64     `json:"id"`
+		State       string    `json:"state"`
+		Environment string    `json:"environment"`
+		CreatedAt   time.Time `json:"created_at"`
+	} `json:"deployment_status"`
+	Deployment struct {
+		Id                    int64     `json:"id"`
+		Sha                   string    `json:"sha"`
+		Ref                   string    `json:"ref"`
+		Task                  string    `json:"task"`
+		Environment           string    `json:"environment"`
+		ProductionEnvironment bool      `json:"production_environment"`
+		CreatedAt             time.Time `json:"created_at"`
+	} `json:"deployment"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		HtmlUrl  string `json:"html_url"`
+	} `json:"repository"`
+}
+
+// PostGithubDeploymentStatus
+// @Summary receive GitHub deployment_status event
+// @Description Receive the native `deployment_status` event sent by a GitHub webhook, and save it as cicd_pipeline, cicd_task and cicd_pipeline_commit.<br/>
+// @Description If the connection has a secret, it must be the secret of the GitHub webhook so that `X-Hub-Signature-256` could be verified.<br/>
+// @Description `ping` events are accepted and ignored.
+// @Tags plugins/webhook
+// @Param body body GithubDeploymentStatusEvent true "json body"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/:connectionId/github/deployment_status [POST]
+func PostGithubDeploymentStatus(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readRawBody(input)
+	if err != nil {
+		return nil, err
+	}
+	err = verifyHmacSha256(connection, body, input.Request.Header.Get("X-Hub-Signature-256"))
+	if err != nil {
+		return nil, err
+	}
+	switch eventName := input.Request.Header.Get("X-GitHub-Event"); eventName {
+	case "ping":
+		return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	case "deployment_status":
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("unsupported GitHub event: %s", eventName))
+	}
+	payload := &GithubDeploymentStatusEvent{}
+	err = errors.Convert(json.Unmarshal(body, payload))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse deployment_status event")
+	}
+	event := convertGithubDeploymentStatus(connection.ID, payload)
+	if event == nil {
+		return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	}
+	// statuses might be delivered out of order, a finished deployment must not be reverted by an older status
+	existing := &devops.CICDTask{}
+	err = basicRes.GetDal().First(existing, dal.Where("id = ?", event.Tasks[0].Id))
+	if err != nil && !basicRes.GetDal().IsErrorNotFound(err) {
+		return nil, err
+	}
+	if err == nil && isOutdatedDeploymentStatus(existing, event.Tasks[0]) {
+		return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	}
+	err = saveCicdEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// isOutdatedDeploymentStatus returns true if the task is finished already and the status comes from an earlier time
+func isOutdatedDeploymentStatus(existing *devops.CICDTask, task *devops.CICDTask) bool {
+	if existing.Status != devops.DONE {
+		return false
+	}
+	if task.Status != devops.DONE {
+		return true
+	}
+	return existing.FinishedDate != nil && task.FinishedDate.Before(*existing.FinishedDate)
+}
+
+// convertGithubDeploymentStatus converts the event to one deployment pipeline with a single task,
+// every status of the same deployment updates the same records. nil is returned for the `inactive` status since
+// GitHub sets it to the previous deployments of the environment when a new one succeeds, which says nothing about
+// the deployment itself
+func convertGithubDeploymentStatus(connectionId uint64, payload *GithubDeploymentStatusEvent) *cicdEvent {
+	if payload.DeploymentStatus.State == "inactive" {
+		return nil
+	}
+	scopeId := fmt.Sprintf("%s:%d", "webhook", connectionId)
+	pipelineId := fmt.Sprintf("%s:%d:%s:%d", "webhook", connectionId, "github", payload.Deployment.Id)
+	environment := payload.DeploymentStatus.Environment
+	if environment == `` {
+		environment = payload.Deployment.Environment
+	}
+	environment = getEnvironment(environment)
+	if payload.Deployment.ProductionEnvironment {
+		environment = devops.PRODUCTION
+	}
+
+	task := &devops.CICDTask{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: fmt.Sprintf("%s:%d:%s:%d", "webhook", connectionId, "github", payload.Deployment.Id),
+		},
+		PipelineId:  pipelineId,
+		Name:        fmt.Sprintf("deploy %s to %s", payload.Deployment.Ref, payload.Deployment.Environment),
+		Type:        devops.DEPLOYMENT,
+		Environment: environment,
+		StartedDate: payload.Deployment.CreatedAt,
+		CicdScopeId: scopeId,
+	}
+	switch payload.DeploymentStatus.State {
+	case "success":
+		task.Result = devops.SUCCESS
+		task.Status = devops.DONE
+	case "failure", "error":
+		task.Result = devops.FAILURE
+		task.Status = devops.DONE
+	default:
+		// pending, queued and in_progress
+		task.Result = devops.IN_PROGRESS
+		task.Status = devops.IN_PROGRESS
+	}
+	if task.Status == devops.DONE {
+		finishedDate := payload.DeploymentStatus.CreatedAt
+		task.FinishedDate = &finishedDate
+		task.DurationSec = uint64(finishedDate.Sub(task.StartedDate).Seconds())
+	}
+
+	pipeline := &devops.CICDPipeline{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: pipelineId,
+		},
+		Name:         fmt.Sprintf("%s deployment %d", payload.Repository.FullName, payload.Deployment.Id),
+		Type:         devops.DEPLOYMENT,
+		CreatedDate:  task.StartedDate,
+		FinishedDate: task.FinishedDate,
+		Environment:  environment,
+		CicdScopeId:  scopeId,
+	}
+	tasks := []*devops.CICDTask{task}
+	finishPipeline(pipeline, tasks)
+
+	return &cicdEvent{
+		Pipeline: pipeline,
+		Tasks:    tasks,
+		Commits: []*devops.CiCDPipelineCommit{{
+			PipelineId: pipelineId,
+			CommitSha:  payload.Deployment.Sha,
+			Branch:     payload.Deployment.Ref,
+			RepoId:     payload.Repository.HtmlUrl,
+			Repo:       payload.Repository.HtmlUrl,
+		}},
+	}
+}
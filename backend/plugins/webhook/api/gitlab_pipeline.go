@@ -0,0 +1,230 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// gitlabTime accepts both `2016-08-12 15:23:28 UTC` used by GitLab hooks and ISO8601
+type gitlabTime struct {
+	time *time.Time
+}
+
+func (t *gitlabTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil || s == "" {
+		return nil
+	}
+	parsed, err := time.Parse("2006-01-02 15:04:05 MST", s)
+	if err != nil {
+		parsed, err = api.ConvertStringToTime(s)
+		if err != nil {
+			return err
+		}
+	}
+	t.time = &parsed
+	return nil
+}
+
+// ToTime returns nil if the value was null or absent
+func (t *gitlabTime) ToTime() *time.Time {
+	if t == nil {
+		return nil
+	}
+	return t.time
+}
+
+// GitlabPipelineEvent is the part of GitLab pipeline hook payload we care about
+type GitlabPipelineEvent struct {
+	ObjectKind       string `json:"object_kind"`
+	ObjectAttributes struct {
+		Id         int64       `json:"id"`
+		Ref        string      `json:"ref"`
+		Sha        string      `json:"sha"`
+		Status     string      `json:"status"`
+		CreatedAt  *gitlabTime `json:"created_at"`
+		FinishedAt *gitlabTime `json:"finished_at"`
+		Duration   float64     `json:"duration"`
+	} `json:"object_attributes"`
+	Project struct {
+		Id                int64  `json:"id"`
+		WebUrl            string `json:"web_url"`
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	Builds []struct {
+		Id          int64       `json:"id"`
+		Stage       string      `json:"stage"`
+		Name        string      `json:"name"`
+		Status      string      `json:"status"`
+		CreatedAt   *gitlabTime `json:"created_at"`
+		StartedAt   *gitlabTime `json:"started_at"`
+		FinishedAt  *gitlabTime `json:"finished_at"`
+		Duration    float64     `json:"duration"`
+		Environment *struct {
+			Name           string `json:"name"`
+			DeploymentTier string `json:"deployment_tier"`
+		} `json:"environment"`
+	} `json:"builds"`
+}
+
+var gitlabResultRule = devops.ResultRule{
+	Success: []string{"success"},
+	Failed:  []string{"failed"},
+	Abort:   []string{"canceled", "skipped"},
+	Manual:  []string{"manual"},
+	Default: devops.IN_PROGRESS,
+}
+
+// PostGitlabPipeline
+// @Summary receive GitLab pipeline hook
+// @Description Receive the native pipeline hook sent by GitLab, and save it as cicd_pipeline, cicd_tasks and cicd_pipeline_commit.<br/>
+// @Description If the connection has a secret, it must be the secret token of the GitLab webhook, which is sent as `X-Gitlab-Token`.
+// @Tags plugins/webhook
+// @Param body body GitlabPipelineEvent true "json body"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/:connectionId/gitlab/pipeline [POST]
+func PostGitlabPipeline(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readRawBody(input)
+	if err != nil {
+		return nil, err
+	}
+	err = verifyToken(connection, input.Request.Header.Get("X-Gitlab-Token"))
+	if err != nil {
+		return nil, err
+	}
+	payload := &GitlabPipelineEvent{}
+	err = errors.Convert(json.Unmarshal(body, payload))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse pipeline hook")
+	}
+	if payload.ObjectKind != "pipeline" {
+		return nil, errors.BadInput.New(fmt.Sprintf("unsupported GitLab hook: %s", payload.ObjectKind))
+	}
+	err = saveCicdEvent(convertGitlabPipeline(connection.ID, payload))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// convertGitlabPipeline converts the hook to a pipeline with one task per build,
+// GitLab sends the whole pipeline on every status change, so the latest hook always wins
+func convertGitlabPipeline(connectionId uint64, payload *GitlabPipelineEvent) *cicdEvent {
+	scopeId := fmt.Sprintf("%s:%d", "webhook", connectionId)
+	attributes := payload.ObjectAttributes
+	pipelineId := fmt.Sprintf("%s:%d:%s:%d:%d", "webhook", connectionId, "gitlab", payload.Project.Id, attributes.Id)
+
+	pipeline := &devops.CICDPipeline{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: pipelineId,
+		},
+		Name:         fmt.Sprintf("%s pipeline %d", payload.Project.PathWithNamespace, attributes.Id),
+		FinishedDate: attributes.FinishedAt.ToTime(),
+		CicdScopeId:  scopeId,
+	}
+	if createdAt := attributes.CreatedAt.ToTime(); createdAt != nil {
+		pipeline.CreatedDate = *createdAt
+	}
+
+	tasks := make([]*devops.CICDTask, 0, len(payload.Builds))
+	for _, build := range payload.Builds {
+		task := &devops.CICDTask{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: fmt.Sprintf("%s:%d:%s:%d:%d", "webhook", connectionId, "gitlab", payload.Project.Id, build.Id),
+			},
+			PipelineId:   pipelineId,
+			Name:         build.Name,
+			Result:       devops.GetResult(&gitlabResultRule, build.Status),
+			Status:       devops.IN_PROGRESS,
+			FinishedDate: build.FinishedAt.ToTime(),
+			DurationSec:  uint64(build.Duration),
+			CicdScopeId:  scopeId,
+		}
+		if startedAt := build.StartedAt.ToTime(); startedAt != nil {
+			task.StartedDate = *startedAt
+		} else if createdAt := build.CreatedAt.ToTime(); createdAt != nil {
+			task.StartedDate = *createdAt
+		} else {
+			task.StartedDate = pipeline.CreatedDate
+		}
+		if task.Result != devops.IN_PROGRESS {
+			task.Status = devops.DONE
+		}
+		switch {
+		case build.Environment != nil:
+			task.Type = devops.DEPLOYMENT
+			task.Environment = getEnvironment(build.Environment.DeploymentTier)
+			if task.Environment == `` {
+				task.Environment = getEnvironment(build.Environment.Name)
+			}
+		case strings.Contains(strings.ToLower(build.Stage), "deploy"):
+			task.Type = devops.DEPLOYMENT
+		case strings.Contains(strings.ToLower(build.Stage), "test"):
+			task.Type = devops.TEST
+		case strings.Contains(strings.ToLower(build.Stage), "lint"):
+			task.Type = devops.LINT
+		case strings.Contains(strings.ToLower(build.Stage), "build"):
+			task.Type = devops.BUILD
+		}
+		if task.Environment != `` && pipeline.Environment == `` {
+			pipeline.Environment = task.Environment
+		}
+		tasks = append(tasks, task)
+	}
+
+	pipeline.Result = devops.GetResult(&gitlabResultRule, attributes.Status)
+	if pipeline.Result == devops.IN_PROGRESS {
+		pipeline.Status = devops.IN_PROGRESS
+	} else {
+		pipeline.Status = devops.DONE
+		pipeline.DurationSec = uint64(attributes.Duration)
+	}
+	pipelineType, _ := getTypeAndResultFromTasks(derefTasks(tasks))
+	pipeline.Type = pipelineType
+
+	return &cicdEvent{
+		Pipeline: pipeline,
+		Tasks:    tasks,
+		Commits: []*devops.CiCDPipelineCommit{{
+			PipelineId: pipelineId,
+			CommitSha:  attributes.Sha,
+			Branch:     attributes.Ref,
+			RepoId:     payload.Project.WebUrl,
+			Repo:       payload.Project.WebUrl,
+		}},
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JenkinsNotificationEvent is the part of payload sent by Jenkins Notification plugin we care about
+type JenkinsNotificationEvent struct {
+	Name  string `json:"name"`
+	Build struct {
+		FullUrl   string `json:"full_url"`
+		Number    int64  `json:"number"`
+		Phase     string `json:"phase"`
+		Status    string `json:"status"`
+		Timestamp int64  `json:"timestamp"`
+		Duration  int64  `json:"duration"`
+		Scm       struct {
+			Url    string `json:"url"`
+			Branch string `json:"branch"`
+			Commit string `json:"commit"`
+		} `json:"scm"`
+	} `json:"build"`
+}
+
+var jenkinsResultRule = devops.ResultRule{
+	Success: []string{"SUCCESS"},
+	Failed:  []string{"FAILURE", "UNSTABLE"},
+	Abort:   []string{"ABORTED", "NOT_BUILT"},
+	Default: devops.IN_PROGRESS,
+}
+
+// PostJenkinsNotification
+// @Summary receive Jenkins notification
+// @Description Receive the payload sent by Jenkins Notification plugin, and save it as cicd_pipeline, cicd_task and cicd_pipeline_commit.<br/>
+// @Description Query `type` (TEST LINT BUILD DEPLOYMENT, default DEPLOYMENT) and `environment` (default PRODUCTION) describe the job.<br/>
+// @Description If the connection has a secret, the request must either have `X-Jenkins-Signature` (`sha256=<hmac of body>`) or the secret as query `token`.
+// @Tags plugins/webhook
+// @Param body body JenkinsNotificationEvent true "json body"
+// @Param type query string false "type"
+// @Param environment query string false "environment"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/:connectionId/jenkins/notification [POST]
+func PostJenkinsNotification(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readRawBody(input)
+	if err != nil {
+		return nil, err
+	}
+	if signature := input.Request.Header.Get("X-Jenkins-Signature"); signature != "" {
+		err = verifyHmacSha256(connection, body, signature)
+	} else {
+		err = verifyToken(connection, input.Query.Get("token"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	payload := &JenkinsNotificationEvent{}
+	err = errors.Convert(json.Unmarshal(body, payload))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse jenkins notification")
+	}
+	taskType := strings.ToUpper(input.Query.Get("type"))
+	switch taskType {
+	case ``:
+		taskType = devops.DEPLOYMENT
+	case devops.TEST, devops.LINT, devops.BUILD, devops.DEPLOYMENT:
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("unsupported type: %s", taskType))
+	}
+	environment := getEnvironment(input.Query.Get("environment"))
+	if environment == `` {
+		environment = devops.PRODUCTION
+	}
+	err = saveCicdEvent(convertJenkinsNotification(connection.ID, payload, taskType, environment))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// convertJenkinsNotification converts one build of a job to a pipeline with a single task
+func convertJenkinsNotification(connectionId uint64, payload *JenkinsNotificationEvent, taskType, environment string) *cicdEvent {
+	scopeId := fmt.Sprintf("%s:%d", "webhook", connectionId)
+	pipelineId := fmt.Sprintf("%s:%d:%s:%s:%d", "webhook", connectionId, "jenkins", payload.Name, payload.Build.Number)
+	build := payload.Build
+
+	task := &devops.CICDTask{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: pipelineId,
+		},
+		PipelineId:  pipelineId,
+		Name:        payload.Name,
+		Result:      devops.IN_PROGRESS,
+		Status:      devops.IN_PROGRESS,
+		Type:        taskType,
+		Environment: environment,
+		StartedDate: time.UnixMilli(build.Timestamp),
+		CicdScopeId: scopeId,
+	}
+	// status is only reliable once the build completed
+	if build.Phase == "COMPLETED" || build.Phase == "FINALIZED" {
+		task.Result = devops.GetResult(&jenkinsResultRule, build.Status)
+		if task.Result != devops.IN_PROGRESS {
+			task.Status = devops.DONE
+			finishedDate := task.StartedDate.Add(time.Duration(build.Duration) * time.Millisecond)
+			task.FinishedDate = &finishedDate
+			task.DurationSec = uint64(build.Duration / 1000)
+		}
+	}
+
+	pipeline := &devops.CICDPipeline{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: pipelineId,
+		},
+		Name:         fmt.Sprintf("%s #%d", payload.Name, build.Number),
+		Type:         taskType,
+		CreatedDate:  task.StartedDate,
+		FinishedDate: task.FinishedDate,
+		Environment:  environment,
+		CicdScopeId:  scopeId,
+	}
+	tasks := []*devops.CICDTask{task}
+	finishPipeline(pipeline, tasks)
+
+	event := &cicdEvent{
+		Pipeline: pipeline,
+		Tasks:    tasks,
+	}
+	if build.Scm.Commit != `` {
+		event.Commits = append(event.Commits, &devops.CiCDPipelineCommit{
+			PipelineId: pipelineId,
+			CommitSha:  build.Scm.Commit,
+			Branch:     strings.TrimPrefix(build.Scm.Branch, "origin/"),
+			RepoId:     build.Scm.Url,
+			Repo:       build.Scm.Url,
+		})
+	}
+	return event
+}
@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"io"
+	"strings"
+)
+
+// readRawBody returns the raw request body, which is needed to verify the signature
+func readRawBody(input *plugin.ApiResourceInput) ([]byte, errors.Error) {
+	if input.Request == nil || input.Request.Body == nil {
+		return nil, errors.BadInput.New("request body is missing")
+	}
+	body, err := io.ReadAll(input.Request.Body)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to read request body")
+	}
+	return body, nil
+}
+
+// verifyHmacSha256 checks the `sha256=<hex digest>` signature of the body, as GitHub does in `X-Hub-Signature-256`,
+// verification is skipped if no secret was set for the connection
+func verifyHmacSha256(connection *models.WebhookConnection, body []byte, signature string) errors.Error {
+	if connection.Secret == "" {
+		return nil
+	}
+	digest, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || !strings.HasPrefix(signature, "sha256=") {
+		return errors.Unauthorized.New("invalid signature")
+	}
+	mac := hmac.New(sha256.New, []byte(connection.Secret))
+	mac.Write(body)
+	if !hmac.Equal(digest, mac.Sum(nil)) {
+		return errors.Unauthorized.New("invalid signature")
+	}
+	return nil
+}
+
+// verifyToken checks the token sent in plain text, as GitLab does in `X-Gitlab-Token`,
+// verification is skipped if no secret was set for the connection
+func verifyToken(connection *models.WebhookConnection, token string) errors.Error {
+	if connection.Secret == "" {
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(connection.Secret), []byte(token)) != 1 {
+		return errors.Unauthorized.New("invalid token")
+	}
+	return nil
+}
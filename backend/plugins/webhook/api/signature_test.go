@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVerifyHmacSha256(t *testing.T) {
+	body := []byte(`{"action":"created"}`)
+	mac := hmac.New(sha256.New, []byte("It's a Secret to Everybody"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	connection := &models.WebhookConnection{Secret: "It's a Secret to Everybody"}
+	assert.Nil(t, verifyHmacSha256(connection, body, signature))
+	assert.NotNil(t, verifyHmacSha256(connection, []byte(`{"action":"deleted"}`), signature))
+	assert.NotNil(t, verifyHmacSha256(connection, body, hex.EncodeToString(mac.Sum(nil))))
+	assert.NotNil(t, verifyHmacSha256(connection, body, "sha256=xyz"))
+	assert.NotNil(t, verifyHmacSha256(connection, body, ""))
+
+	// skipped without secret
+	assert.Nil(t, verifyHmacSha256(&models.WebhookConnection{}, body, ""))
+}
+
+func TestVerifyToken(t *testing.T) {
+	connection := &models.WebhookConnection{Secret: "token"}
+	assert.Nil(t, verifyToken(connection, "token"))
+	assert.NotNil(t, verifyToken(connection, "tokens"))
+	assert.NotNil(t, verifyToken(connection, ""))
+	assert.Nil(t, verifyToken(&models.WebhookConnection{}, ""))
+}
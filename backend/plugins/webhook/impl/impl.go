@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/plugins/webhook/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models/migrationscripts"
 )
 
 // make sure interface is implemented
@@ -57,7 +58,7 @@ func (p Webhook) RootPkgPath() string {
 }
 
 func (p Webhook) MigrationScripts() []plugin.MigrationScript {
-	return nil
+	return migrationscripts.All()
 }
 
 func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
@@ -77,9 +78,21 @@ func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 		":connectionId/cicd_pipeline/:pipelineName/finish": {
 			"POST": api.PostPipelineFinish,
 		},
+		":connectionId/cicd_pipelines": {
+			"POST": api.PostCicdPipeline,
+		},
 		":connectionId/deployments": {
 			"POST": api.PostDeploymentCicdTask,
 		},
+		":connectionId/github/deployment_status": {
+			"POST": api.PostGithubDeploymentStatus,
+		},
+		":connectionId/gitlab/pipeline": {
+			"POST": api.PostGitlabPipeline,
+		},
+		":connectionId/jenkins/notification": {
+			"POST": api.PostJenkinsNotification,
+		},
 		":connectionId/issues": {
 			"POST": api.PostIssue,
 		},
@@ -23,6 +23,9 @@ import (
 
 type WebhookConnection struct {
 	helper.BaseConnection `mapstructure:",squash"`
+	// Secret is used to verify the signatures of the native event payloads sent by GitHub, GitLab or Jenkins,
+	// verification is skipped if it is empty
+	Secret string `mapstructure:"secret" json:"secret" gorm:"serializer:encdec"`
 }
 
 func (WebhookConnection) TableName() string {
@@ -56,7 +56,20 @@ func CreateApiService() {
 	services.Init()
 	v := config.GetConfig()
 	gin.SetMode(v.GetString("MODE"))
-	router := gin.Default()
+	router := gin.New()
+	// the access_token query parameter must be stripped before the request gets logged
+	router.Use(stripAccessToken, gin.Logger(), gin.Recovery())
+	// CORS CONFIG
+	router.Use(cors.New(cors.Config{
+		AllowOrigins:     strings.Split(v.GetString("API_CORS_ALLOW_ORIGINS"), ","),
+		AllowMethods:     []string{"PUT", "PATCH", "POST", "GET", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		ExposeHeaders:    []string{"Content-Length"},
+		AllowCredentials: true,
+		MaxAge:           120 * time.Hour,
+	}))
+	// middlewares must be registered before the routes to take effect
+	router.Use(audit, authenticate)
 	remotePluginsEnabled := v.GetBool("ENABLE_REMOTE_PLUGINS")
 	if remotePluginsEnabled {
 		router.POST("/plugins/register", remote.RegisterPlugin(router, registerPluginEndpoints))
@@ -92,16 +105,6 @@ func CreateApiService() {
 		logruslog.Global.Printf("endpoint %v %v %v %v", httpMethod, absolutePath, handlerName, nuHandlers)
 	}
 
-	// CORS CONFIG
-	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"PUT", "PATCH", "POST", "GET", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           120 * time.Hour,
-	}))
-
 	RegisterRouter(router)
 	port := v.GetString("PORT")
 	if remotePluginsEnabled {
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apitoken
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary create api token
+// @Description create an api token of the role viewer, operator or admin, the token is returned only once
+// @Tags framework/auth
+// @Accept application/json
+// @Param apiToken body models.ApiToken true "json"
+// @Success 201  {object} services.ApiTokenCreated
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-tokens [post]
+func Post(c *gin.Context) {
+	apiToken := &models.ApiToken{}
+	err := c.ShouldBind(apiToken)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	if principal := shared.GetPrincipal(c); principal != nil {
+		apiToken.Creator = principal.Name
+	}
+	created, err := services.CreateApiToken(apiToken)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating api token"))
+		return
+	}
+	shared.ApiOutputSuccess(c, created, http.StatusCreated)
+}
+
+// @Summary get api tokens
+// @Description get all api tokens, the tokens themselves are not returned
+// @Tags framework/auth
+// @Success 200  {object} []models.ApiToken
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-tokens [get]
+func Index(c *gin.Context) {
+	apiTokens, err := services.GetApiTokens()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting api tokens"))
+		return
+	}
+	shared.ApiOutputSuccess(c, apiTokens, http.StatusOK)
+}
+
+// @Summary revoke api token
+// @Description revoke api token
+// @Tags framework/auth
+// @Param apiTokenId path int true "api token id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-tokens/{apiTokenId} [delete]
+func Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("apiTokenId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad apiTokenId format supplied"))
+		return
+	}
+	err = services.DeleteApiToken(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting api token"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary get current principal
+// @Description get the caller authenticated by the token, null if authentication was disabled
+// @Tags framework/auth
+// @Success 200  {object} services.ApiPrincipal
+// @Router /auth/me [get]
+func Me(c *gin.Context) {
+	shared.ApiOutputSuccess(c, shared.GetPrincipal(c), http.StatusOK)
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditlog
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ResponseAuditLogs struct {
+	Count     int64                 `json:"count"`
+	AuditLogs []*models.ApiAuditLog `json:"auditLogs"`
+}
+
+// @Summary get api audit logs
+// @Description get the audit logs of api calls, the latest first
+// @Tags framework/auth
+// @Param principal query string false "principal"
+// @Param method query string false "method"
+// @Param page query int false "page"
+// @Param pageSize query int false "pageSize"
+// @Success 200  {object} ResponseAuditLogs
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /audit-logs [get]
+func Index(c *gin.Context) {
+	var query services.ApiAuditLogQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	auditLogs, count, err := services.GetApiAuditLogs(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting audit logs"))
+		return
+	}
+	shared.ApiOutputSuccess(c, ResponseAuditLogs{AuditLogs: auditLogs, Count: count}, http.StatusOK)
+}
@@ -0,0 +1,181 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// publicRoutes could be called without authentication
+var publicRoutes = map[string]bool{
+	"GET /ping":         true,
+	"GET /version":      true,
+	"GET /swagger/*any": true,
+}
+
+// operatorRoutes trigger, cancel or inspect the execution of pipelines
+var operatorRoutes = map[string]bool{
+	"POST /pipelines":                       true,
+	"DELETE /pipelines/:pipelineId":         true,
+	"POST /pipelines/:pipelineId/rerun":     true,
+	"POST /pipelines/:pipelineId/resume":    true,
+	"POST /tasks/:taskId/rerun":             true,
+	"POST /tasks/:taskId/resume":            true,
+	"POST /blueprints/:blueprintId/trigger": true,
+	"POST /blueprints/:blueprintId/plan":    true,
+}
+
+// adminRoutePrefixes require admin even for reading, since they expose credentials or affect the whole system
+var adminRoutePrefixes = []string{
+	"/api-tokens",
+	"/audit-logs",
+	"/notification-channels",
+	"/proceed-db-migration",
+	"/plugins/register",
+}
+
+// getRequiredRole returns the role required to call the route, empty string if the route is public.
+// Reading requires viewer, and changing anything requires admin unless the route was listed as an operator route
+func getRequiredRole(method, route string) string {
+	key := method + " " + route
+	if publicRoutes[key] {
+		return ""
+	}
+	if operatorRoutes[key] {
+		return models.API_ROLE_OPERATOR
+	}
+	for _, prefix := range adminRoutePrefixes {
+		if strings.HasPrefix(route, prefix) {
+			return models.API_ROLE_ADMIN
+		}
+	}
+	if strings.HasPrefix(route, "/plugins/") {
+		// connections hold the credentials of the data sources
+		if strings.Contains(route, "/connections") {
+			return models.API_ROLE_ADMIN
+		}
+		// webhooks are fed by the CI/CD tools and issue trackers
+		if strings.HasPrefix(route, "/plugins/webhook/:connectionId/") {
+			return models.API_ROLE_OPERATOR
+		}
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return models.API_ROLE_VIEWER
+	}
+	return models.API_ROLE_ADMIN
+}
+
+const accessTokenParam = "access_token"
+
+// stripAccessToken moves the access_token query parameter into the context before the request gets logged
+func stripAccessToken(c *gin.Context) {
+	query := c.Request.URL.Query()
+	if !query.Has(accessTokenParam) {
+		return
+	}
+	c.Set(accessTokenParam, query.Get(accessTokenParam))
+	query.Del(accessTokenParam)
+	c.Request.URL.RawQuery = query.Encode()
+}
+
+// getApiToken reads the token from the Authorization header, or the access_token query parameter
+// for the webhooks of the tools which could not customize headers
+func getApiToken(c *gin.Context) string {
+	authorization := c.Request.Header.Get("Authorization")
+	if len(authorization) > 7 && strings.EqualFold(authorization[:7], "Bearer ") {
+		return strings.TrimSpace(authorization[7:])
+	}
+	if strings.HasPrefix(c.FullPath(), "/plugins/webhook/") {
+		return c.GetString(accessTokenParam)
+	}
+	return ""
+}
+
+// authenticate rejects the callers who were not granted the role required by the route
+func authenticate(c *gin.Context) {
+	if !services.IsApiAuthEnabled() || c.Request.Method == http.MethodOptions {
+		return
+	}
+	route := c.FullPath()
+	// remote plugins launched by devlake register themselves from the same host
+	if route == "/plugins/register" {
+		if ip, _ := c.RemoteIP(); ip != nil && ip.IsLoopback() {
+			return
+		}
+	}
+	role := getRequiredRole(c.Request.Method, route)
+	if role == "" {
+		return
+	}
+	principal, err := services.AuthenticateApiToken(getApiToken(c))
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		c.Abort()
+		return
+	}
+	shared.SetPrincipal(c, principal)
+	if !principal.HasRole(role) {
+		shared.ApiOutputError(c, errors.Forbidden.New("role "+role+" is required"))
+		c.Abort()
+	}
+}
+
+// audit records who called the endpoint, reading requests are recorded only if API_AUDIT_READS was set
+func audit(c *gin.Context) {
+	startedAt := time.Now()
+	c.Next()
+	method := c.Request.Method
+	if method == http.MethodOptions {
+		return
+	}
+	if (method == http.MethodGet || method == http.MethodHead) && !services.IsApiAuditReadsEnabled() {
+		return
+	}
+	if services.MigrationRequireConfirmation() {
+		return
+	}
+	auditLog := &models.ApiAuditLog{
+		CreatedAt:  startedAt,
+		Principal:  "anonymous",
+		Method:     method,
+		Route:      c.FullPath(),
+		Path:       c.Request.URL.Path,
+		StatusCode: c.Writer.Status(),
+		ClientIp:   c.ClientIP(),
+		LatencyMs:  time.Since(startedAt).Milliseconds(),
+	}
+	if principal := shared.GetPrincipal(c); principal != nil {
+		auditLog.Principal = principal.Name
+		auditLog.Role = principal.Role
+		auditLog.AuthMethod = principal.AuthMethod
+	}
+	err := services.RecordApiAuditLog(auditLog)
+	if err != nil {
+		logruslog.Global.Error(err, "failed to record audit log of %s %s", method, auditLog.Path)
+	}
+}
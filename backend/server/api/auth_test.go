@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRequiredRole(t *testing.T) {
+	assert.Equal(t, "", getRequiredRole("GET", "/ping"))
+	assert.Equal(t, models.API_ROLE_VIEWER, getRequiredRole("GET", "/pipelines"))
+	assert.Equal(t, models.API_ROLE_VIEWER, getRequiredRole("GET", "/plugins/github/test"))
+	assert.Equal(t, models.API_ROLE_OPERATOR, getRequiredRole("POST", "/blueprints/:blueprintId/trigger"))
+	assert.Equal(t, models.API_ROLE_OPERATOR, getRequiredRole("DELETE", "/pipelines/:pipelineId"))
+	assert.Equal(t, models.API_ROLE_OPERATOR, getRequiredRole("POST", "/plugins/webhook/:connectionId/cicd_tasks"))
+	assert.Equal(t, models.API_ROLE_ADMIN, getRequiredRole("POST", "/blueprints"))
+	assert.Equal(t, models.API_ROLE_ADMIN, getRequiredRole("POST", "/push/:tableName"))
+	assert.Equal(t, models.API_ROLE_ADMIN, getRequiredRole("GET", "/proceed-db-migration"))
+	assert.Equal(t, models.API_ROLE_ADMIN, getRequiredRole("GET", "/notification-channels"))
+	assert.Equal(t, models.API_ROLE_ADMIN, getRequiredRole("GET", "/plugins/github/connections/:connectionId"))
+	assert.Equal(t, models.API_ROLE_ADMIN, getRequiredRole("POST", "/plugins/webhook/connections"))
+	// unknown routes
+	assert.Equal(t, models.API_ROLE_VIEWER, getRequiredRole("GET", ""))
+	assert.Equal(t, models.API_ROLE_ADMIN, getRequiredRole("POST", ""))
+}
+
+func TestGetApiToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(stripAccessToken)
+	var token, rawQuery string
+	handler := func(c *gin.Context) {
+		token = getApiToken(c)
+		rawQuery = c.Request.URL.RawQuery
+	}
+	router.GET("/pipelines", handler)
+	router.POST("/plugins/webhook/:connectionId/deployments", handler)
+
+	// the query parameter is accepted by the webhooks only
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/pipelines?access_token=query&page=1", nil))
+	assert.Equal(t, "", token)
+	assert.Equal(t, "page=1", rawQuery)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/plugins/webhook/1/deployments?access_token=query", nil))
+	assert.Equal(t, "query", token)
+	assert.Equal(t, "", rawQuery)
+
+	req := httptest.NewRequest("POST", "/plugins/webhook/1/deployments?access_token=query", nil)
+	req.Header.Set("Authorization", "Bearer header")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "header", token)
+	req = httptest.NewRequest("POST", "/plugins/webhook/1/deployments?access_token=query", nil)
+	req.Header.Set("Authorization", "Basic header")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "query", token)
+}
@@ -111,26 +111,38 @@ func Get(c *gin.Context) {
 	shared.ApiOutputSuccess(c, blueprint, http.StatusOK)
 }
 
-// // @Summary delete blueprints
-// // @Description Delete BluePrints
-// // @Tags framework/blueprints
-// // @Param blueprintId path string true "blueprintId"
-// // @Success 200
-// // @Failure 400  {object} shared.ApiBody "Bad Request"
-// // @Failure 500  {object} shared.ApiBody "Internal Error"
-// // @Router /blueprints/{blueprintId} [delete]
-// func Delete(c *gin.Context) {
-// 	pipelineId := c.Param("blueprintId")
-// 	id, err := strconv.ParseUint(pipelineId, 10, 64)
-// 	if err != nil {
-// 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
-// 		return
-// 	}
-// 	err = services.DeleteBlueprint(id)
-// 	if err != nil {
-// 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting blueprint"))
-// 	}
-// }
+// @Summary delete blueprints
+// @Description Delete the blueprint along with its labels, use dryRun=true to see what would be deleted
+// @Tags framework/blueprints
+// @Param blueprintId path string true "blueprintId"
+// @Param dryRun query bool false "dryRun"
+// @Success 200  {object} services.DeletionReport
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId} [delete]
+func Delete(c *gin.Context) {
+	blueprintId := c.Param("blueprintId")
+	id, err := strconv.ParseUint(blueprintId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad dryRun format supplied"))
+		return
+	}
+	report, err := services.DeleteBlueprint(id, dryRun)
+	if errors.Is(err, services.ErrBlueprintRunning) {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "the blueprint is running"))
+		return
+	}
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting blueprint"))
+		return
+	}
+	shared.ApiOutputSuccess(c, report, http.StatusOK)
+}
 
 // @Summary patch blueprints
 // @Description patch blueprints
@@ -190,6 +202,41 @@ func Trigger(c *gin.Context) {
 	shared.ApiOutputSuccess(c, pipeline, http.StatusOK)
 }
 
+// @Summary explain the pipeline plan of blueprint
+// @Description make the pipeline plan of a blueprint and explain it without running it, including subtasks of each plugin,
+// @Description whether each collected scope in raw tables will be collected incrementally or fully, and estimated api calls.
+// @Description Only dryRun=true is supported, use trigger to run the blueprint
+// @Tags framework/blueprints
+// @Param blueprintId path string true "blueprintId"
+// @Param dryRun query bool true "dryRun"
+// @Success 200  {object} services.PlanExplanation
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId}/plan [post]
+func Plan(c *gin.Context) {
+	blueprintId := c.Param("blueprintId")
+	id, err := strconv.ParseUint(blueprintId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad dryRun format supplied"))
+		return
+	}
+	if !dryRun {
+		shared.ApiOutputError(c, errors.BadInput.New("only dryRun=true is supported, please trigger the blueprint to run it"))
+		return
+	}
+	explanation, err := services.DryRunBlueprint(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error explaining the plan of blueprint"))
+		return
+	}
+	shared.ApiOutputSuccess(c, explanation, http.StatusOK)
+}
+
 // @Summary get pipelines by blueprint id
 // @Description get pipelines by blueprint id
 // @Tags framework/blueprints
@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary create notification channel
+// @Description create a channel receiving pipeline notifications of a blueprint, a project, or all pipelines if neither was set,
+// @Description the type could be WEBHOOK, SLACK, TEAMS or EMAIL
+// @Tags framework/notifications
+// @Accept application/json
+// @Param channel body models.NotificationChannel true "json"
+// @Success 201  {object} models.NotificationChannel
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels [post]
+func Post(c *gin.Context) {
+	channel := &models.NotificationChannel{}
+	err := c.ShouldBind(channel)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.CreateNotificationChannel(channel)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating notification channel"))
+		return
+	}
+	shared.ApiOutputSuccess(c, services.MaskNotificationChannel(channel), http.StatusCreated)
+}
+
+// @Summary get notification channels
+// @Description get all notification channels, the endpoints and secrets are masked
+// @Tags framework/notifications
+// @Success 200  {object} []models.NotificationChannel
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels [get]
+func Index(c *gin.Context) {
+	channels, err := services.GetNotificationChannels()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting notification channels"))
+		return
+	}
+	masked := make([]*models.NotificationChannel, len(channels))
+	for i, channel := range channels {
+		masked[i] = services.MaskNotificationChannel(channel)
+	}
+	shared.ApiOutputSuccess(c, masked, http.StatusOK)
+}
+
+// @Summary get notification channel
+// @Description get notification channel, the endpoint and secret are masked
+// @Tags framework/notifications
+// @Param channelId path int true "channel id"
+// @Success 200  {object} models.NotificationChannel
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels/{channelId} [get]
+func Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("channelId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad channelId format supplied"))
+		return
+	}
+	channel, err := services.GetNotificationChannel(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting notification channel"))
+		return
+	}
+	shared.ApiOutputSuccess(c, services.MaskNotificationChannel(channel), http.StatusOK)
+}
+
+// @Summary patch notification channel
+// @Description patch notification channel
+// @Tags framework/notifications
+// @Accept application/json
+// @Param channelId path int true "channel id"
+// @Success 200  {object} models.NotificationChannel
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels/{channelId} [patch]
+func Patch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("channelId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad channelId format supplied"))
+		return
+	}
+	var body map[string]interface{}
+	err = c.ShouldBind(&body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	channel, err := services.PatchNotificationChannel(id, body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error patching notification channel"))
+		return
+	}
+	shared.ApiOutputSuccess(c, services.MaskNotificationChannel(channel), http.StatusOK)
+}
+
+// @Summary delete notification channel
+// @Description delete notification channel
+// @Tags framework/notifications
+// @Param channelId path int true "channel id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels/{channelId} [delete]
+func Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("channelId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad channelId format supplied"))
+		return
+	}
+	err = services.DeleteNotificationChannel(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting notification channel"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary test notification channel
+// @Description send a sample notification through the channel
+// @Tags framework/notifications
+// @Param channelId path int true "channel id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /notification-channels/{channelId}/test [post]
+func Test(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("channelId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad channelId format supplied"))
+		return
+	}
+	err = services.TestNotificationChannel(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error sending test notification"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
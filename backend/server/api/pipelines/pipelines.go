@@ -191,3 +191,28 @@ func PostRerun(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, rerunTasks, http.StatusOK)
 }
+
+// ResumePipeline resume all failed tasks of the specified pipeline from the first subtask not completed
+// @Summary resume tasks
+// @Description rerun all failed tasks with the same options, subtasks completed by them are skipped
+// @Tags framework/pipelines
+// @Accept application/json
+// @Param pipelineId path int true "pipelineId"
+// @Success 200  {object} []models.Task
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /pipelines/{pipelineId}/resume [post]
+func PostResume(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
+		return
+	}
+	resumedTasks, err := services.ResumePipeline(id, nil)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "failed to resume pipeline"))
+		return
+	}
+	shared.ApiOutputSuccess(c, resumedTasks, http.StatusOK)
+}
@@ -133,3 +133,38 @@ func PatchProject(c *gin.Context) {
 
 	shared.ApiOutputSuccess(c, projectOutput, http.StatusCreated)
 }
+
+// @Summary Delete a project
+// @Description Delete a project along with its blueprint, metric settings and mappings.
+// @Description Set deleteData=true to delete the data of the scopes used by this project only as well,
+// @Description and dryRun=true to see what would be deleted.
+// @Tags framework/projects
+// @Param projectName path string true "project name"
+// @Param dryRun query bool false "dryRun"
+// @Param deleteData query bool false "deleteData"
+// @Success 200  {object} services.DeletionReport
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/:projectName [delete]
+func DeleteProject(c *gin.Context) {
+	projectName := c.Param("projectName")[1:]
+
+	var query services.ProjectDeleteQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+
+	report, err := services.DeleteProject(projectName, &query)
+	if errors.Is(err, services.ErrBlueprintRunning) {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "the blueprint of the project is running"))
+		return
+	}
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting project"))
+		return
+	}
+
+	shared.ApiOutputSuccess(c, report, http.StatusOK)
+}
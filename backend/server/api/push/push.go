@@ -18,6 +18,7 @@ limitations under the License.
 package push
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/server/api/shared"
@@ -28,7 +29,7 @@ import (
 )
 
 /*
-	POST /push/:tableName
+	POST /push/:tableName?rawDataParams=my-source
 	[
 		{
 			"id": 1,
@@ -37,12 +38,14 @@ import (
 	]
 */
 // @Summary POST /push/:tableName
-// @Description POST /push/:tableName
+// @Description upsert rows into a domain layer table by the primary keys, rows are validated against the columns of the table
+// @Description and stamped with `_raw_data_table`=push_api, rows failed to be saved are reported in errors with their indexes
 // @Tags framework/push
 // @Accept application/json
 // @Param tableName path string true "table name"
+// @Param rawDataParams query string false "stamped into _raw_data_params of the rows"
 // @Param data body string true "data"
-// @Success 200  {object} gin.H "{"rowsAffected": rowsAffected}"
+// @Success 200  {object} services.PushResult
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /push/{tableName} [post]
@@ -50,15 +53,22 @@ func Post(c *gin.Context) {
 	var err error
 	tableName := c.Param("tableName")
 	var rowsToInsert []map[string]interface{}
-	err = c.ShouldBindJSON(&rowsToInsert)
+	decoder := json.NewDecoder(c.Request.Body)
+	// keep big integers precise
+	decoder.UseNumber()
+	err = decoder.Decode(&rowsToInsert)
 	if err != nil {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
-	rowsAffected, err := services.InsertRow(tableName, rowsToInsert)
+	options := &services.PushOptions{RawDataParams: c.Query("rawDataParams")}
+	if principal := shared.GetPrincipal(c); principal != nil {
+		options.Pusher = principal.Name
+	}
+	result, err := services.UpsertRows(tableName, rowsToInsert, options)
 	if err != nil {
-		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("error inserting request body into table %s", tableName)))
+		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("error upserting request body into table %s", tableName)))
 		return
 	}
-	shared.ApiOutputSuccess(c, gin.H{"rowsAffected": rowsAffected}, http.StatusOK)
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
 }
@@ -0,0 +1,142 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary create raw data retention policy
+// @Description create a retention policy for a raw table, all raw tables of a plugin, or all raw tables if neither was set
+// @Tags framework/retention
+// @Accept application/json
+// @Param policy body models.RawDataRetentionPolicy true "json"
+// @Success 201  {object} models.RawDataRetentionPolicy
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data-retention/policies [post]
+func Post(c *gin.Context) {
+	policy := &models.RawDataRetentionPolicy{}
+	err := c.ShouldBind(policy)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	err = services.CreateRawDataRetentionPolicy(policy)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating retention policy"))
+		return
+	}
+	shared.ApiOutputSuccess(c, policy, http.StatusCreated)
+}
+
+// @Summary get raw data retention policies
+// @Description get all raw data retention policies
+// @Tags framework/retention
+// @Success 200  {object} []models.RawDataRetentionPolicy
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data-retention/policies [get]
+func Index(c *gin.Context) {
+	policies, err := services.GetRawDataRetentionPolicies()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting retention policies"))
+		return
+	}
+	shared.ApiOutputSuccess(c, policies, http.StatusOK)
+}
+
+// @Summary patch raw data retention policy
+// @Description patch raw data retention policy
+// @Tags framework/retention
+// @Accept application/json
+// @Param policyId path int true "policy id"
+// @Success 200  {object} models.RawDataRetentionPolicy
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data-retention/policies/{policyId} [patch]
+func Patch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("policyId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad policyId format supplied"))
+		return
+	}
+	var body map[string]interface{}
+	err = c.ShouldBind(&body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	policy, err := services.PatchRawDataRetentionPolicy(id, body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error patching retention policy"))
+		return
+	}
+	shared.ApiOutputSuccess(c, policy, http.StatusOK)
+}
+
+// @Summary delete raw data retention policy
+// @Description delete raw data retention policy
+// @Tags framework/retention
+// @Param policyId path int true "policy id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data-retention/policies/{policyId} [delete]
+func Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("policyId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad policyId format supplied"))
+		return
+	}
+	err = services.DeleteRawDataRetentionPolicy(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting retention policy"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary purge raw data
+// @Description purge raw data by the enabled retention policies now and report the rows purged from each table,
+// @Description use dryRun=true to see what would be purged
+// @Tags framework/retention
+// @Param dryRun query bool false "dryRun"
+// @Success 200  {object} services.DeletionReport
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /raw-data-retention/purge [post]
+func Purge(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad dryRun format supplied"))
+		return
+	}
+	report, err := services.PurgeRawData(dryRun)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error purging raw data"))
+		return
+	}
+	shared.ApiOutputSuccess(c, report, http.StatusOK)
+}
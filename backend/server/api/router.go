@@ -18,24 +18,31 @@ limitations under the License.
 package api
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/api/apitoken"
+	"github.com/apache/incubator-devlake/server/api/auditlog"
 	"github.com/apache/incubator-devlake/server/api/blueprints"
 	"github.com/apache/incubator-devlake/server/api/domainlayer"
+	"github.com/apache/incubator-devlake/server/api/notification"
 	"github.com/apache/incubator-devlake/server/api/ping"
 	"github.com/apache/incubator-devlake/server/api/pipelines"
 	"github.com/apache/incubator-devlake/server/api/plugininfo"
 	"github.com/apache/incubator-devlake/server/api/project"
 	"github.com/apache/incubator-devlake/server/api/push"
+	"github.com/apache/incubator-devlake/server/api/retention"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/task"
 	"github.com/apache/incubator-devlake/server/api/version"
 	"github.com/apache/incubator-devlake/server/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func RegisterRouter(r *gin.Engine) {
@@ -44,7 +51,8 @@ func RegisterRouter(r *gin.Engine) {
 	r.GET("/pipelines/:pipelineId", pipelines.Get)
 	r.PATCH("/blueprints/:blueprintId", blueprints.Patch)
 	r.POST("/blueprints/:blueprintId/trigger", blueprints.Trigger)
-	// r.DELETE("/blueprints/:blueprintId", blueprints.Delete)
+	r.POST("/blueprints/:blueprintId/plan", blueprints.Plan)
+	r.DELETE("/blueprints/:blueprintId", blueprints.Delete)
 
 	r.GET("/blueprints", blueprints.Index)
 	r.POST("/blueprints", blueprints.Post)
@@ -54,11 +62,33 @@ func RegisterRouter(r *gin.Engine) {
 	r.GET("/pipelines/:pipelineId/tasks", task.GetTaskByPipeline)
 	r.POST("/pipelines/:pipelineId/rerun", pipelines.PostRerun)
 	r.POST("/tasks/:taskId/rerun", task.PostRerun)
+	r.POST("/pipelines/:pipelineId/resume", pipelines.PostResume)
+	r.POST("/tasks/:taskId/resume", task.PostResume)
 
 	r.GET("/pipelines/:pipelineId/logging.tar.gz", pipelines.DownloadLogs)
 
+	r.GET("/raw-data-retention/policies", retention.Index)
+	r.POST("/raw-data-retention/policies", retention.Post)
+	r.PATCH("/raw-data-retention/policies/:policyId", retention.Patch)
+	r.DELETE("/raw-data-retention/policies/:policyId", retention.Delete)
+	r.POST("/raw-data-retention/purge", retention.Purge)
+
+	r.GET("/notification-channels", notification.Index)
+	r.POST("/notification-channels", notification.Post)
+	r.GET("/notification-channels/:channelId", notification.Get)
+	r.PATCH("/notification-channels/:channelId", notification.Patch)
+	r.DELETE("/notification-channels/:channelId", notification.Delete)
+	r.POST("/notification-channels/:channelId/test", notification.Test)
+
+	r.GET("/api-tokens", apitoken.Index)
+	r.POST("/api-tokens", apitoken.Post)
+	r.DELETE("/api-tokens/:apiTokenId", apitoken.Delete)
+	r.GET("/audit-logs", auditlog.Index)
+	r.GET("/auth/me", apitoken.Me)
+
 	r.GET("/ping", ping.Get)
 	r.GET("/version", version.Get)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	r.POST("/push/:tableName", push.Post)
 	r.GET("/domainlayer/repos", domainlayer.ReposIndex)
 
@@ -69,7 +99,7 @@ func RegisterRouter(r *gin.Engine) {
 	// project api
 	r.GET("/projects/*projectName", project.GetProject)
 	r.PATCH("/projects/*projectName", project.PatchProject)
-	//r.DELETE("/projects/:projectName", project.DeleteProject)
+	r.DELETE("/projects/*projectName", project.DeleteProject)
 	r.POST("/projects", project.PostProject)
 	r.GET("/projects", project.GetProjects)
 
@@ -107,16 +137,22 @@ func handlePluginCall(handler plugin.ApiResourceHandler) func(c *gin.Context) {
 			}
 		}
 		input.Query = c.Request.URL.Query()
-		if c.Request.Body != nil {
-			if strings.HasPrefix(c.Request.Header.Get("Content-Type"), "multipart/form-data;") {
-				input.Request = c.Request
-			} else {
-				err = c.ShouldBindJSON(&input.Body)
-				if err != nil && err.Error() != "EOF" {
-					shared.ApiOutputError(c, err)
-					return
-				}
+		input.Request = c.Request
+		if c.Request.Body != nil && !strings.HasPrefix(c.Request.Header.Get("Content-Type"), "multipart/form-data;") {
+			// keep the raw body readable for handlers, i.e. to verify the signature of a webhook payload
+			var body []byte
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				shared.ApiOutputError(c, err)
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			err = c.ShouldBindJSON(&input.Body)
+			if err != nil && err.Error() != "EOF" {
+				shared.ApiOutputError(c, err)
+				return
 			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
 		}
 		output, err := handler(input)
 		if err != nil {
@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const principalKey = "principal"
+
+// SetPrincipal saves the authenticated caller into the context
+func SetPrincipal(c *gin.Context, principal *services.ApiPrincipal) {
+	c.Set(principalKey, principal)
+}
+
+// GetPrincipal returns the authenticated caller, nil if the api was called anonymously
+func GetPrincipal(c *gin.Context) *services.ApiPrincipal {
+	if principal, ok := c.Get(principalKey); ok {
+		return principal.(*services.ApiPrincipal)
+	}
+	return nil
+}
@@ -93,3 +93,27 @@ func PostRerun(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, task, http.StatusOK)
 }
+
+// ResumeTask resume the specified task from the first subtask not completed.
+// @Summary resume task
+// @Description rerun the task with the same options, subtasks completed by it are skipped
+// @Tags framework/tasks
+// @Accept application/json
+// @Success 200  {object} models.Task
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /tasks/{taskId}/resume [post]
+func PostResume(c *gin.Context) {
+	taskId := c.Param("taskId")
+	id, err := strconv.ParseUint(taskId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad taskId format supplied"))
+		return
+	}
+	task, err := services.ResumeTask(id)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, task, http.StatusOK)
+}
@@ -0,0 +1,248 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"strings"
+	"time"
+)
+
+const (
+	// API_AUTH_METHOD_ADMIN_TOKEN is the method of the static API_ADMIN_TOKEN configured
+	API_AUTH_METHOD_ADMIN_TOKEN = "admin_token"
+	// API_AUTH_METHOD_API_TOKEN is the method of tokens created by the api
+	API_AUTH_METHOD_API_TOKEN = "api_token"
+	// API_AUTH_METHOD_OIDC is the method of bearer tokens issued by OIDC_ISSUER
+	API_AUTH_METHOD_OIDC = "oidc"
+
+	apiTokenPrefix = "dlk_"
+)
+
+// ApiPrincipal is the caller of the REST API
+type ApiPrincipal struct {
+	Name       string `json:"name"`
+	Role       string `json:"role"`
+	AuthMethod string `json:"authMethod"`
+}
+
+// HasRole tells whether the principal was granted the role
+func (p *ApiPrincipal) HasRole(role string) bool {
+	return models.ApiRoleLevels[p.Role] >= models.ApiRoleLevels[role]
+}
+
+// ApiTokenCreated is returned only once on creation, the token could not be retrieved again
+type ApiTokenCreated struct {
+	*models.ApiToken
+	Token string `json:"token"`
+}
+
+// ApiAuditLogQuery is the query of api audit logs
+type ApiAuditLogQuery struct {
+	Pagination
+	Principal string `form:"principal"`
+	Method    string `form:"method"`
+}
+
+// IsApiAuthEnabled tells whether callers of the REST API should be authenticated
+func IsApiAuthEnabled() bool {
+	return cfg.GetBool("API_AUTH_ENABLED")
+}
+
+// IsApiAuditReadsEnabled tells whether reading requests should be recorded in audit logs as well
+func IsApiAuditReadsEnabled() bool {
+	return cfg.GetBool("API_AUDIT_READS")
+}
+
+// CreateApiToken generates a new token for the role
+func CreateApiToken(apiToken *models.ApiToken) (*ApiTokenCreated, errors.Error) {
+	err := VerifyStruct(apiToken)
+	if err != nil {
+		return nil, err
+	}
+	if apiToken.ExpiredAt != nil && apiToken.ExpiredAt.Before(time.Now()) {
+		return nil, errors.BadInput.New("expiredAt should be in the future")
+	}
+	secret := make([]byte, 20)
+	_, e := rand.Read(secret)
+	if e != nil {
+		return nil, errors.Convert(e)
+	}
+	token := apiTokenPrefix + hex.EncodeToString(secret)
+	apiToken.ID = 0
+	apiToken.TokenPrefix = token[:len(apiTokenPrefix)+6]
+	apiToken.TokenHash = hashApiToken(token)
+	apiToken.LastUsedAt = nil
+	err = db.Create(apiToken)
+	if err != nil {
+		return nil, err
+	}
+	return &ApiTokenCreated{ApiToken: apiToken, Token: token}, nil
+}
+
+// GetApiTokens returns all api tokens without the tokens themselves
+func GetApiTokens() ([]*models.ApiToken, errors.Error) {
+	apiTokens := make([]*models.ApiToken, 0)
+	err := db.All(&apiTokens, dal.Orderby("id"))
+	if err != nil {
+		return nil, err
+	}
+	return apiTokens, nil
+}
+
+// DeleteApiToken revokes the api token
+func DeleteApiToken(id uint64) errors.Error {
+	apiToken := &models.ApiToken{}
+	err := db.First(apiToken, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.Wrap(err, "api token not found")
+		}
+		return err
+	}
+	return db.Delete(apiToken)
+}
+
+// AuthenticateApiToken returns the principal of the token, which could be the API_ADMIN_TOKEN,
+// a bearer token issued by OIDC_ISSUER or a token created by CreateApiToken
+func AuthenticateApiToken(token string) (*ApiPrincipal, errors.Error) {
+	if token == "" {
+		return nil, errors.Unauthorized.New("api token is required")
+	}
+	adminToken := cfg.GetString("API_ADMIN_TOKEN")
+	if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+		return &ApiPrincipal{Name: "admin", Role: models.API_ROLE_ADMIN, AuthMethod: API_AUTH_METHOD_ADMIN_TOKEN}, nil
+	}
+	if strings.HasPrefix(token, apiTokenPrefix) {
+		return authenticateDbApiToken(token)
+	}
+	if strings.Count(token, ".") == 2 && cfg.GetString("OIDC_ISSUER") != "" {
+		return authenticateOidcToken(token)
+	}
+	return nil, errors.Unauthorized.New("invalid api token")
+}
+
+func authenticateDbApiToken(token string) (*ApiPrincipal, errors.Error) {
+	apiToken := &models.ApiToken{}
+	err := db.First(apiToken, dal.Where("token_hash = ?", hashApiToken(token)))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.Unauthorized.New("invalid api token")
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if apiToken.ExpiredAt != nil && apiToken.ExpiredAt.Before(now) {
+		return nil, errors.Unauthorized.New("api token expired")
+	}
+	err = db.UpdateColumn(&models.ApiToken{}, "last_used_at", now, dal.Where("id = ?", apiToken.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &ApiPrincipal{Name: apiToken.Name, Role: apiToken.Role, AuthMethod: API_AUTH_METHOD_API_TOKEN}, nil
+}
+
+func authenticateOidcToken(token string) (*ApiPrincipal, errors.Error) {
+	verifier, err := getOidcVerifier()
+	if err != nil {
+		return nil, err
+	}
+	claims, err := verifier.verify(token)
+	if err != nil {
+		return nil, err
+	}
+	principal := &ApiPrincipal{AuthMethod: API_AUTH_METHOD_OIDC}
+	if name, ok := claims[cfg.GetString("OIDC_USERNAME_CLAIM")].(string); ok && name != "" {
+		principal.Name = name
+	} else if sub, ok := claims["sub"].(string); ok {
+		principal.Name = sub
+	}
+	principal.Role = getOidcRole(claims[cfg.GetString("OIDC_ROLE_CLAIM")], cfg.GetString("OIDC_DEFAULT_ROLE"))
+	if principal.Role == "" {
+		return nil, errors.Forbidden.New("no role was granted to the oidc user")
+	}
+	return principal, nil
+}
+
+// getOidcRole picks the highest role from the claim, which could be a string or an array of strings
+func getOidcRole(claim interface{}, defaultRole string) string {
+	var values []string
+	switch v := claim.(type) {
+	case string:
+		values = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	role := ""
+	if _, ok := models.ApiRoleLevels[defaultRole]; ok {
+		role = defaultRole
+	}
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if models.ApiRoleLevels[value] > models.ApiRoleLevels[role] {
+			role = value
+		}
+	}
+	return role
+}
+
+func hashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordApiAuditLog saves the audit log of an api call
+func RecordApiAuditLog(auditLog *models.ApiAuditLog) errors.Error {
+	return db.Create(auditLog)
+}
+
+// GetApiAuditLogs returns the paginated audit logs, the latest first
+func GetApiAuditLogs(query *ApiAuditLogQuery) ([]*models.ApiAuditLog, int64, errors.Error) {
+	clauses := []dal.Clause{dal.From(&models.ApiAuditLog{})}
+	if query.Principal != "" {
+		clauses = append(clauses, dal.Where("principal = ?", query.Principal))
+	}
+	if query.Method != "" {
+		clauses = append(clauses, dal.Where("method = ?", strings.ToUpper(query.Method)))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, err
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	auditLogs := make([]*models.ApiAuditLog, 0)
+	err = db.All(&auditLogs, clauses...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return auditLogs, count, nil
+}
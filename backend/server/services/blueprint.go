@@ -266,6 +266,11 @@ func createPipelineByBlueprint(blueprint *models.Blueprint) (*models.Pipeline, e
 
 // MakePlanForBlueprint generates pipeline plan by version
 func MakePlanForBlueprint(blueprint *models.Blueprint) (plugin.PipelinePlan, errors.Error) {
+	return makePlanForBlueprint(blueprint, false)
+}
+
+// makePlanForBlueprint generates pipeline plan by version, scopes and project mappings are not saved when dryRun is true
+func makePlanForBlueprint(blueprint *models.Blueprint, dryRun bool) (plugin.PipelinePlan, errors.Error) {
 	bpSettings := new(models.BlueprintSettings)
 	err := errors.Convert(json.Unmarshal(blueprint.Settings, bpSettings))
 	if err != nil {
@@ -293,7 +298,11 @@ func MakePlanForBlueprint(blueprint *models.Blueprint) (plugin.PipelinePlan, err
 				metrics[projectMetric.PluginName] = json.RawMessage(projectMetric.PluginOption)
 			}
 		}
-		plan, err = GeneratePlanJsonV200(blueprint.ProjectName, bpSyncPolicy, bpSettings, metrics)
+		if dryRun {
+			plan, _, err = genPlanJsonV200(blueprint.ProjectName, bpSyncPolicy, bpSettings, metrics)
+		} else {
+			plan, err = GeneratePlanJsonV200(blueprint.ProjectName, bpSyncPolicy, bpSettings, metrics)
+		}
 	default:
 		return nil, errors.Default.New(fmt.Sprintf("unknown version of blueprint settings: %s", bpSettings.Version))
 	}
@@ -364,3 +373,59 @@ func TriggerBlueprint(id uint64) (*models.Pipeline, errors.Error) {
 	// done
 	return pipeline, err
 }
+
+// DeleteBlueprint removes the blueprint along with its labels, nothing would be deleted in dryRun mode
+func DeleteBlueprint(id uint64, dryRun bool) (*DeletionReport, errors.Error) {
+	blueprint, err := GetBlueprint(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			err = tx.Rollback()
+			if err != nil {
+				blueprintLog.Error(err, "DeleteBlueprint: failed to rollback")
+			}
+		}
+	}()
+
+	report := NewDeletionReport(dryRun)
+	err = deleteBlueprint(tx, report, blueprint)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		err = ReloadBlueprints(cronManager)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "error reloading blueprints")
+		}
+	}
+	return report, nil
+}
+
+// deleteBlueprint removes the blueprint and its labels within the transaction, pipelines are kept for
+// the record but deletion is refused while any of them is still pending
+func deleteBlueprint(tx dal.Dal, report *DeletionReport, blueprint *models.Blueprint) errors.Error {
+	pendingCount, err := tx.Count(
+		dal.From(&models.Pipeline{}),
+		dal.Where("blueprint_id = ? AND status IN ?", blueprint.ID, models.PendingTaskStatus),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "error counting pending pipelines of the blueprint")
+	}
+	if pendingCount > 0 {
+		return ErrBlueprintRunning
+	}
+	err = report.deleteRows(tx, models.DbBlueprintLabel{}.TableName(), &models.DbBlueprintLabel{}, dal.Where("blueprint_id = ?", blueprint.ID))
+	if err != nil {
+		return err
+	}
+	return report.deleteRows(tx, blueprint.TableName(), &models.Blueprint{}, dal.Where("id = ?", blueprint.ID))
+}
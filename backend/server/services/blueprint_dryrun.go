@@ -0,0 +1,284 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"sort"
+	"strings"
+	"time"
+)
+
+// collection modes of a raw table
+const (
+	COLLECTION_FULL        = "FULL"
+	COLLECTION_INCREMENTAL = "INCREMENTAL"
+	COLLECTION_BACKFILL    = "BACKFILL"
+)
+
+// PlanExplanation describes what the pipeline plan of a blueprint would do without running it
+type PlanExplanation struct {
+	Plan              plugin.PipelinePlan  `json:"plan"`
+	Stages            [][]*TaskExplanation `json:"stages"`
+	EstimatedApiCalls int64                `json:"estimatedApiCalls"`
+}
+
+// TaskExplanation describes a task in the plan
+type TaskExplanation struct {
+	Plugin            string                   `json:"plugin"`
+	Options           map[string]interface{}   `json:"options"`
+	Subtasks          []string                 `json:"subtasks"`
+	Collections       []*CollectionExplanation `json:"collections"`
+	EstimatedApiCalls int64                    `json:"estimatedApiCalls"`
+}
+
+// CollectionExplanation describes how the data of a scope in a raw table would be collected,
+// EstimatedApiCalls is the number of distinct urls requested by the same kind of collection last time,
+// for a scope never collected in the raw table it is the average of the other scopes, and nil if there are none
+type CollectionExplanation struct {
+	RawDataTable       string             `json:"rawDataTable"`
+	RawDataParams      string             `json:"rawDataParams"`
+	Mode               string             `json:"mode"`
+	NeverCollected     bool               `json:"neverCollected"`
+	LatestSuccessStart *time.Time         `json:"latestSuccessStart"`
+	MissingRanges      []models.TimeRange `json:"missingRanges"`
+	EstimatedApiCalls  *int64             `json:"estimatedApiCalls"`
+}
+
+// DryRunBlueprint makes the pipeline plan of the blueprint and explains it, nothing would be run or saved
+func DryRunBlueprint(id uint64) (*PlanExplanation, errors.Error) {
+	blueprint, err := GetBlueprint(id)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := makePlanForBlueprint(blueprint, true)
+	if err != nil {
+		return nil, err
+	}
+	return explainPipelinePlan(plan)
+}
+
+func explainPipelinePlan(plan plugin.PipelinePlan) (*PlanExplanation, errors.Error) {
+	tables, err := db.AllTables()
+	if err != nil {
+		return nil, err
+	}
+	explanation := &PlanExplanation{
+		Plan:   plan,
+		Stages: make([][]*TaskExplanation, 0, len(plan)),
+	}
+	for _, stage := range plan {
+		stageExplanation := make([]*TaskExplanation, 0, len(stage))
+		for _, task := range stage {
+			taskExplanation, err := explainPipelineTask(task, tables)
+			if err != nil {
+				return nil, err
+			}
+			explanation.EstimatedApiCalls += taskExplanation.EstimatedApiCalls
+			stageExplanation = append(stageExplanation, taskExplanation)
+		}
+		explanation.Stages = append(explanation.Stages, stageExplanation)
+	}
+	return explanation, nil
+}
+
+func explainPipelineTask(task *plugin.PipelineTask, tables []string) (*TaskExplanation, errors.Error) {
+	explanation := &TaskExplanation{
+		Plugin:      task.Plugin,
+		Options:     task.Options,
+		Subtasks:    []string{},
+		Collections: []*CollectionExplanation{},
+	}
+	pluginMeta, err := plugin.GetPlugin(task.Plugin)
+	if err != nil {
+		return nil, err
+	}
+	collects := false
+	if pluginTask, ok := pluginMeta.(plugin.PluginTask); ok {
+		subtaskMetas := pluginTask.SubTaskMetas()
+		subtasksFlag, err := runner.GetSubtasksFlag(subtaskMetas, task.Subtasks)
+		if err != nil {
+			return nil, err
+		}
+		for _, subtaskMeta := range subtaskMetas {
+			if subtasksFlag[subtaskMeta.Name] {
+				explanation.Subtasks = append(explanation.Subtasks, subtaskMeta.Name)
+				collects = collects || strings.HasPrefix(strings.ToLower(subtaskMeta.Name), "collect")
+			}
+		}
+	}
+
+	var createdDateAfter *time.Time
+	if value, ok := task.Options["createdDateAfter"].(string); ok && value != "" {
+		t, err := errors.Convert01(api.ConvertStringToTime(value))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid createdDateAfter")
+		}
+		createdDateAfter = &t
+	}
+	for _, table := range rawTablesOfPlugin(task.Plugin, tables) {
+		collections, err := explainCollections(table, task.Options, createdDateAfter)
+		if err != nil {
+			return nil, err
+		}
+		for _, collection := range collections {
+			if collection.EstimatedApiCalls != nil {
+				explanation.EstimatedApiCalls += *collection.EstimatedApiCalls
+			}
+		}
+		explanation.Collections = append(explanation.Collections, collections...)
+	}
+	// no raw table has been created yet, the task would collect everything from scratch
+	if collects && len(explanation.Collections) == 0 {
+		explanation.Collections = append(explanation.Collections, &CollectionExplanation{
+			Mode:           COLLECTION_FULL,
+			NeverCollected: true,
+		})
+	}
+	return explanation, nil
+}
+
+// rawTablesOfPlugin returns the raw tables with the prefix _raw_<plugin>_ except the ones of other plugins sharing
+// the prefix, e.g. _raw_github_graphql_* tables are not the raw tables of github
+func rawTablesOfPlugin(pluginName string, tables []string) []string {
+	prefix := fmt.Sprintf("_raw_%s_", pluginName)
+	var otherPrefixes []string
+	for name := range plugin.AllPlugins() {
+		otherPrefix := fmt.Sprintf("_raw_%s_", name)
+		if len(otherPrefix) > len(prefix) && strings.HasPrefix(otherPrefix, prefix) {
+			otherPrefixes = append(otherPrefixes, otherPrefix)
+		}
+	}
+	var rawTables []string
+	for _, table := range tables {
+		if !strings.HasPrefix(table, prefix) {
+			continue
+		}
+		ofOther := false
+		for _, otherPrefix := range otherPrefixes {
+			if strings.HasPrefix(table, otherPrefix) {
+				ofOther = true
+				break
+			}
+		}
+		if !ofOther {
+			rawTables = append(rawTables, table)
+		}
+	}
+	return rawTables
+}
+
+// explainCollections finds the collected scopes of the task in the raw table by the options,
+// and tells how they would be collected next time
+func explainCollections(table string, options map[string]interface{}, createdDateAfter *time.Time) ([]*CollectionExplanation, errors.Error) {
+	var allParams []string
+	err := db.Pluck("DISTINCT params", &allParams, dal.From(table))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(allParams)
+	collections := []*CollectionExplanation{}
+	for _, params := range allParams {
+		if !isRawDataParamsOfOptions(params, options) {
+			continue
+		}
+		collection := &CollectionExplanation{
+			RawDataTable:  table,
+			RawDataParams: params,
+			Mode:          COLLECTION_FULL,
+		}
+		latestState := models.CollectorLatestState{}
+		err = db.First(&latestState, dal.Where(`raw_data_table = ? AND raw_data_params = ?`, table, params))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, err
+		}
+		// data collected since last successful start is the result of an incremental collection
+		since := time.Time{}
+		if err == nil {
+			latestState.TimeRanges = api.GetCollectedTimeRanges(&latestState)
+			stateManager := api.ApiCollectorStateManager{LatestState: latestState, CreatedDateAfter: createdDateAfter}
+			collection.LatestSuccessStart = latestState.LatestSuccessStart
+			if stateManager.IsIncremental() {
+				collection.Mode = COLLECTION_INCREMENTAL
+				since = *latestState.LatestSuccessStart
+			} else if stateManager.IsBackfill() {
+				collection.Mode = COLLECTION_BACKFILL
+				collection.MissingRanges = stateManager.GetMissingRanges()
+			}
+		}
+		var counts []int64
+		err = db.Pluck("COUNT(DISTINCT url)", &counts, dal.From(table), dal.Where("params = ? AND created_at >= ?", params, since))
+		if err != nil {
+			return nil, err
+		}
+		if len(counts) > 0 {
+			collection.EstimatedApiCalls = &counts[0]
+		}
+		collections = append(collections, collection)
+	}
+	if len(collections) > 0 {
+		return collections, nil
+	}
+	// the scope has never been collected into the table, estimate by the other scopes
+	collection := &CollectionExplanation{
+		RawDataTable:   table,
+		Mode:           COLLECTION_FULL,
+		NeverCollected: true,
+	}
+	if len(allParams) > 0 {
+		var counts []int64
+		err = db.Pluck("COUNT(DISTINCT url)", &counts, dal.From(table))
+		if err != nil {
+			return nil, err
+		}
+		if len(counts) > 0 {
+			average := counts[0] / int64(len(allParams))
+			collection.EstimatedApiCalls = &average
+		}
+	}
+	return []*CollectionExplanation{collection}, nil
+}
+
+// isRawDataParamsOfOptions returns true if all params could be found in task options, keys are case-insensitive,
+// e.g. {"ConnectionId":1,"BoardId":2} for {"connectionId":1,"boardId":2,"createdDateAfter":"..."}
+func isRawDataParamsOfOptions(params string, options map[string]interface{}) bool {
+	decoded := make(map[string]interface{})
+	if json.Unmarshal([]byte(params), &decoded) != nil || len(decoded) == 0 {
+		return false
+	}
+	for key, value := range decoded {
+		found := false
+		for optionKey, optionValue := range options {
+			if strings.EqualFold(key, optionKey) && fmt.Sprint(value) == fmt.Sprint(optionValue) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
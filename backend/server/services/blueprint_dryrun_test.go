@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRawDataParamsOfOptions(t *testing.T) {
+	options := map[string]interface{}{
+		"connectionId":     float64(1),
+		"boardId":          float64(68),
+		"createdDateAfter": "2023-01-01T00:00:00Z",
+	}
+	assert.True(t, isRawDataParamsOfOptions(`{"ConnectionId":1,"BoardId":68}`, options))
+	assert.True(t, isRawDataParamsOfOptions(`{"ConnectionId":1}`, options))
+	assert.False(t, isRawDataParamsOfOptions(`{"ConnectionId":1,"BoardId":69}`, options))
+	assert.False(t, isRawDataParamsOfOptions(`{"ConnectionId":1,"ProjectId":68}`, options))
+	assert.False(t, isRawDataParamsOfOptions(`{}`, options))
+	assert.False(t, isRawDataParamsOfOptions(`not json`, options))
+
+	// options decoded from json of a string id
+	assert.True(t, isRawDataParamsOfOptions(`{"ConnectionId":1,"Name":"apache/incubator-devlake"}`, map[string]interface{}{
+		"connectionId": 1,
+		"name":         "apache/incubator-devlake",
+	}))
+}
+
+func TestRawTablesOfPlugin(t *testing.T) {
+	plugin.RegisterPlugin("github", new(mockplugin.PluginMeta))
+	plugin.RegisterPlugin("github_graphql", new(mockplugin.PluginMeta))
+	tables := []string{
+		"_raw_github_api_issues",
+		"_raw_github_graphql_issues",
+		"_raw_gitlab_api_issues",
+		"_tool_github_issues",
+	}
+	assert.Equal(t, []string{"_raw_github_api_issues"}, rawTablesOfPlugin("github", tables))
+	assert.Equal(t, []string{"_raw_github_graphql_issues"}, rawTablesOfPlugin("github_graphql", tables))
+	assert.Nil(t, rawTablesOfPlugin("jira", tables))
+}
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// DeletionReport lists how many rows were deleted from each table, or would be deleted in dry-run mode
+type DeletionReport struct {
+	DryRun bool             `json:"dryRun"`
+	Tables map[string]int64 `json:"tables"`
+}
+
+// NewDeletionReport returns an empty DeletionReport
+func NewDeletionReport(dryRun bool) *DeletionReport {
+	return &DeletionReport{
+		DryRun: dryRun,
+		Tables: make(map[string]int64),
+	}
+}
+
+// deleteRows counts the rows of `table` matched by `clauses` and deletes them unless in dry-run mode
+func (r *DeletionReport) deleteRows(tx dal.Dal, table string, entity interface{}, clauses ...dal.Clause) errors.Error {
+	clauses = append([]dal.Clause{dal.From(table)}, clauses...)
+	count, err := tx.Count(clauses...)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error counting rows of %s", table))
+	}
+	if count == 0 {
+		return nil
+	}
+	r.Tables[table] += count
+	if r.DryRun {
+		return nil
+	}
+	err = tx.Delete(entity, clauses...)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error deleting rows of %s", table))
+	}
+	return nil
+}
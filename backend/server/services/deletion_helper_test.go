@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestDeletionReportDryRun(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Count", mock.Anything).Return(int64(3), nil).Once()
+
+	report := NewDeletionReport(true)
+	err := report.deleteRows(mockDal, "projects", &models.Project{}, dal.Where("name = ?", "p"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"projects": 3}, report.Tables)
+	mockDal.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockDal.AssertExpectations(t)
+}
+
+func TestDeletionReportDelete(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Count", mock.Anything).Return(int64(2), nil).Once()
+	mockDal.On("Count", mock.Anything).Return(int64(0), nil).Once()
+	mockDal.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+
+	report := NewDeletionReport(false)
+	err := report.deleteRows(mockDal, "project_mapping", &crossdomain.ProjectMapping{}, dal.Where("project_name = ?", "p"))
+	assert.Nil(t, err)
+	// nothing to be deleted, Delete should not be called
+	err = report.deleteRows(mockDal, "project_metric_settings", &models.ProjectMetricSetting{}, dal.Where("project_name = ?", "p"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"project_mapping": 2}, report.Tables)
+	mockDal.AssertExpectations(t)
+}
@@ -79,10 +79,14 @@ func GetMigrator() plugin.Migrator {
 func Init() {
 	InitResources()
 
+	err := CheckOidcConfig()
+	if err != nil {
+		panic(err)
+	}
+
 	// lock the database to avoid multiple devlake instances from sharing the same one
 	lockDb()
 
-	var err error
 	// now, load the plugins
 	err = runner.LoadPlugins(basicRes)
 	if err != nil {
@@ -125,6 +129,12 @@ func ExecuteMigration() errors.Error {
 
 	// initialize pipeline server, mainly to start the pipeline consuming process
 	pipelineServiceInit()
+
+	// cronjob for purging raw data
+	initRawDataRetention()
+
+	// expose counts of pipelines and tasks as metrics
+	registerMetrics()
 	return nil
 }
 
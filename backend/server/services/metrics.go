@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var registerMetricsOnce sync.Once
+
+// statusCountCollector counts records of a table grouped by status whenever metrics are scraped
+type statusCountCollector struct {
+	db    dal.Dal
+	table string
+	desc  *prometheus.Desc
+}
+
+type statusCount struct {
+	Status string
+	Count  int64
+}
+
+func newStatusCountCollector(db dal.Dal, table string, name string, help string) *statusCountCollector {
+	return &statusCountCollector{
+		db:    db,
+		table: table,
+		desc:  prometheus.NewDesc(name, help, []string{"status"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *statusCountCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *statusCountCollector) Collect(ch chan<- prometheus.Metric) {
+	var counts []statusCount
+	err := c.db.All(
+		&counts,
+		dal.Select("status, COUNT(*) AS count"),
+		dal.From(c.table),
+		dal.Groupby("status"),
+	)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	for _, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count.Count), count.Status)
+	}
+}
+
+// registerMetrics registers collectors of pipelines and tasks counts to the default prometheus registry
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		prometheus.MustRegister(
+			newStatusCountCollector(db, models.Pipeline{}.TableName(), "devlake_pipelines", "Number of pipelines by status"),
+			newStatusCountCollector(db, models.Task{}.TableName(), "devlake_tasks", "Number of tasks by status"),
+		)
+	})
+}
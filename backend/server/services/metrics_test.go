@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStatusCountCollector(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		counts := args.Get(0).(*[]statusCount)
+		*counts = []statusCount{
+			{Status: "TASK_COMPLETED", Count: 3},
+			{Status: "TASK_FAILED", Count: 1},
+		}
+	}).Return(nil).Once()
+
+	collector := newStatusCountCollector(mockDal, "_devlake_pipelines", "devlake_pipelines", "Number of pipelines by status")
+	expected := `
+# HELP devlake_pipelines Number of pipelines by status
+# TYPE devlake_pipelines gauge
+devlake_pipelines{status="TASK_COMPLETED"} 3
+devlake_pipelines{status="TASK_FAILED"} 1
+`
+	assert.Nil(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+
+	mockDal.On("All", mock.Anything, mock.Anything).Return(errors.Default.New("db is down")).Once()
+	assert.NotNil(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
@@ -37,8 +37,9 @@ func init() {
 
 // NotificationService FIXME ...
 type NotificationService struct {
-	EndPoint string
-	Secret   string
+	EndPoint  string
+	Secret    string
+	ChannelId uint64
 }
 
 // NewNotificationService FIXME ...
@@ -73,6 +74,7 @@ func (n *NotificationService) sendNotification(notificationType models.Notificat
 	var notification models.Notification
 	notification.Data = string(dataJson)
 	notification.Type = notificationType
+	notification.ChannelId = n.ChannelId
 	notification.Endpoint = n.EndPoint
 	nonce := randSeq(16)
 	notification.Nonce = nonce
@@ -90,6 +92,7 @@ func (n *NotificationService) sendNotification(notificationType models.Notificat
 		return errors.Convert(err)
 	}
 
+	defer resp.Body.Close()
 	notification.ResponseCode = resp.StatusCode
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -0,0 +1,385 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const defaultNotificationTemplate = `Pipeline #{{.PipelineID}} {{.PipelineName}}` +
+	`{{if .ProjectName}} of project {{.ProjectName}}{{end}} finished with status {{.Status}}` +
+	`{{if .Message}}: {{.Message}}{{end}}`
+
+// notificationChannelMask replaces the credentials of notification channels in api responses
+const notificationChannelMask = "********"
+
+var notificationHttpClient = &http.Client{Timeout: 30 * time.Second}
+
+// the pipeline name could contain line breaks, which must not end up in the email headers
+var headerLineBreaks = strings.NewReplacer("\r", " ", "\n", " ")
+
+// PipelineNotificationMessage is the data used to render the templates of notification channels
+type PipelineNotificationMessage struct {
+	PipelineNotification
+	PipelineName string
+	BlueprintId  uint64
+	ProjectName  string
+	Message      string
+}
+
+// CreateNotificationChannel accepts a notification channel and saves it
+func CreateNotificationChannel(channel *models.NotificationChannel) errors.Error {
+	err := validateNotificationChannel(channel)
+	if err != nil {
+		return err
+	}
+	return db.Create(channel)
+}
+
+// GetNotificationChannels returns all notification channels
+func GetNotificationChannels() ([]*models.NotificationChannel, errors.Error) {
+	channels := make([]*models.NotificationChannel, 0)
+	err := db.All(&channels, dal.Orderby("id"))
+	if err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// GetNotificationChannel returns the notification channel of the id
+func GetNotificationChannel(id uint64) (*models.NotificationChannel, errors.Error) {
+	channel := &models.NotificationChannel{}
+	err := db.First(channel, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.Wrap(err, "notification channel not found")
+		}
+		return nil, err
+	}
+	return channel, nil
+}
+
+// MaskNotificationChannel returns a copy of the channel with the endpoint and secret masked, only the scheme and
+// host of the endpoint are kept to tell where the notifications go
+func MaskNotificationChannel(channel *models.NotificationChannel) *models.NotificationChannel {
+	masked := *channel
+	masked.Endpoint = maskNotificationEndpoint(channel.Endpoint)
+	if masked.Secret != "" {
+		masked.Secret = notificationChannelMask
+	}
+	return &masked
+}
+
+func maskNotificationEndpoint(endpoint string) string {
+	if endpoint == "" {
+		return ""
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return notificationChannelMask
+	}
+	return fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, notificationChannelMask)
+}
+
+// PatchNotificationChannel updates the notification channel with the fields in body
+func PatchNotificationChannel(id uint64, body map[string]interface{}) (*models.NotificationChannel, errors.Error) {
+	channel, err := GetNotificationChannel(id)
+	if err != nil {
+		return nil, err
+	}
+	// the masked values sent back by clients are not meant to change the credentials
+	if endpoint, ok := body["endpoint"].(string); ok && endpoint != "" && endpoint == maskNotificationEndpoint(channel.Endpoint) {
+		delete(body, "endpoint")
+	}
+	if secret, ok := body["secret"].(string); ok && secret == notificationChannelMask {
+		delete(body, "secret")
+	}
+	err = helper.DecodeMapStruct(body, channel)
+	if err != nil {
+		return nil, err
+	}
+	channel.ID = id
+	err = validateNotificationChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(channel)
+	if err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// DeleteNotificationChannel deletes the notification channel
+func DeleteNotificationChannel(id uint64) errors.Error {
+	channel, err := GetNotificationChannel(id)
+	if err != nil {
+		return err
+	}
+	return db.Delete(channel)
+}
+
+// TestNotificationChannel sends a sample notification through the channel
+func TestNotificationChannel(id uint64) errors.Error {
+	channel, err := GetNotificationChannel(id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return sendToNotificationChannel(channel, &PipelineNotificationMessage{
+		PipelineNotification: PipelineNotification{
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			BeganAt:    &now,
+			FinishedAt: &now,
+			Status:     models.TASK_COMPLETED,
+		},
+		PipelineName: "test",
+		BlueprintId:  channel.BlueprintId,
+		ProjectName:  channel.ProjectName,
+		Message:      "this is a test notification from DevLake",
+	})
+}
+
+func validateNotificationChannel(channel *models.NotificationChannel) errors.Error {
+	err := VerifyStruct(channel)
+	if err != nil {
+		return err
+	}
+	if channel.Type == models.NOTIFICATION_CHANNEL_EMAIL {
+		if _, err := mail.ParseAddressList(channel.Recipients); err != nil {
+			return errors.BadInput.Wrap(err, "recipients should be a comma separated list of email addresses")
+		}
+	} else if channel.Endpoint == "" {
+		return errors.BadInput.New("endpoint is required")
+	}
+	if channel.Template != "" {
+		if _, err := template.New(channel.Name).Parse(channel.Template); err != nil {
+			return errors.BadInput.Wrap(err, "invalid template")
+		}
+	}
+	return nil
+}
+
+// notifyChannels sends the notification of the pipeline to all enabled channels matching it
+func notifyChannels(pipeline *models.Pipeline, params PipelineNotification) errors.Error {
+	channels := make([]*models.NotificationChannel, 0)
+	err := db.All(&channels, dal.Where("enable = ?", true), dal.Orderby("id"))
+	if err != nil {
+		return err
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+	message := &PipelineNotificationMessage{
+		PipelineNotification: params,
+		PipelineName:         pipeline.Name,
+		BlueprintId:          pipeline.BlueprintId,
+		Message:              pipeline.Message,
+	}
+	if pipeline.BlueprintId != 0 {
+		blueprint, err := GetDbBlueprint(pipeline.BlueprintId)
+		if err != nil && !db.IsErrorNotFound(err) {
+			return err
+		}
+		if blueprint != nil {
+			message.ProjectName = blueprint.ProjectName
+		}
+	}
+	var lastErr errors.Error
+	for _, channel := range channels {
+		if !matchNotificationChannel(channel, message) {
+			continue
+		}
+		err = sendToNotificationChannel(channel, message)
+		if err != nil {
+			globalPipelineLog.Error(err, "failed to send notification to channel %d", channel.ID)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// matchNotificationChannel tells whether the channel is interested in the notification
+func matchNotificationChannel(channel *models.NotificationChannel, message *PipelineNotificationMessage) bool {
+	if channel.FailuresOnly && message.Status != models.TASK_FAILED && message.Status != models.TASK_PARTIAL {
+		return false
+	}
+	if channel.BlueprintId != 0 {
+		return channel.BlueprintId == message.BlueprintId
+	}
+	if channel.ProjectName != "" {
+		return channel.ProjectName == message.ProjectName
+	}
+	return true
+}
+
+func renderNotificationMessage(channel *models.NotificationChannel, message *PipelineNotificationMessage) (string, errors.Error) {
+	text := channel.Template
+	if text == "" {
+		text = defaultNotificationTemplate
+	}
+	tmpl, err := template.New(channel.Name).Parse(text)
+	if err != nil {
+		return "", errors.BadInput.Wrap(err, "invalid template")
+	}
+	buf := &bytes.Buffer{}
+	err = tmpl.Execute(buf, message)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "failed to render template")
+	}
+	return buf.String(), nil
+}
+
+func notificationSubject(message *PipelineNotificationMessage) string {
+	return fmt.Sprintf("[DevLake] Pipeline #%d %s %s", message.PipelineID, headerLineBreaks.Replace(message.PipelineName), message.Status)
+}
+
+func sendToNotificationChannel(channel *models.NotificationChannel, message *PipelineNotificationMessage) errors.Error {
+	if channel.Type == models.NOTIFICATION_CHANNEL_WEBHOOK {
+		n := NewNotificationService(channel.Endpoint, channel.Secret)
+		n.ChannelId = channel.ID
+		return n.PipelineStatusChanged(message.PipelineNotification)
+	}
+	text, err := renderNotificationMessage(channel, message)
+	if err != nil {
+		return err
+	}
+	notification := &models.Notification{
+		Type:      models.NotificationPipelineStatusChanged,
+		ChannelId: channel.ID,
+		Endpoint:  channel.Endpoint,
+		Data:      text,
+	}
+	switch channel.Type {
+	case models.NOTIFICATION_CHANNEL_SLACK:
+		err = postNotificationJson(notification, map[string]interface{}{
+			"text": text,
+		})
+	case models.NOTIFICATION_CHANNEL_TEAMS:
+		err = postNotificationJson(notification, map[string]interface{}{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    notificationSubject(message),
+			"title":      notificationSubject(message),
+			"themeColor": notificationThemeColor(message.Status),
+			"text":       text,
+		})
+	case models.NOTIFICATION_CHANNEL_EMAIL:
+		notification.Endpoint = "mailto:" + channel.Recipients
+		err = sendNotificationEmail(notification, channel.Recipients, notificationSubject(message), text)
+	default:
+		return errors.BadInput.New(fmt.Sprintf("unsupported notification channel type %s", channel.Type))
+	}
+	// record the notification no matter it was sent or not
+	dbErr := db.Create(notification)
+	if err != nil {
+		return err
+	}
+	return dbErr
+}
+
+func notificationThemeColor(status string) string {
+	switch status {
+	case models.TASK_COMPLETED:
+		return "2EB67D"
+	case models.TASK_PARTIAL:
+		return "ECB22E"
+	default:
+		return "E01E5A"
+	}
+}
+
+func postNotificationJson(notification *models.Notification, payload interface{}) errors.Error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	resp, err := notificationHttpClient.Post(notification.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		notification.Response = err.Error()
+		return errors.Convert(err)
+	}
+	defer resp.Body.Close()
+	notification.ResponseCode = resp.StatusCode
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	notification.Response = string(respBody)
+	if resp.StatusCode >= 300 {
+		return errors.Default.New(fmt.Sprintf("notification rejected with status %d: %s", resp.StatusCode, notification.Response))
+	}
+	return nil
+}
+
+func sendNotificationEmail(notification *models.Notification, recipients, subject, text string) errors.Error {
+	host := cfg.GetString("SMTP_HOST")
+	if host == "" {
+		return errors.BadInput.New("SMTP_HOST is required to send notification emails")
+	}
+	from := cfg.GetString("SMTP_FROM")
+	username := cfg.GetString("SMTP_USERNAME")
+	if from == "" {
+		from = username
+	}
+	addresses, err := mail.ParseAddressList(recipients)
+	if err != nil {
+		return errors.BadInput.Wrap(err, "invalid recipients")
+	}
+	to := make([]string, len(addresses))
+	for i, address := range addresses {
+		to[i] = address.Address
+	}
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, cfg.GetString("SMTP_PASSWORD"), host)
+	}
+	msg := strings.Join([]string{
+		"From: " + from,
+		"To: " + strings.Join(to, ", "),
+		"Subject: " + mime.QEncoding.Encode("UTF-8", headerLineBreaks.Replace(subject)),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		text,
+	}, "\r\n")
+	err = smtp.SendMail(net.JoinHostPort(host, cfg.GetString("SMTP_PORT")), auth, from, to, []byte(msg))
+	if err != nil {
+		notification.Response = err.Error()
+		return errors.Convert(err)
+	}
+	notification.Response = "sent"
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/models"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMatchNotificationChannel(t *testing.T) {
+	failed := &PipelineNotificationMessage{BlueprintId: 1, ProjectName: "devlake"}
+	failed.Status = models.TASK_FAILED
+	completed := &PipelineNotificationMessage{BlueprintId: 2, ProjectName: "devlake"}
+	completed.Status = models.TASK_COMPLETED
+	partial := &PipelineNotificationMessage{}
+	partial.Status = models.TASK_PARTIAL
+
+	global := &models.NotificationChannel{}
+	assert.True(t, matchNotificationChannel(global, failed))
+	assert.True(t, matchNotificationChannel(global, partial))
+
+	failuresOnly := &models.NotificationChannel{FailuresOnly: true}
+	assert.True(t, matchNotificationChannel(failuresOnly, failed))
+	assert.True(t, matchNotificationChannel(failuresOnly, partial))
+	assert.False(t, matchNotificationChannel(failuresOnly, completed))
+
+	blueprint := &models.NotificationChannel{BlueprintId: 2, ProjectName: "devlake"}
+	assert.False(t, matchNotificationChannel(blueprint, failed))
+	assert.True(t, matchNotificationChannel(blueprint, completed))
+
+	project := &models.NotificationChannel{ProjectName: "devlake"}
+	assert.True(t, matchNotificationChannel(project, failed))
+	assert.True(t, matchNotificationChannel(project, completed))
+	assert.False(t, matchNotificationChannel(project, partial))
+}
+
+func TestRenderNotificationMessage(t *testing.T) {
+	message := &PipelineNotificationMessage{PipelineName: "daily", ProjectName: "devlake", Message: "boom"}
+	message.PipelineID = 7
+	message.Status = models.TASK_FAILED
+
+	text, err := renderNotificationMessage(&models.NotificationChannel{}, message)
+	assert.Nil(t, err)
+	assert.Equal(t, "Pipeline #7 daily of project devlake finished with status TASK_FAILED: boom", text)
+
+	channel := &models.NotificationChannel{Template: "{{.ProjectName}}/{{.PipelineID}} {{.Status}}"}
+	text, err = renderNotificationMessage(channel, message)
+	assert.Nil(t, err)
+	assert.Equal(t, "devlake/7 TASK_FAILED", text)
+
+	_, err = renderNotificationMessage(&models.NotificationChannel{Template: "{{.Unknown}}"}, message)
+	assert.NotNil(t, err)
+}
+
+func TestSendToSlackChannel(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &payload)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var notification *models.Notification
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		notification = args.Get(0).(*models.Notification)
+	}).Return(nil).Once()
+	originalDb := db
+	db = mockDal
+	defer func() {
+		db = originalDb
+	}()
+
+	channel := &models.NotificationChannel{Type: models.NOTIFICATION_CHANNEL_SLACK, Endpoint: server.URL, Template: "{{.Status}}"}
+	channel.ID = 3
+	message := &PipelineNotificationMessage{}
+	message.Status = models.TASK_COMPLETED
+	err := sendToNotificationChannel(channel, message)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"text": models.TASK_COMPLETED}, payload)
+	assert.Equal(t, uint64(3), notification.ChannelId)
+	assert.Equal(t, http.StatusOK, notification.ResponseCode)
+	assert.Equal(t, "ok", notification.Response)
+	mockDal.AssertExpectations(t)
+}
+
+func TestNotificationSubject(t *testing.T) {
+	message := &PipelineNotificationMessage{PipelineName: "nightly\r\nBcc: victim@example.com"}
+	message.PipelineID = 5
+	message.Status = models.TASK_FAILED
+	subject := notificationSubject(message)
+	assert.NotContains(t, subject, "\r")
+	assert.NotContains(t, subject, "\n")
+	assert.Equal(t, "[DevLake] Pipeline #5 nightly  Bcc: victim@example.com TASK_FAILED", subject)
+}
+
+func TestMaskNotificationChannel(t *testing.T) {
+	channel := &models.NotificationChannel{
+		Endpoint: "https://hooks.slack.com/services/T000/B000/XXXX",
+		Secret:   "s3cret",
+	}
+	masked := MaskNotificationChannel(channel)
+	assert.Equal(t, "https://hooks.slack.com/********", masked.Endpoint)
+	assert.Equal(t, "********", masked.Secret)
+	// the channel itself is left untouched
+	assert.Equal(t, "s3cret", channel.Secret)
+
+	masked = MaskNotificationChannel(&models.NotificationChannel{Type: models.NOTIFICATION_CHANNEL_EMAIL})
+	assert.Equal(t, "", masked.Endpoint)
+	assert.Equal(t, "", masked.Secret)
+}
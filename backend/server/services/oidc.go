@@ -0,0 +1,203 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	oidcClockSkew        = time.Minute
+	oidcKeysRefreshDelay = time.Minute
+)
+
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512"}
+
+var oidcVerifierOnce sync.Once
+var globalOidcVerifier *oidcVerifier
+var globalOidcVerifierErr errors.Error
+
+// oidcVerifier verifies the RS256/RS384/RS512 signed JWTs issued by the issuer with the keys published by
+// the jwks_uri of its discovery document
+type oidcVerifier struct {
+	issuer    string
+	audience  string
+	client    *http.Client
+	parser    *jwt.Parser
+	fetching  singleflight.Group
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+type oidcJwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// CheckOidcConfig makes sure OIDC_AUDIENCE is set along with OIDC_ISSUER, tokens issued for any other
+// client of the issuer would be accepted otherwise
+func CheckOidcConfig() errors.Error {
+	if cfg.GetString("OIDC_ISSUER") == "" {
+		return nil
+	}
+	_, err := getOidcVerifier()
+	return err
+}
+
+func getOidcVerifier() (*oidcVerifier, errors.Error) {
+	oidcVerifierOnce.Do(func() {
+		globalOidcVerifier, globalOidcVerifierErr = newOidcVerifier(cfg.GetString("OIDC_ISSUER"), cfg.GetString("OIDC_AUDIENCE"))
+	})
+	return globalOidcVerifier, globalOidcVerifierErr
+}
+
+func newOidcVerifier(issuer, audience string) (*oidcVerifier, errors.Error) {
+	if audience == "" {
+		return nil, errors.BadInput.New("OIDC_AUDIENCE is required when OIDC_ISSUER is set")
+	}
+	return &oidcVerifier{
+		issuer:   issuer,
+		audience: audience,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(oidcSigningMethods),
+			jwt.WithIssuer(issuer),
+			jwt.WithAudience(audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(oidcClockSkew),
+		),
+	}, nil
+}
+
+// verify checks the signature, issuer, audience and validity period of the token and returns its claims
+func (v *oidcVerifier) verify(token string) (map[string]interface{}, errors.Error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, err := v.getKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, errors.Unauthorized.Wrap(err, "invalid bearer token")
+	}
+	return claims, nil
+}
+
+// getKey returns the key of the kid, keys are refetched if the kid is unknown, i.e. rotated by the issuer.
+// Concurrent callers share the same fetching and no lock is held while fetching
+func (v *oidcVerifier) getKey(kid string) (*rsa.PublicKey, errors.Error) {
+	v.mu.RLock()
+	key := v.findKey(kid)
+	fetchedAt := v.fetchedAt
+	v.mu.RUnlock()
+	if key != nil {
+		return key, nil
+	}
+	if time.Since(fetchedAt) < oidcKeysRefreshDelay {
+		return nil, errors.Unauthorized.New("unknown signing key of bearer token")
+	}
+	_, err, _ := v.fetching.Do("keys", func() (interface{}, error) {
+		keys, err := v.fetchKeys()
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		v.fetchedAt = time.Now()
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+		return nil, nil
+	})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if key = v.findKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, errors.Unauthorized.New("unknown signing key of bearer token")
+}
+
+func (v *oidcVerifier) findKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key
+		}
+	}
+	return v.keys[kid]
+}
+
+func (v *oidcVerifier) fetchKeys() (map[string]*rsa.PublicKey, errors.Error) {
+	discovery := struct {
+		JwksUri string `json:"jwks_uri"`
+	}{}
+	err := v.getJson(strings.TrimSuffix(v.issuer, "/")+"/.well-known/openid-configuration", &discovery)
+	if err != nil {
+		return nil, err
+	}
+	jwks := struct {
+		Keys []oidcJwk `json:"keys"`
+	}{}
+	err = v.getJson(discovery.JwksUri, &jwks)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		n, e1 := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, e2 := base64.RawURLEncoding.DecodeString(jwk.E)
+		if e1 != nil || e2 != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+func (v *oidcVerifier) getJson(url string, result interface{}) errors.Error {
+	resp, err := v.client.Get(url)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to request %s", url))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Default.New(fmt.Sprintf("unexpected status %d of %s", resp.StatusCode, url))
+	}
+	return errors.Convert(json.NewDecoder(resp.Body).Decode(result))
+}
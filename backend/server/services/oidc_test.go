@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func signTestJwt(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	assert.Nil(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestOidcVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	var issuer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{"jwks_uri": issuer + "/jwks"})
+		case "/jwks":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+				"kid": "k1",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	issuer = server.URL
+
+	_, err = newOidcVerifier(issuer, "")
+	assert.NotNil(t, err)
+	verifier, err := newOidcVerifier(issuer, "devlake")
+	assert.Nil(t, err)
+	exp := float64(time.Now().Add(time.Hour).Unix())
+	claims, err := verifier.verify(signTestJwt(t, key, "k1", map[string]interface{}{
+		"iss": issuer, "aud": []string{"devlake"}, "exp": exp, "email": "a@b.c",
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, "a@b.c", claims["email"])
+
+	_, err = verifier.verify(signTestJwt(t, key, "k1", map[string]interface{}{
+		"iss": issuer, "aud": "others", "exp": exp,
+	}))
+	assert.NotNil(t, err)
+
+	_, err = verifier.verify(signTestJwt(t, key, "k1", map[string]interface{}{
+		"iss": issuer, "aud": "devlake", "exp": float64(time.Now().Add(-time.Hour).Unix()),
+	}))
+	assert.NotNil(t, err)
+
+	_, err = verifier.verify(signTestJwt(t, key, "k1", map[string]interface{}{
+		"iss": "https://evil.example.com", "aud": "devlake", "exp": exp,
+	}))
+	assert.NotNil(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	_, err = verifier.verify(signTestJwt(t, otherKey, "k1", map[string]interface{}{
+		"iss": issuer, "aud": "devlake", "exp": exp,
+	}))
+	assert.NotNil(t, err)
+}
+
+func TestGetOidcRole(t *testing.T) {
+	assert.Equal(t, models.API_ROLE_ADMIN, getOidcRole([]interface{}{"viewer", "Admin", "others"}, ""))
+	assert.Equal(t, models.API_ROLE_OPERATOR, getOidcRole("viewer,operator", ""))
+	assert.Equal(t, models.API_ROLE_VIEWER, getOidcRole(nil, models.API_ROLE_VIEWER))
+	assert.Equal(t, models.API_ROLE_OPERATOR, getOidcRole([]interface{}{"viewer"}, models.API_ROLE_OPERATOR))
+	assert.Equal(t, "", getOidcRole([]interface{}{"others"}, ""))
+}
+
+func TestApiPrincipalHasRole(t *testing.T) {
+	operator := &ApiPrincipal{Role: models.API_ROLE_OPERATOR}
+	assert.True(t, operator.HasRole(models.API_ROLE_VIEWER))
+	assert.True(t, operator.HasRole(models.API_ROLE_OPERATOR))
+	assert.False(t, operator.HasRole(models.API_ROLE_ADMIN))
+	assert.False(t, (&ApiPrincipal{}).HasRole(models.API_ROLE_VIEWER))
+}
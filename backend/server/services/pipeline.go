@@ -338,29 +338,36 @@ func getTemporalWorkflowId(pipelineId uint64) string {
 	return fmt.Sprintf("pipeline #%d", pipelineId)
 }
 
-// NotifyExternal FIXME ...
+// NotifyExternal sends the notification of the pipeline to the endpoint configured by NOTIFICATION_ENDPOINT
+// and the enabled notification channels matching the pipeline
 func NotifyExternal(pipelineId uint64) errors.Error {
-	if notificationService == nil {
-		return nil
-	}
-	// send notification to an external web endpoint
 	pipeline, err := GetPipeline(pipelineId)
 	if err != nil {
 		return err
 	}
-	err = notificationService.PipelineStatusChanged(PipelineNotification{
+	params := PipelineNotification{
 		PipelineID: pipeline.ID,
 		CreatedAt:  pipeline.CreatedAt,
 		UpdatedAt:  pipeline.UpdatedAt,
 		BeganAt:    pipeline.BeganAt,
 		FinishedAt: pipeline.FinishedAt,
 		Status:     pipeline.Status,
-	})
+	}
+	var notifyErr errors.Error
+	if notificationService != nil {
+		// send notification to an external web endpoint
+		notifyErr = notificationService.PipelineStatusChanged(params)
+		if notifyErr != nil {
+			globalPipelineLog.Error(notifyErr, "failed to send notification: %v", notifyErr)
+		}
+	}
+	// a failure of the endpoint above should not stop the channels from being notified
+	err = notifyChannels(pipeline, params)
 	if err != nil {
-		globalPipelineLog.Error(err, "failed to send notification: %v", err)
+		globalPipelineLog.Error(err, "failed to send notification to channels: %v", err)
 		return err
 	}
-	return nil
+	return notifyErr
 }
 
 // CancelPipeline FIXME ...
@@ -424,6 +431,15 @@ func getPipelineLogsPath(pipeline *models.Pipeline) (string, errors.Error) {
 
 // RerunPipeline would rerun all failed tasks or specified task
 func RerunPipeline(pipelineId uint64, task *models.Task) ([]*models.Task, errors.Error) {
+	return rerunPipeline(pipelineId, task, false)
+}
+
+// ResumePipeline would resume all failed tasks or specified task from the first subtask not completed
+func ResumePipeline(pipelineId uint64, task *models.Task) ([]*models.Task, errors.Error) {
+	return rerunPipeline(pipelineId, task, true)
+}
+
+func rerunPipeline(pipelineId uint64, task *models.Task, resume bool) ([]*models.Task, errors.Error) {
 	// prevent pipeline executor from doing anything that might jeopardize the integrity
 	cronLocker.Lock()
 	defer cronLocker.Unlock()
@@ -485,16 +501,24 @@ func RerunPipeline(pipelineId uint64, task *models.Task) ([]*models.Task, errors
 		if err != nil {
 			return nil, err
 		}
+		var completedSubtasks []string
+		if resume {
+			completedSubtasks, err = getCompletedSubtasks(t)
+			if err != nil {
+				return nil, err
+			}
+		}
 		rerunTask, err := CreateTask(&models.NewTask{
 			PipelineTask: &plugin.PipelineTask{
 				Plugin:   t.Plugin,
 				Subtasks: subtasks,
 				Options:  options,
 			},
-			PipelineId:  t.PipelineId,
-			PipelineRow: t.PipelineRow,
-			PipelineCol: t.PipelineCol,
-			IsRerun:     true,
+			PipelineId:        t.PipelineId,
+			PipelineRow:       t.PipelineRow,
+			PipelineCol:       t.PipelineCol,
+			IsRerun:           true,
+			CompletedSubtasks: completedSubtasks,
 		})
 		if err != nil {
 			return nil, err
@@ -513,3 +537,20 @@ func RerunPipeline(pipelineId uint64, task *models.Task) ([]*models.Task, errors
 	}
 	return rerunTasks, nil
 }
+
+// getCompletedSubtasks returns subtasks completed by the task, including those skipped because it resumed another one
+func getCompletedSubtasks(task *models.Task) ([]string, errors.Error) {
+	completedSubtasks, err := task.GetCompletedSubtasks()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	err = db.Pluck("name", &names,
+		dal.From(&models.Subtask{}),
+		dal.Where("task_id = ? AND status = ?", task.ID, models.TASK_COMPLETED),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return append(completedSubtasks, names...), nil
+}
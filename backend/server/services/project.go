@@ -22,8 +22,10 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"strings"
 )
 
 // ProjectQuery used to query projects as the api project input
@@ -31,6 +33,14 @@ type ProjectQuery struct {
 	Pagination
 }
 
+// ProjectDeleteQuery controls what DeleteProject removes besides the project itself
+type ProjectDeleteQuery struct {
+	// DryRun reports what would be deleted without deleting anything
+	DryRun bool `form:"dryRun"`
+	// DeleteData deletes the domain and tool layer data of scopes that belong to this project only
+	DeleteData bool `form:"deleteData"`
+}
+
 // GetProjects returns a paginated list of Projects based on `query`
 func GetProjects(query *ProjectQuery) ([]*models.Project, int64, errors.Error) {
 	// verify input
@@ -254,6 +264,170 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 	return makeProjectOutput(&projectInput.BaseProject)
 }
 
+// DeleteProject removes the project along with its blueprint, metric settings, metrics and mappings,
+// and optionally the data of the scopes which are not shared with any other project
+func DeleteProject(name string, query *ProjectDeleteQuery) (*DeletionReport, errors.Error) {
+	// verify input
+	if name == "" {
+		return nil, errors.BadInput.New("project name is missing")
+	}
+	project := &models.Project{}
+	err := db.First(project, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.Wrap(err, fmt.Sprintf("could not find project [%s] in DB", name))
+		}
+		return nil, errors.Default.Wrap(err, "error getting project from DB")
+	}
+	blueprint, err := GetBlueprintByProjectName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// wrap all operation inside a transaction
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			err = tx.Rollback()
+			if err != nil {
+				logger.Error(err, "DeleteProject: failed to rollback")
+			}
+		}
+	}()
+
+	report := NewDeletionReport(query.DryRun)
+	if blueprint != nil {
+		err = deleteBlueprint(tx, report, blueprint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// scope data must be located before project_mapping is gone
+	if query.DeleteData {
+		err = deleteProjectScopeData(tx, report, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, entity := range []dal.Tabler{
+		&models.ProjectMetricSetting{},
+		&crossdomain.ProjectPrMetric{},
+		&crossdomain.ProjectIssueMetric{},
+		&crossdomain.ProjectDoraMetric{},
+		&crossdomain.ProjectMapping{},
+	} {
+		err = report.deleteRows(tx, entity.TableName(), entity, dal.Where("project_name = ?", name))
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = report.deleteRows(tx, project.TableName(), &models.Project{}, dal.Where("name = ?", name))
+	if err != nil {
+		return nil, err
+	}
+
+	// commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	if blueprint != nil && !query.DryRun {
+		err = ReloadBlueprints(cronManager)
+		if err != nil {
+			return nil, errors.Internal.Wrap(err, "error reloading blueprints")
+		}
+	}
+	return report, nil
+}
+
+// deleteProjectScopeData deletes all rows, including the raw data, collected for the scopes of the project.
+// The rows are located by the `_raw_data_params` of the domain layer scopes (repos, boards, cicd_scopes, etc.)
+// since the same params are shared by all the subtasks of the scope. Scopes mapped to other projects are kept.
+func deleteProjectScopeData(tx dal.Dal, report *DeletionReport, projectName string) errors.Error {
+	mappings := make([]*crossdomain.ProjectMapping, 0)
+	err := tx.All(&mappings, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "error getting project mappings from DB")
+	}
+	if len(mappings) == 0 {
+		return nil
+	}
+	rowIds := make([]string, len(mappings))
+	for i, mapping := range mappings {
+		rowIds[i] = mapping.RowId
+	}
+	sharedMappings := make([]*crossdomain.ProjectMapping, 0)
+	err = tx.All(&sharedMappings, dal.Where("project_name != ? AND row_id IN ?", projectName, rowIds))
+	if err != nil {
+		return errors.Default.Wrap(err, "error getting shared project mappings from DB")
+	}
+	shared := make(map[string]bool)
+	for _, mapping := range sharedMappings {
+		shared[mapping.Table+":"+mapping.RowId] = true
+	}
+
+	params := make([]string, 0)
+	for _, mapping := range mappings {
+		if shared[mapping.Table+":"+mapping.RowId] {
+			continue
+		}
+		origin := &common.RawDataOrigin{}
+		err = tx.First(origin, dal.From(mapping.Table), dal.Where("id = ?", mapping.RowId))
+		if err != nil {
+			if tx.IsErrorNotFound(err) {
+				continue
+			}
+			return errors.Default.Wrap(err, fmt.Sprintf("error getting scope %s from %s", mapping.RowId, mapping.Table))
+		}
+		if origin.RawDataParams != "" {
+			params = append(params, origin.RawDataParams)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+
+	tables, err := tx.AllTables()
+	if err != nil {
+		return err
+	}
+	rawTables := make([]string, 0)
+	for _, table := range tables {
+		if strings.HasPrefix(table, "_raw_") {
+			rawTables = append(rawTables, table)
+			err = report.deleteRows(tx, table, &helper.RawData{}, dal.Where("params IN ?", params))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		columns, err := dal.GetColumnNames(tx, dal.DefaultTabler{Name: table}, func(columnMeta dal.ColumnMeta) bool {
+			return columnMeta.Name() == "_raw_data_params"
+		})
+		if err != nil {
+			return err
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		err = report.deleteRows(tx, table, &common.RawDataOrigin{}, dal.Where("_raw_data_params IN ?", params))
+		if err != nil {
+			return err
+		}
+	}
+	if len(rawTables) == 0 {
+		return nil
+	}
+	// the collectors of the scopes must start over if they get collected again
+	return report.deleteRows(
+		tx,
+		models.CollectorLatestState{}.TableName(),
+		&models.CollectorLatestState{},
+		dal.Where("raw_data_table IN ? AND raw_data_params IN ?", rawTables, params),
+	)
+}
+
 func refreshProjectMetrics(tx dal.Transaction, projectInput *models.ApiInputProject) errors.Error {
 	err := tx.Delete(&models.ProjectMetricSetting{}, dal.Where("project_name = ?", projectInput.Name))
 	if err != nil {
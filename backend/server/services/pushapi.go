@@ -18,15 +18,290 @@ limitations under the License.
 package services
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"sort"
+	"strings"
+	"time"
 )
 
-// InsertRow FIXME ...
-func InsertRow(table string, rows []map[string]interface{}) (int64, errors.Error) {
-	err := db.Create(rows, dal.From(table))
+// PUSH_API_RAW_DATA_TABLE is stamped into the `_raw_data_table` of the rows pushed
+const PUSH_API_RAW_DATA_TABLE = "push_api"
+
+// PushOptions are the provenance of the rows pushed
+type PushOptions struct {
+	// RawDataParams would be stamped into `_raw_data_params`, so the rows could be flushed together later
+	RawDataParams string
+	// Pusher would be stamped into `_raw_data_remark`
+	Pusher string
+}
+
+// PushRowError is the error of a row which failed to be pushed
+type PushRowError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// PushResult reports how the rows were pushed
+type PushResult struct {
+	RowsAffected int64           `json:"rowsAffected"`
+	RowsInserted int64           `json:"rowsInserted"`
+	RowsUpdated  int64           `json:"rowsUpdated"`
+	Errors       []*PushRowError `json:"errors"`
+}
+
+// pushBatchSize is the max number of rows upserted in one transaction
+const pushBatchSize = 500
+
+type pushRow struct {
+	index  int
+	values map[string]interface{}
+}
+
+type pushTable struct {
+	tabler      dal.Tabler
+	columns     map[string]dal.ColumnMeta
+	primaryKeys []string
+}
+
+// UpsertRows validates the rows against the columns of the domain layer table and upserts them by the primary keys,
+// rows failed to be validated or saved are reported in PushResult.Errors without affecting other rows
+func UpsertRows(tableName string, rows []map[string]interface{}, options *PushOptions) (*PushResult, errors.Error) {
+	table, err := getPushTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+	result := &PushResult{Errors: make([]*PushRowError, 0)}
+	now := time.Now()
+	batch := make([]*pushRow, 0, pushBatchSize)
+	for i, row := range rows {
+		values, err := convertPushRow(table, row, options, now)
+		if err != nil {
+			result.Errors = append(result.Errors, &PushRowError{Index: i, Error: err.Error()})
+			continue
+		}
+		batch = append(batch, &pushRow{index: i, values: values})
+		if len(batch) == pushBatchSize {
+			upsertBatch(table, batch, result)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		upsertBatch(table, batch, result)
+	}
+	result.RowsAffected = result.RowsInserted + result.RowsUpdated
+	return result, nil
+}
+
+func getPushTable(tableName string) (*pushTable, errors.Error) {
+	var tabler dal.Tabler
+	for _, t := range domaininfo.GetDomainTablesInfo() {
+		if t.TableName() == tableName {
+			tabler = t
+			break
+		}
+	}
+	if tabler == nil {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s is not a domain layer table", tableName))
+	}
+	columnMetas, err := db.GetColumns(tabler, nil)
+	if err != nil {
+		return nil, err
+	}
+	table := &pushTable{tabler: tabler, columns: make(map[string]dal.ColumnMeta)}
+	for _, column := range columnMetas {
+		table.columns[column.Name()] = column
+		if isPrimaryKey, ok := column.PrimaryKey(); ok && isPrimaryKey {
+			table.primaryKeys = append(table.primaryKeys, column.Name())
+		}
+	}
+	if len(table.primaryKeys) == 0 {
+		return nil, errors.BadInput.New(fmt.Sprintf("table %s has no primary key to upsert rows", tableName))
+	}
+	return table, nil
+}
+
+// convertPushRow validates and converts the row to the values to be saved
+func convertPushRow(table *pushTable, row map[string]interface{}, options *PushOptions, now time.Time) (map[string]interface{}, errors.Error) {
+	values := make(map[string]interface{}, len(row))
+	for name, value := range row {
+		column, ok := table.columns[name]
+		if !ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("unknown column %s", name))
+		}
+		converted, err := convertPushValue(column, value)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = converted
+	}
+	for _, pk := range table.primaryKeys {
+		if values[pk] == nil {
+			return nil, errors.BadInput.New(fmt.Sprintf("primary key %s is required", pk))
+		}
+	}
+	stampPushProvenance(table, values, options, now)
+	// created_at is only written by inserting, the upsert leaves it as it is for the existing rows
+	if _, ok := table.columns["created_at"]; ok && values["created_at"] == nil {
+		values["created_at"] = now
+	}
+	return values, nil
+}
+
+// upsertBatch upserts the rows in one transaction, the rows existing before are counted as updated and the
+// others as inserted. All rows of the batch are reported as failed if the transaction failed.
+func upsertBatch(table *pushTable, rows []*pushRow, result *PushResult) {
+	// rows of the same primary key are merged, the later ones overwrite the columns of the earlier ones
+	merged := make([]map[string]interface{}, 0, len(rows))
+	positions := make(map[string]int, len(rows))
+	for _, row := range rows {
+		key := fmt.Sprint(pushRowPrimaryKey(table, row.values))
+		if i, ok := positions[key]; ok {
+			for name, value := range row.values {
+				merged[i][name] = value
+			}
+			continue
+		}
+		positions[key] = len(merged)
+		merged = append(merged, row.values)
+	}
+	existing, err := savePushRows(table, merged)
+	if err != nil {
+		for _, row := range rows {
+			result.Errors = append(result.Errors, &PushRowError{Index: row.index, Error: err.Error()})
+		}
+		return
+	}
+	result.RowsInserted += int64(len(merged)) - existing
+	result.RowsUpdated += existing + int64(len(rows)-len(merged))
+}
+
+// savePushRows upserts the rows in a transaction and returns the number of rows existing before
+func savePushRows(table *pushTable, rows []map[string]interface{}) (existing int64, err errors.Error) {
+	tx := db.Begin()
+	defer func() {
+		r := recover()
+		if r != nil || err != nil {
+			if e := tx.Rollback(); e != nil {
+				logger.Error(e, "UpsertRows: failed to rollback")
+			}
+		}
+		if r != nil {
+			panic(r)
+		}
+	}()
+	pkConditions := make([]string, len(table.primaryKeys))
+	for i, pk := range table.primaryKeys {
+		pkConditions[i] = pk + " = ?"
+	}
+	pkCondition := "(" + strings.Join(pkConditions, " AND ") + ")"
+	conditions := make([]string, len(rows))
+	params := make([]interface{}, 0, len(rows)*len(table.primaryKeys))
+	for i, row := range rows {
+		conditions[i] = pkCondition
+		params = append(params, pushRowPrimaryKey(table, row)...)
+	}
+	existing, err = tx.Count(dal.From(table.tabler), dal.Where(strings.Join(conditions, " OR "), params...))
 	if err != nil {
 		return 0, err
 	}
-	return 1, nil
+	// rows are upserted by groups of the same columns, so the columns absent from a row are left untouched
+	groups := make(map[string][]map[string]interface{})
+	var groupKeys []string
+	for _, row := range rows {
+		columns := make([]string, 0, len(row))
+		for name := range row {
+			columns = append(columns, name)
+		}
+		sort.Strings(columns)
+		key := strings.Join(columns, ",")
+		if _, ok := groups[key]; !ok {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+	for _, key := range groupKeys {
+		err = tx.CreateOrUpdate(groups[key], dal.From(table.tabler))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return existing, tx.Commit()
+}
+
+func pushRowPrimaryKey(table *pushTable, values map[string]interface{}) []interface{} {
+	pk := make([]interface{}, len(table.primaryKeys))
+	for i, name := range table.primaryKeys {
+		pk[i] = values[name]
+	}
+	return pk
+}
+
+func stampPushProvenance(table *pushTable, values map[string]interface{}, options *PushOptions, now time.Time) {
+	stamp := func(name string, value interface{}) {
+		if _, ok := table.columns[name]; ok {
+			values[name] = value
+		}
+	}
+	stamp("_raw_data_table", PUSH_API_RAW_DATA_TABLE)
+	stamp("_raw_data_id", 0)
+	stamp("updated_at", now)
+	if options == nil {
+		return
+	}
+	if options.RawDataParams != "" {
+		stamp("_raw_data_params", options.RawDataParams)
+	}
+	stamp("_raw_data_remark", options.Pusher)
+}
+
+// convertPushValue checks the json value against the type of the column and converts it for the database
+func convertPushValue(column dal.ColumnMeta, value interface{}) (interface{}, errors.Error) {
+	name := column.Name()
+	if value == nil {
+		if nullable, ok := column.Nullable(); ok && !nullable {
+			return nil, errors.BadInput.New(fmt.Sprintf("column %s could not be null", name))
+		}
+		return nil, nil
+	}
+	typeName := strings.ToLower(column.DatabaseTypeName())
+	switch {
+	case strings.Contains(typeName, "time") || strings.Contains(typeName, "date"):
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("column %s expects a time string", name))
+		}
+		t, err := helper.ConvertStringToTime(s)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("column %s expects a time string", name))
+		}
+		return t, nil
+	case strings.Contains(typeName, "bool"):
+		switch value.(type) {
+		case bool, float64, json.Number:
+			return value, nil
+		}
+		return nil, errors.BadInput.New(fmt.Sprintf("column %s expects a boolean", name))
+	case strings.Contains(typeName, "int") || strings.Contains(typeName, "numeric") ||
+		strings.Contains(typeName, "decimal") || strings.Contains(typeName, "float") ||
+		strings.Contains(typeName, "double") || strings.Contains(typeName, "real"):
+		switch value.(type) {
+		case bool, float64, json.Number:
+			return value, nil
+		}
+		return nil, errors.BadInput.New(fmt.Sprintf("column %s expects a number", name))
+	case strings.Contains(typeName, "char") || strings.Contains(typeName, "text"):
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64, bool, json.Number:
+			return fmt.Sprint(v), nil
+		}
+		return nil, errors.BadInput.New(fmt.Sprintf("column %s expects a string", name))
+	}
+	return value, nil
 }
@@ -0,0 +1,156 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func mockColumnMeta(name, typeName string, primaryKey, nullable bool) dal.ColumnMeta {
+	column := new(mockdal.ColumnMeta)
+	column.On("Name").Return(name).Maybe()
+	column.On("DatabaseTypeName").Return(typeName).Maybe()
+	column.On("PrimaryKey").Return(primaryKey, true).Maybe()
+	column.On("Nullable").Return(nullable, true).Maybe()
+	return column
+}
+
+func mockPushTableColumns(mockDal *mockdal.Dal) {
+	mockDal.On("GetColumns", mock.Anything, mock.Anything).Return([]dal.ColumnMeta{
+		mockColumnMeta("id", "VARCHAR", true, false),
+		mockColumnMeta("title", "VARCHAR", false, true),
+		mockColumnMeta("story_point", "DOUBLE", false, true),
+		mockColumnMeta("created_date", "DATETIME", false, true),
+		mockColumnMeta("created_at", "DATETIME", false, true),
+		mockColumnMeta("updated_at", "DATETIME", false, true),
+		mockColumnMeta("_raw_data_table", "VARCHAR", false, true),
+		mockColumnMeta("_raw_data_params", "VARCHAR", false, true),
+		mockColumnMeta("_raw_data_remark", "VARCHAR", false, true),
+	}, nil).Once()
+}
+
+func TestUpsertRows(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockPushTableColumns(mockDal)
+	tx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(tx).Once()
+	// one of the 2 valid rows exists
+	tx.On("Count", mock.Anything).Return(int64(1), nil).Once()
+	var saved []map[string]interface{}
+	tx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(0).([]map[string]interface{})...)
+	}).Return(nil).Twice()
+	tx.On("Commit").Return(nil).Once()
+	originalDb := db
+	db = mockDal
+	defer func() {
+		db = originalDb
+	}()
+
+	rows := []map[string]interface{}{
+		{"id": "jira:1", "title": "new", "story_point": json.Number("3"), "created_date": "2023-03-01T00:00:00Z"},
+		{"id": "jira:2", "title": "existing", "_raw_data_table": "faked"},
+		{"id": "jira:3", "unknown": "x"},
+		{"title": "no id"},
+		{"id": "jira:5", "story_point": "many"},
+		{"id": "jira:6", "created_date": "yesterday"},
+	}
+	result, err := UpsertRows("issues", rows, &PushOptions{RawDataParams: "csv", Pusher: "admin"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), result.RowsAffected)
+	assert.Equal(t, int64(1), result.RowsInserted)
+	assert.Equal(t, int64(1), result.RowsUpdated)
+	assert.Equal(t, []int{2, 3, 4, 5}, []int{result.Errors[0].Index, result.Errors[1].Index, result.Errors[2].Index, result.Errors[3].Index})
+
+	// rows of different columns are upserted separately, so the absent columns are not overwritten
+	assert.Len(t, saved, 2)
+	assert.Equal(t, PUSH_API_RAW_DATA_TABLE, saved[0]["_raw_data_table"])
+	assert.Equal(t, "csv", saved[0]["_raw_data_params"])
+	assert.Equal(t, "admin", saved[0]["_raw_data_remark"])
+	assert.Equal(t, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), saved[0]["created_date"])
+	assert.NotNil(t, saved[0]["created_at"])
+	assert.Equal(t, "existing", saved[1]["title"])
+	assert.Equal(t, PUSH_API_RAW_DATA_TABLE, saved[1]["_raw_data_table"])
+	assert.NotContains(t, saved[1], "story_point")
+	mockDal.AssertExpectations(t)
+	tx.AssertExpectations(t)
+}
+
+func TestUpsertRowsOfSamePrimaryKey(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockPushTableColumns(mockDal)
+	tx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(tx).Once()
+	tx.On("Count", mock.Anything).Return(int64(0), nil).Once()
+	var saved []map[string]interface{}
+	tx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).([]map[string]interface{})
+	}).Return(nil).Once()
+	tx.On("Commit").Return(nil).Once()
+	originalDb := db
+	db = mockDal
+	defer func() {
+		db = originalDb
+	}()
+
+	result, err := UpsertRows("issues", []map[string]interface{}{
+		{"id": "jira:1", "title": "first", "story_point": json.Number("3")},
+		{"id": "jira:1", "title": "second"},
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.RowsInserted)
+	assert.Equal(t, int64(1), result.RowsUpdated)
+	assert.Len(t, saved, 1)
+	assert.Equal(t, "second", saved[0]["title"])
+	assert.Equal(t, json.Number("3"), saved[0]["story_point"])
+	tx.AssertExpectations(t)
+}
+
+func TestUpsertRowsRollback(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockPushTableColumns(mockDal)
+	tx := new(mockdal.Transaction)
+	mockDal.On("Begin").Return(tx).Once()
+	tx.On("Count", mock.Anything).Return(int64(0), nil).Once()
+	tx.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(errors.Default.New("deadlock")).Once()
+	tx.On("Rollback").Return(nil).Once()
+	originalDb := db
+	db = mockDal
+	defer func() {
+		db = originalDb
+	}()
+
+	result, err := UpsertRows("issues", []map[string]interface{}{{"id": "jira:1"}, {"id": "jira:2"}}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), result.RowsAffected)
+	assert.Len(t, result.Errors, 2)
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Commit")
+}
+
+func TestUpsertRowsToNonDomainTable(t *testing.T) {
+	_, err := UpsertRows("_devlake_pipelines", []map[string]interface{}{{"id": 1}}, nil)
+	assert.NotNil(t, err)
+}
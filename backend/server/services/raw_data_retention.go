@@ -0,0 +1,308 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/md5"
+	"fmt"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/robfig/cron/v3"
+	"strings"
+	"sync"
+	"time"
+)
+
+const purgeBatchSize = 1000
+
+var retentionCron *cron.Cron
+var purgeLocker sync.Mutex
+
+// CreateRawDataRetentionPolicy accepts a retention policy and saves it
+func CreateRawDataRetentionPolicy(policy *models.RawDataRetentionPolicy) errors.Error {
+	err := validateRawDataRetentionPolicy(policy)
+	if err != nil {
+		return err
+	}
+	return db.Create(policy)
+}
+
+// GetRawDataRetentionPolicies returns all retention policies
+func GetRawDataRetentionPolicies() ([]*models.RawDataRetentionPolicy, errors.Error) {
+	policies := make([]*models.RawDataRetentionPolicy, 0)
+	err := db.All(&policies, dal.Orderby("id"))
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// GetRawDataRetentionPolicy returns the retention policy of the id
+func GetRawDataRetentionPolicy(id uint64) (*models.RawDataRetentionPolicy, errors.Error) {
+	policy := &models.RawDataRetentionPolicy{}
+	err := db.First(policy, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.Wrap(err, "retention policy not found")
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// PatchRawDataRetentionPolicy updates the retention policy with the fields in body
+func PatchRawDataRetentionPolicy(id uint64, body map[string]interface{}) (*models.RawDataRetentionPolicy, errors.Error) {
+	policy, err := GetRawDataRetentionPolicy(id)
+	if err != nil {
+		return nil, err
+	}
+	err = helper.DecodeMapStruct(body, policy)
+	if err != nil {
+		return nil, err
+	}
+	policy.ID = id
+	err = validateRawDataRetentionPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeleteRawDataRetentionPolicy deletes the retention policy, data would not be purged by it any more
+func DeleteRawDataRetentionPolicy(id uint64) errors.Error {
+	policy, err := GetRawDataRetentionPolicy(id)
+	if err != nil {
+		return err
+	}
+	return db.Delete(policy)
+}
+
+func validateRawDataRetentionPolicy(policy *models.RawDataRetentionPolicy) errors.Error {
+	err := VerifyStruct(policy)
+	if err != nil {
+		return err
+	}
+	if policy.RawTable != "" && !strings.HasPrefix(policy.RawTable, "_raw_") {
+		return errors.BadInput.New("rawTable should be a table starts with _raw_")
+	}
+	if policy.RetentionDays == 0 && !policy.KeepLatestOnly {
+		return errors.BadInput.New("either retentionDays or keepLatestOnly should be set")
+	}
+	return nil
+}
+
+// PurgeRawData deletes data of raw tables according to the enabled retention policies,
+// the remaining data is left as it was so extractors could still extract them again, and the collector states of
+// scopes losing data by retention days are reset so they would be fully collected next time
+func PurgeRawData(dryRun bool) (*DeletionReport, errors.Error) {
+	if !purgeLocker.TryLock() {
+		return nil, errors.BadInput.New("raw data is being purged")
+	}
+	defer purgeLocker.Unlock()
+
+	policies := make([]*models.RawDataRetentionPolicy, 0)
+	err := db.All(&policies, dal.Where("enable = ?", true))
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return NewDeletionReport(dryRun), nil
+	}
+	tables, err := db.AllTables()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	report := NewDeletionReport(dryRun)
+	purgedRows := make(map[uint64]int64)
+	for _, table := range tables {
+		if !strings.HasPrefix(table, "_raw_") {
+			continue
+		}
+		policy := getRawDataRetentionPolicy(table, policies)
+		if policy == nil {
+			continue
+		}
+		err = purgeRawTable(report, table, policy, now)
+		if err != nil {
+			return nil, err
+		}
+		purgedRows[policy.ID] += report.Tables[table]
+	}
+	if dryRun {
+		return report, nil
+	}
+	for _, policy := range policies {
+		err = db.UpdateColumns(policy, []dal.DalSet{
+			{ColumnName: "last_purged_at", Value: now},
+			{ColumnName: "last_purged_rows", Value: purgedRows[policy.ID]},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// getRawDataRetentionPolicy returns the most specific policy of the table
+func getRawDataRetentionPolicy(table string, policies []*models.RawDataRetentionPolicy) *models.RawDataRetentionPolicy {
+	var matched *models.RawDataRetentionPolicy
+	matchedRank := 0
+	for _, policy := range policies {
+		rank := 0
+		switch {
+		case policy.RawTable != "":
+			if policy.RawTable == table {
+				rank = 3
+			}
+		case policy.Plugin != "":
+			if strings.HasPrefix(table, fmt.Sprintf("_raw_%s_", policy.Plugin)) {
+				rank = 2
+			}
+		default:
+			rank = 1
+		}
+		if rank > matchedRank {
+			matched, matchedRank = policy, rank
+		}
+	}
+	return matched
+}
+
+func purgeRawTable(report *DeletionReport, table string, policy *models.RawDataRetentionPolicy, now time.Time) errors.Error {
+	var cutoff time.Time
+	if policy.RetentionDays > 0 {
+		cutoff = now.AddDate(0, 0, -policy.RetentionDays)
+		// the collectors of the scopes losing raw data have to collect them again, otherwise the records would
+		// disappear from tool and domain layer tables after the next extraction since the incremental collection
+		// only fetches the recent changes. The states are reset before deleting the raw data, so a failure in
+		// between leads to a full collection rather than to losing records
+		var allParams []string
+		err := db.Pluck("DISTINCT params", &allParams, dal.From(table), dal.Where("created_at < ?", cutoff))
+		if err != nil {
+			return err
+		}
+		for start := 0; start < len(allParams); start += purgeBatchSize {
+			end := start + purgeBatchSize
+			if end > len(allParams) {
+				end = len(allParams)
+			}
+			err = report.deleteRows(
+				db,
+				models.CollectorLatestState{}.TableName(),
+				&models.CollectorLatestState{},
+				dal.Where("raw_data_table = ? AND raw_data_params IN ?", table, allParams[start:end]),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		err = report.deleteRows(db, table, &helper.RawData{}, dal.Where("created_at < ?", cutoff))
+		if err != nil {
+			return err
+		}
+	}
+	if !policy.KeepLatestOnly {
+		return nil
+	}
+	ids, err := getSupersededRawDataIds(table, cutoff)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(ids); start += purgeBatchSize {
+		end := start + purgeBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		err = report.deleteRows(db, table, &helper.RawData{}, dal.Where("id IN ?", ids[start:end]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSupersededRawDataIds returns ids of rows collected since `since` but superseded by a later response
+// of the same request (url and input) with the same params
+func getSupersededRawDataIds(table string, since time.Time) ([]uint64, errors.Error) {
+	cursor, err := db.Cursor(
+		dal.Select("id, params, url, input"),
+		dal.From(table),
+		dal.Where("created_at >= ?", since),
+		dal.Orderby("id DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	seen := make(map[[md5.Size]byte]bool)
+	var ids []uint64
+	for cursor.Next() {
+		row := &helper.RawData{}
+		err = db.Fetch(cursor, row)
+		if err != nil {
+			return nil, err
+		}
+		key := getRawDataRequestKey(row)
+		if seen[key] {
+			ids = append(ids, row.ID)
+		} else {
+			seen[key] = true
+		}
+	}
+	return ids, nil
+}
+
+func getRawDataRequestKey(row *helper.RawData) [md5.Size]byte {
+	return md5.Sum([]byte(fmt.Sprintf("%s\x00%s\x00%s", row.Params, row.Url, row.Input)))
+}
+
+// initRawDataRetention schedules the purging of raw data by RAW_DATA_RETENTION_CRON, it is disabled by default
+func initRawDataRetention() {
+	spec := cfg.GetString("RAW_DATA_RETENTION_CRON")
+	if spec == "" {
+		logger.Info("raw data retention is disabled")
+		return
+	}
+	retentionCron = cron.New(cron.WithLocation(time.UTC))
+	_, err := retentionCron.AddFunc(spec, func() {
+		report, err := PurgeRawData(false)
+		if err != nil {
+			logger.Error(err, "failed to purge raw data")
+			return
+		}
+		var total int64
+		for table, rows := range report.Tables {
+			logger.Info("purged %d rows of %s", rows, table)
+			total += rows
+		}
+		logger.Info("purged %d rows of raw data in total", total)
+	})
+	if err != nil {
+		panic(errors.BadInput.Wrap(err, "invalid RAW_DATA_RETENTION_CRON"))
+	}
+	retentionCron.Start()
+}
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestGetRawDataRetentionPolicy(t *testing.T) {
+	global := &models.RawDataRetentionPolicy{RetentionDays: 365}
+	github := &models.RawDataRetentionPolicy{Plugin: "github", RetentionDays: 90}
+	githubIssues := &models.RawDataRetentionPolicy{RawTable: "_raw_github_api_issues", KeepLatestOnly: true}
+	jira := &models.RawDataRetentionPolicy{Plugin: "jira", RetentionDays: 30}
+
+	policies := []*models.RawDataRetentionPolicy{global, github, githubIssues, jira}
+	assert.Equal(t, githubIssues, getRawDataRetentionPolicy("_raw_github_api_issues", policies))
+	assert.Equal(t, github, getRawDataRetentionPolicy("_raw_github_api_pull_requests", policies))
+	assert.Equal(t, jira, getRawDataRetentionPolicy("_raw_jira_api_boards", policies))
+	assert.Equal(t, global, getRawDataRetentionPolicy("_raw_gitlab_api_projects", policies))
+	// plugin names are matched as a whole
+	assert.Equal(t, global, getRawDataRetentionPolicy("_raw_githubgraphql_api_issues", policies))
+
+	assert.Nil(t, getRawDataRetentionPolicy("_raw_gitlab_api_projects", []*models.RawDataRetentionPolicy{github}))
+}
+
+func TestGetRawDataRequestKey(t *testing.T) {
+	row := &helper.RawData{Params: `{"ConnectionId":1}`, Url: "https://api.github.com/repos/a/b/issues/1", Input: []byte(`{"Number":1}`)}
+	same := &helper.RawData{ID: 2, Params: row.Params, Url: row.Url, Input: row.Input, Data: []byte("newer")}
+	otherInput := &helper.RawData{Params: row.Params, Url: row.Url, Input: []byte(`{"Number":2}`)}
+	otherParams := &helper.RawData{Params: `{"ConnectionId":2}`, Url: row.Url, Input: row.Input}
+
+	assert.Equal(t, getRawDataRequestKey(row), getRawDataRequestKey(same))
+	assert.NotEqual(t, getRawDataRequestKey(row), getRawDataRequestKey(otherInput))
+	assert.NotEqual(t, getRawDataRequestKey(row), getRawDataRequestKey(otherParams))
+}
+
+func TestPurgeRawTableByRetentionDays(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("Pluck", "DISTINCT params", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]string) = []string{`{"ConnectionId":1,"BoardId":8}`}
+	}).Return(nil).Once()
+	// the collector state of the scope losing data is reset before the raw data is deleted
+	var deleted []string
+	mockDal.On("Count", mock.Anything).Return(int64(1), nil).Once()
+	mockDal.On("Delete", mock.AnythingOfType("*models.CollectorLatestState"), mock.Anything).Run(func(args mock.Arguments) {
+		deleted = append(deleted, "state")
+	}).Return(nil).Once()
+	mockDal.On("Count", mock.Anything).Return(int64(42), nil).Once()
+	mockDal.On("Delete", mock.AnythingOfType("*api.RawData"), mock.Anything).Run(func(args mock.Arguments) {
+		deleted = append(deleted, "raw")
+	}).Return(nil).Once()
+	originalDb := db
+	db = mockDal
+	defer func() {
+		db = originalDb
+	}()
+
+	report := NewDeletionReport(false)
+	policy := &models.RawDataRetentionPolicy{RetentionDays: 90}
+	err := purgeRawTable(report, "_raw_jira_api_issues", policy, time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"_raw_jira_api_issues": 42, "_devlake_collector_latest_state": 1}, report.Tables)
+	assert.Equal(t, []string{"state", "raw"}, deleted)
+	mockDal.AssertNotCalled(t, "Cursor", mock.Anything)
+	mockDal.AssertExpectations(t)
+}
@@ -53,15 +53,24 @@ func CreateTask(newTask *models.NewTask) (*models.Task, errors.Error) {
 		return nil, errors.Convert(err)
 	}
 
+	var completedSubtasks []byte
+	if len(newTask.CompletedSubtasks) > 0 {
+		completedSubtasks, err = json.Marshal(newTask.CompletedSubtasks)
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+	}
+
 	task := &models.Task{
-		Plugin:      newTask.Plugin,
-		Subtasks:    s,
-		Options:     string(b),
-		Status:      models.TASK_CREATED,
-		Message:     "",
-		PipelineId:  newTask.PipelineId,
-		PipelineRow: newTask.PipelineRow,
-		PipelineCol: newTask.PipelineCol,
+		Plugin:            newTask.Plugin,
+		Subtasks:          s,
+		Options:           string(b),
+		Status:            models.TASK_CREATED,
+		Message:           "",
+		PipelineId:        newTask.PipelineId,
+		PipelineRow:       newTask.PipelineRow,
+		PipelineCol:       newTask.PipelineCol,
+		CompletedSubtasks: completedSubtasks,
 	}
 	if newTask.IsRerun {
 		task.Status = models.TASK_RERUN
@@ -230,3 +239,16 @@ func RerunTask(taskId uint64) (*models.Task, errors.Error) {
 	}
 	return rerunTasks[0], nil
 }
+
+// ResumeTask resumes specified task from the first subtask not completed
+func ResumeTask(taskId uint64) (*models.Task, errors.Error) {
+	task, err := GetTask(taskId)
+	if err != nil {
+		return nil, err
+	}
+	resumedTasks, err := ResumePipeline(task.PipelineId, task)
+	if err != nil {
+		return nil, err
+	}
+	return resumedTasks[0], nil
+}
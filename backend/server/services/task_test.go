@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockResumeDal(t *testing.T, pipelineStatus string) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	originalDb, originalBasicRes := db, basicRes
+	db, basicRes = mockDal, mockRes
+	t.Cleanup(func() {
+		db, basicRes = originalDb, originalBasicRes
+	})
+	mockDal.On("First", mock.AnythingOfType("*models.Pipeline"), mock.Anything).Run(func(args mock.Arguments) {
+		pipeline := args.Get(0).(*models.Pipeline)
+		pipeline.ID = 7
+		pipeline.Status = pipelineStatus
+	}).Return(nil)
+	return mockDal
+}
+
+func TestResumeTask(t *testing.T) {
+	mockDal := mockResumeDal(t, models.TASK_FAILED)
+	mockDal.On("First", mock.AnythingOfType("*models.Task"), mock.Anything).Run(func(args mock.Arguments) {
+		task := args.Get(0).(*models.Task)
+		task.ID = 2
+		task.PipelineId = 7
+		task.Plugin = "jira"
+		task.Subtasks = []byte(`["collectIssues","extractIssues","convertIssues"]`)
+		task.Options = `{"connectionId":1,"boardId":8}`
+		task.Status = models.TASK_FAILED
+		// the task resumed another one before
+		task.CompletedSubtasks = []byte(`["collectIssues"]`)
+	}).Return(nil).Once()
+	// labels of the pipeline
+	mockDal.On("Pluck", "name", mock.Anything, mock.Anything).Return(nil).Twice()
+	// subtasks completed by the task
+	mockDal.On("Pluck", "name", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]string) = []string{"extractIssues"}
+	}).Return(nil).Once()
+	mockDal.On("UpdateColumn", mock.AnythingOfType("*models.Task"), "status", models.TASK_FAILED, mock.Anything).Return(nil).Once()
+	var created *models.Task
+	mockDal.On("Create", mock.AnythingOfType("*models.Task"), mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*models.Task)
+	}).Return(nil).Once()
+	mockDal.On("UpdateColumn", mock.AnythingOfType("*models.Pipeline"), "status", models.TASK_RERUN, mock.Anything).Return(nil).Once()
+
+	task, err := ResumeTask(2)
+	assert.Nil(t, err)
+	assert.Equal(t, created, task)
+	assert.Equal(t, models.TASK_RERUN, task.Status)
+	assert.Equal(t, uint64(7), task.PipelineId)
+	completedSubtasks, err := task.GetCompletedSubtasks()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"collectIssues", "extractIssues"}, completedSubtasks)
+	subtasks, err := task.GetSubTasks()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"collectIssues", "extractIssues", "convertIssues"}, subtasks)
+	mockDal.AssertExpectations(t)
+}
+
+func TestResumePipelineRejected(t *testing.T) {
+	mockDal := mockResumeDal(t, models.TASK_RUNNING)
+	mockDal.On("Pluck", "name", mock.Anything, mock.Anything).Return(nil)
+	_, err := ResumePipeline(7, nil)
+	assert.Equal(t, errors.BadInput, err.GetType())
+
+	mockDal = mockResumeDal(t, models.TASK_FAILED)
+	mockDal.On("Pluck", "name", mock.Anything, mock.Anything).Return(nil)
+	task := &models.Task{PipelineId: 8}
+	_, err = ResumePipeline(7, task)
+	assert.Equal(t, errors.BadInput, err.GetType())
+	mockDal.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}